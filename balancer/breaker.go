@@ -0,0 +1,160 @@
+package balancer
+
+import (
+	"sync"
+	"time"
+)
+
+type BreakerState int
+
+const (
+	Closed   BreakerState = iota // 正常转发
+	Open                         // 熔断，直接拒绝
+	HalfOpen                     // 放少量探测请求，看上游是否恢复
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	default:
+		return "half-open"
+	}
+}
+
+type BreakerOptions struct {
+	Window       int           // 统计最近多少次请求的结果，默认 20
+	MinRequests  int           // 窗口内至少有这么多次请求才会熔断，默认 10
+	FailureRatio float64       // 失败比例达到它就熔断，默认 0.5
+	OpenFor      time.Duration // 熔断多久后进入半开，默认 5s
+	Probes       int           // 半开时放行的探测请求数，全部成功才关闭，默认 1
+}
+
+func (o *BreakerOptions) defaults() {
+	if o.Window <= 0 {
+		o.Window = 20
+	}
+	if o.MinRequests <= 0 {
+		o.MinRequests = min(10, o.Window)
+	}
+	if o.FailureRatio <= 0 {
+		o.FailureRatio = 0.5
+	}
+	if o.OpenFor <= 0 {
+		o.OpenFor = 5 * time.Second
+	}
+	if o.Probes <= 0 {
+		o.Probes = 1
+	}
+}
+
+// Breaker 按失败比例熔断：关闭时用环形缓冲区记录最近 Window 次结果，
+// 失败比例过高就打开；打开 OpenFor 后半开，探测成功则关闭，失败则重新打开。
+type Breaker struct {
+	mu       sync.Mutex
+	opts     BreakerOptions
+	state    BreakerState
+	outcomes []bool // 环形缓冲区，true 表示失败
+	next     int
+	n        int // 缓冲区中的结果数
+	failures int
+	openedAt time.Time
+	probes   int // 半开时已放行的探测数
+	passed   int // 半开时已成功的探测数
+}
+
+func NewBreaker(opts BreakerOptions) *Breaker {
+	opts.defaults()
+	return &Breaker{opts: opts, outcomes: make([]bool, opts.Window)}
+}
+
+func (b *Breaker) State(now time.Time) BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	return b.state
+}
+
+func (b *Breaker) advance(now time.Time) {
+	if b.state == Open && now.Sub(b.openedAt) >= b.opts.OpenFor {
+		b.state, b.probes, b.passed = HalfOpen, 0, 0
+	}
+}
+
+// Ready 报告现在是否可能放行，不占用半开时的探测名额。
+func (b *Breaker) Ready(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	return b.state == Closed || b.state == HalfOpen && b.probes < b.opts.Probes
+}
+
+// Allow 报告这次请求能否发出；返回 true 后必须调用 Record 报告结果，或者用 Release 放弃。
+func (b *Breaker) Allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(now)
+	switch b.state {
+	case Closed:
+		return true
+	case HalfOpen:
+		if b.probes < b.opts.Probes {
+			b.probes++
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Breaker) Record(now time.Time, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case HalfOpen:
+		if !ok {
+			b.trip(now)
+			return
+		}
+		b.passed++
+		if b.passed >= b.opts.Probes {
+			b.state = Closed
+			b.reset()
+		}
+	case Closed:
+		if b.n == len(b.outcomes) && b.outcomes[b.next] {
+			b.failures--
+		}
+		b.outcomes[b.next] = !ok
+		b.next = (b.next + 1) % len(b.outcomes)
+		b.n = min(b.n+1, len(b.outcomes))
+		if !ok {
+			b.failures++
+		}
+		if b.n >= b.opts.MinRequests && float64(b.failures) >= b.opts.FailureRatio*float64(b.n) {
+			b.trip(now)
+		}
+	}
+	// 打开期间返回的结果属于熔断前发出的请求，忽略。
+}
+
+// Release 放弃一次 Allow 放行的请求而不记录结果，例如客户端在上游响应前断开。
+// 半开时归还探测名额，让下一个请求去探测。
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == HalfOpen && b.probes > b.passed {
+		b.probes--
+	}
+}
+
+func (b *Breaker) trip(now time.Time) {
+	b.state, b.openedAt = Open, now
+	b.reset()
+}
+
+func (b *Breaker) reset() {
+	clear(b.outcomes)
+	b.next, b.n, b.failures = 0, 0, 0
+}
@@ -0,0 +1,73 @@
+package balancer
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// CheckHealth 并发检查所有上游一次，全部完成后返回。
+func (p *Pool) CheckHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, u := range p.upstreams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.recordCheck(u, p.probe(ctx, u))
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Pool) probe(ctx context.Context, u *Upstream) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.URL.JoinPath(p.opts.Health.Path).String(), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode >= 200 && resp.StatusCode < 300
+}
+
+func (p *Pool) recordCheck(u *Upstream, ok bool) {
+	h := p.opts.Health
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ok {
+		u.checkStreak = max(u.checkStreak, 0) + 1
+		if !u.healthy && u.checkStreak >= h.Healthy {
+			u.healthy = true
+		}
+	} else {
+		u.checkStreak = min(u.checkStreak, 0) - 1
+		if u.healthy && -u.checkStreak >= h.Unhealthy {
+			u.healthy = false
+		}
+	}
+}
+
+// StartHealthChecks 每隔 Health.Interval 检查一次，返回停止函数。
+func (p *Pool) StartHealthChecks() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := p.opts.Clock.NewTicker(p.opts.Health.Interval)
+		defer ticker.Stop()
+		for {
+			p.CheckHealth(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
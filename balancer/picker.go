@@ -0,0 +1,176 @@
+package balancer
+
+import (
+	"cmp"
+	"hash/crc32"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// Picker 从可用的上游中选一个。candidates 非空，顺序与创建 Pool 时一致。
+type Picker interface {
+	Pick(r *http.Request, candidates []*Upstream) *Upstream
+}
+
+// RoundRobin 轮询。
+func RoundRobin() Picker {
+	return &roundRobin{}
+}
+
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+func (p *roundRobin) Pick(_ *http.Request, candidates []*Upstream) *Upstream {
+	return candidates[(p.next.Add(1)-1)%uint64(len(candidates))]
+}
+
+// WeightedRoundRobin 平滑加权轮询（nginx 的做法）：每轮每个上游的当前权重加上自身权重，
+// 选当前权重最大的，再把它减去总权重。权重 5:1:1 时得到 a a b a c a a，而不是 a a a a a b c。
+func WeightedRoundRobin() Picker {
+	return &weightedRoundRobin{current: map[*Upstream]int{}}
+}
+
+type weightedRoundRobin struct {
+	mu      sync.Mutex
+	current map[*Upstream]int
+}
+
+func (p *weightedRoundRobin) Pick(_ *http.Request, candidates []*Upstream) *Upstream {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var best *Upstream
+	total := 0
+	for _, u := range candidates {
+		p.current[u] += u.Weight
+		total += u.Weight
+		if best == nil || p.current[u] > p.current[best] {
+			best = u
+		}
+	}
+	p.current[best] -= total
+	return best
+}
+
+// LeastConn 选进行中请求数除以权重最小的上游，相同时轮流选，避免总是压在第一个上。
+func LeastConn() Picker {
+	return &leastConn{}
+}
+
+type leastConn struct {
+	next atomic.Uint64
+}
+
+func (p *leastConn) Pick(_ *http.Request, candidates []*Upstream) *Upstream {
+	start := int(p.next.Add(1) % uint64(len(candidates)))
+	var best *Upstream
+	for i := range candidates {
+		u := candidates[(start+i)%len(candidates)]
+		if best == nil || less(u, best) {
+			best = u
+		}
+	}
+	return best
+}
+
+// less 比较 a.Active()/a.Weight < b.Active()/b.Weight，交叉相乘避免浮点。
+func less(a, b *Upstream) bool {
+	return a.Active()*int64(b.Weight) < b.Active()*int64(a.Weight)
+}
+
+// PowerOfTwo 随机选两个，取负载较轻的一个：比 LeastConn 少了全量扫描，
+// 又不会让所有请求同时涌向同一个“最空闲”的上游。
+func PowerOfTwo() Picker {
+	return powerOfTwo{}
+}
+
+type powerOfTwo struct{}
+
+func (powerOfTwo) Pick(_ *http.Request, candidates []*Upstream) *Upstream {
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	i := rand.IntN(len(candidates))
+	j := rand.IntN(len(candidates) - 1)
+	if j >= i {
+		j++
+	}
+	a, b := candidates[i], candidates[j]
+	if less(b, a) {
+		return b
+	}
+	return a
+}
+
+// ConsistentHash 一致性哈希：每个上游在环上放 replicas 个虚拟节点（按权重倍增），
+// 请求按 key 的哈希顺时针找到第一个可用上游的节点。环包含见过的所有上游，不可用的上游的节点直接跳过，
+// 上游暂时不可用时只有它的键会换到顺时针的下一个上游，恢复后又回到原处。
+// 适合让同一个用户的请求尽量落在同一台机器上（本地缓存、会话）。
+func ConsistentHash(key func(*http.Request) string, replicas int) Picker {
+	if replicas <= 0 {
+		replicas = 100
+	}
+	return &consistentHash{key: key, replicas: replicas, members: map[*Upstream]bool{}}
+}
+
+type consistentHash struct {
+	key      func(*http.Request) string
+	replicas int
+
+	mu      sync.Mutex
+	members map[*Upstream]bool // 环上的上游，出现新的上游时才重建
+	ring    []ringNode
+}
+
+type ringNode struct {
+	hash     uint32
+	upstream *Upstream
+}
+
+func (p *consistentHash) Pick(r *http.Request, candidates []*Upstream) *Upstream {
+	h := crc32.ChecksumIEEE([]byte(p.key(r)))
+	ring := p.ringFor(candidates)
+	i, _ := slices.BinarySearchFunc(ring, h, func(n ringNode, h uint32) int {
+		return cmp.Compare(n.hash, h)
+	})
+	// 候选集通常只有几个，线性查找比建集合更快
+	for k := range ring {
+		if n := ring[(i+k)%len(ring)]; slices.Contains(candidates, n.upstream) {
+			return n.upstream
+		}
+	}
+	return candidates[0] // 不会发生：候选都在环上
+}
+
+// ringFor 返回包含所有候选的环。环建好后不再修改，只在出现新上游时整个替换，所以可以在锁外读。
+func (p *consistentHash) ringFor(candidates []*Upstream) []ringNode {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	added := false
+	for _, u := range candidates {
+		if !p.members[u] {
+			p.members[u] = true
+			added = true
+		}
+	}
+	if !added {
+		return p.ring
+	}
+	// 虚拟节点的位置只取决于上游名字，加入新上游不会移动已有上游的节点。
+	ring := make([]ringNode, 0, len(p.ring)+len(candidates)*p.replicas)
+	for u := range p.members {
+		for i := range p.replicas * u.Weight {
+			ring = append(ring, ringNode{crc32.ChecksumIEEE([]byte(u.Name + "#" + strconv.Itoa(i))), u})
+		}
+	}
+	// 哈希相同时按名字排，环与 members 的遍历顺序无关
+	slices.SortFunc(ring, func(a, b ringNode) int {
+		return cmp.Or(cmp.Compare(a.hash, b.hash), cmp.Compare(a.upstream.Name, b.upstream.Name))
+	})
+	p.ring = ring
+	return ring
+}
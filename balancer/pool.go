@@ -0,0 +1,371 @@
+/**
+ * 负载均衡：把请求分发给一组上游服务器，支持轮询、加权轮询、最少连接、一致性哈希和 P2C 五种选择策略，
+ * 主动健康检查、被动异常摘除（连续失败后暂时移出）以及每个上游独立的熔断器。
+ * Pool 本身是 http.Handler，可以直接作为反向代理某条路由的上游。
+ */
+package balancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"leetcode-go/cache"
+)
+
+// Target 上游地址，Weight 默认为 1。
+type Target struct {
+	Name   string
+	URL    string
+	Weight int
+}
+
+type Options struct {
+	Timeout time.Duration // 单次转发的超时，0 表示不限
+	// Retries 连接失败或超时时，GET/HEAD 请求换一个上游重试的次数。
+	// 已经收到上游响应（哪怕是 5xx）的请求不会重试。
+	Retries   int
+	Breaker   BreakerOptions
+	Outlier   OutlierOptions
+	Health    HealthOptions
+	Transport http.RoundTripper // 默认 http.DefaultTransport
+	Clock     cache.Clock       // 默认 cache.SystemClock，测试时换成 cache.FakeClock
+}
+
+// OutlierOptions 被动异常摘除：上游连续失败（连接错误、超时、5xx）达到阈值就暂时移出，
+// 第 n 次摘除持续 n*BaseEjection，最多 MaxEjection。
+type OutlierOptions struct {
+	ConsecutiveFailures int           // 默认 5
+	BaseEjection        time.Duration // 默认 30s
+	MaxEjection         time.Duration // 默认 5m
+	MaxEjectedPercent   int           // 最多同时摘除的上游比例，默认 50，避免全部摘除后无处可发
+}
+
+// HealthOptions 主动健康检查：定期 GET 每个上游的 Path，2xx 算成功，
+// 连续 Unhealthy 次失败标记为不健康，连续 Healthy 次成功恢复。
+type HealthOptions struct {
+	Path      string        // 默认 "/healthz"
+	Interval  time.Duration // 默认 10s
+	Timeout   time.Duration // 默认 1s
+	Healthy   int           // 默认 2
+	Unhealthy int           // 默认 2
+}
+
+func (o *Options) defaults() {
+	if o.Transport == nil {
+		o.Transport = http.DefaultTransport
+	}
+	if o.Clock == nil {
+		o.Clock = cache.SystemClock
+	}
+	out := &o.Outlier
+	if out.ConsecutiveFailures <= 0 {
+		out.ConsecutiveFailures = 5
+	}
+	if out.BaseEjection <= 0 {
+		out.BaseEjection = 30 * time.Second
+	}
+	if out.MaxEjection <= 0 {
+		out.MaxEjection = 5 * time.Minute
+	}
+	if out.MaxEjectedPercent <= 0 {
+		out.MaxEjectedPercent = 50
+	}
+	h := &o.Health
+	if h.Path == "" {
+		h.Path = "/healthz"
+	}
+	if h.Interval <= 0 {
+		h.Interval = 10 * time.Second
+	}
+	if h.Timeout <= 0 {
+		h.Timeout = time.Second
+	}
+	if h.Healthy <= 0 {
+		h.Healthy = 2
+	}
+	if h.Unhealthy <= 0 {
+		h.Unhealthy = 2
+	}
+}
+
+// Upstream 一个上游服务器。
+type Upstream struct {
+	Name   string
+	URL    *url.URL
+	Weight int
+
+	proxy    *httputil.ReverseProxy
+	breaker  *Breaker
+	active   atomic.Int64
+	requests atomic.Int64
+	failures atomic.Int64
+
+	// 以下字段由 Pool.mu 保护
+	healthy      bool
+	checkStreak  int // 主动检查：>0 为连续成功次数，<0 为连续失败次数
+	failStreak   int // 被动：连续失败次数
+	ejections    int
+	ejectedUntil time.Time
+}
+
+// Active 进行中的请求数。
+func (u *Upstream) Active() int64 {
+	return u.active.Load()
+}
+
+// Pool 一组上游服务器。
+type Pool struct {
+	upstreams []*Upstream
+	picker    Picker
+	opts      Options
+	client    *http.Client
+
+	mu sync.Mutex
+}
+
+// errKey 在请求的 context 中放一个 *error，ReverseProxy 出错时写进去而不是直接返回 502，以便重试。
+type errKey struct{}
+
+func New(targets []Target, picker Picker, opts Options) (*Pool, error) {
+	if len(targets) == 0 {
+		return nil, errors.New("balancer: no targets")
+	}
+	opts.defaults()
+	p := &Pool{
+		picker: picker,
+		opts:   opts,
+		client: &http.Client{Transport: opts.Transport, Timeout: opts.Health.Timeout},
+	}
+	for _, t := range targets {
+		target, err := url.Parse(t.URL)
+		if err != nil {
+			return nil, fmt.Errorf("balancer: target %s: %w", t.Name, err)
+		}
+		if t.Name == "" {
+			t.Name = target.Host
+		}
+		u := &Upstream{
+			Name:    t.Name,
+			URL:     target,
+			Weight:  max(t.Weight, 1),
+			breaker: NewBreaker(opts.Breaker),
+			healthy: true,
+		}
+		u.proxy = &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(target)
+				r.SetXForwarded()
+			},
+			Transport: opts.Transport,
+			ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+				if dst, ok := r.Context().Value(errKey{}).(*error); ok {
+					*dst = err
+					return
+				}
+				http.Error(w, err.Error(), http.StatusBadGateway)
+			},
+		}
+		p.upstreams = append(p.upstreams, u)
+	}
+	return p, nil
+}
+
+func (p *Pool) Upstreams() []*Upstream {
+	return p.upstreams
+}
+
+func (p *Pool) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	retries := 0
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		retries = p.opts.Retries
+	}
+	var tried []*Upstream
+	var lastErr error
+	for {
+		u := p.pick(r, &tried)
+		if u == nil {
+			break
+		}
+		err := p.forward(w, r, u)
+		// 客户端已经断开时不再重试，也没有人接收错误响应
+		if err == nil || r.Context().Err() != nil {
+			return
+		}
+		lastErr = err
+		if retries == 0 {
+			break
+		}
+		retries--
+	}
+
+	switch {
+	case lastErr == nil:
+		http.Error(w, "no available upstream", http.StatusServiceUnavailable)
+	case errors.Is(lastErr, context.DeadlineExceeded):
+		http.Error(w, "upstream timeout", http.StatusGatewayTimeout)
+	default:
+		http.Error(w, "upstream unavailable: "+lastErr.Error(), http.StatusBadGateway)
+	}
+}
+
+// pick 在健康、未被摘除、熔断器放行且这次请求还没试过的上游中选一个，没有则返回 nil。
+func (p *Pool) pick(r *http.Request, tried *[]*Upstream) *Upstream {
+	for {
+		now := p.opts.Clock.Now()
+		var candidates []*Upstream
+		p.mu.Lock()
+		for _, u := range p.upstreams {
+			if u.healthy && !now.Before(u.ejectedUntil) && !slices.Contains(*tried, u) && u.breaker.Ready(now) {
+				candidates = append(candidates, u)
+			}
+		}
+		p.mu.Unlock()
+		if len(candidates) == 0 {
+			return nil
+		}
+		u := p.picker.Pick(r, candidates)
+		*tried = append(*tried, u)
+		// Ready 之后半开的探测名额可能已被别的请求占用
+		if u.breaker.Allow(now) {
+			return u
+		}
+	}
+}
+
+// forward 把请求转发给 u，返回连接错误或超时；收到上游响应时返回 nil，即使是 5xx。
+// 客户端中途取消的请求说明不了上游的好坏，不计入统计、熔断器和异常检测。
+func (p *Pool) forward(w http.ResponseWriter, r *http.Request, u *Upstream) error {
+	ctx := r.Context()
+	if p.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.opts.Timeout)
+		defer cancel()
+	}
+	var proxyErr error
+	ctx = context.WithValue(ctx, errKey{}, &proxyErr)
+
+	sw := &statusWriter{ResponseWriter: w}
+	u.active.Add(1)
+	func() {
+		defer u.active.Add(-1)
+		u.proxy.ServeHTTP(sw, r.WithContext(ctx))
+	}()
+
+	if r.Context().Err() != nil {
+		u.breaker.Release()
+		return proxyErr
+	}
+	ok := proxyErr == nil && sw.status < 500
+	u.requests.Add(1)
+	if !ok {
+		u.failures.Add(1)
+	}
+	now := p.opts.Clock.Now()
+	u.breaker.Record(now, ok)
+	p.observe(u, now, ok)
+	return proxyErr
+}
+
+// observe 被动异常检测。
+func (p *Pool) observe(u *Upstream, now time.Time, ok bool) {
+	out := p.opts.Outlier
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ok {
+		u.failStreak = 0
+		// 恢复后稳定了足够久，摘除时长重新从 BaseEjection 算起
+		if u.ejections > 0 && now.Sub(u.ejectedUntil) >= out.MaxEjection {
+			u.ejections = 0
+		}
+		return
+	}
+	u.failStreak++
+	if u.failStreak < out.ConsecutiveFailures || now.Before(u.ejectedUntil) {
+		return
+	}
+	ejected := 0
+	for _, v := range p.upstreams {
+		if now.Before(v.ejectedUntil) {
+			ejected++
+		}
+	}
+	if (ejected+1)*100 > out.MaxEjectedPercent*len(p.upstreams) {
+		return
+	}
+	u.ejections++
+	u.failStreak = 0
+	u.ejectedUntil = now.Add(min(out.BaseEjection*time.Duration(u.ejections), out.MaxEjection))
+}
+
+// statusWriter 记录上游响应的状态码。
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Status 上游的当前状态，用于展示和调试。
+type Status struct {
+	Name     string
+	Healthy  bool
+	Ejected  bool
+	Breaker  BreakerState
+	Active   int64
+	Requests int64
+	Failures int64
+}
+
+func (s Status) String() string {
+	state := "up"
+	switch {
+	case !s.Healthy:
+		state = "down"
+	case s.Ejected:
+		state = "ejected"
+	}
+	return fmt.Sprintf("%s: %s, breaker %s, %d requests, %d failures", s.Name, state, s.Breaker, s.Requests, s.Failures)
+}
+
+func (p *Pool) Status() []Status {
+	now := p.opts.Clock.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var res []Status
+	for _, u := range p.upstreams {
+		res = append(res, Status{
+			Name:     u.Name,
+			Healthy:  u.healthy,
+			Ejected:  now.Before(u.ejectedUntil),
+			Breaker:  u.breaker.State(now),
+			Active:   u.Active(),
+			Requests: u.requests.Load(),
+			Failures: u.failures.Load(),
+		})
+	}
+	return res
+}
@@ -0,0 +1,451 @@
+package balancer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"leetcode-go/cache"
+)
+
+func newFakeClock() *cache.FakeClock {
+	return cache.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// backend 可以变慢或失败的上游，响应体是它的名字。
+type backend struct {
+	*httptest.Server
+	name    string
+	delay   atomic.Int64 // time.Duration
+	failing atomic.Bool
+	hits    atomic.Int64
+}
+
+func newBackend(t *testing.T, name string) *backend {
+	b := &backend{name: name}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.hits.Add(1)
+		select {
+		case <-time.After(time.Duration(b.delay.Load())):
+		case <-r.Context().Done():
+			return
+		}
+		if b.failing.Load() {
+			http.Error(w, name+" failing", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, name)
+	}))
+	t.Cleanup(b.Close)
+	return b
+}
+
+func newPool(t *testing.T, picker Picker, opts Options, backends ...*backend) *Pool {
+	var targets []Target
+	for _, b := range backends {
+		targets = append(targets, Target{Name: b.name, URL: b.URL})
+	}
+	p, err := New(targets, picker, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func get(p *Pool, path string) (int, string) {
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	a, b, c := &Upstream{Name: "a", Weight: 5}, &Upstream{Name: "b", Weight: 1}, &Upstream{Name: "c", Weight: 1}
+	picker := WeightedRoundRobin()
+	var got []string
+	for range 14 {
+		got = append(got, picker.Pick(nil, []*Upstream{a, b, c}).Name)
+	}
+	if want := "a a b a c a a a a b a c a a"; strings.Join(got, " ") != want {
+		t.Fatalf("picks = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+// upstreams 按名字创建上游，active 为各自进行中的请求数。
+func upstreams(active ...int64) []*Upstream {
+	var ups []*Upstream
+	for i, n := range active {
+		u := &Upstream{Name: fmt.Sprintf("u%d", i), Weight: 1}
+		u.active.Store(n)
+		ups = append(ups, u)
+	}
+	return ups
+}
+
+func TestRoundRobin(t *testing.T) {
+	ups := upstreams(0, 0, 0)
+	picker := RoundRobin()
+	var got []string
+	for range 7 {
+		got = append(got, picker.Pick(nil, ups).Name)
+	}
+	if want := "u0 u1 u2 u0 u1 u2 u0"; strings.Join(got, " ") != want {
+		t.Fatalf("picks = %s, want %s", strings.Join(got, " "), want)
+	}
+
+	// 并发选择时每个上游被选中的次数仍然相同，用 -race 运行
+	picker = RoundRobin()
+	var mu sync.Mutex
+	counts := map[*Upstream]int{}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 300 {
+				u := picker.Pick(nil, ups)
+				mu.Lock()
+				counts[u]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	for _, u := range ups {
+		if counts[u] != 800 {
+			t.Errorf("%s picked %d times, want 800", u.Name, counts[u])
+		}
+	}
+}
+
+func TestLeastConn(t *testing.T) {
+	picker := LeastConn()
+	ups := upstreams(3, 1, 2)
+	for range 5 {
+		if got := picker.Pick(nil, ups); got != ups[1] {
+			t.Fatalf("picked %s, want u1 with the fewest active requests", got.Name)
+		}
+	}
+	// 按权重折算：u0 有 4 个进行中但权重 4，比权重 1、有 2 个进行中的 u2 更空闲
+	ups = upstreams(4, 2, 2)
+	ups[0].Weight = 4
+	if got := picker.Pick(nil, ups); got != ups[0] {
+		t.Fatalf("picked %s, want u0 with the lowest active/weight", got.Name)
+	}
+	// 负载相同时轮流选
+	ups = upstreams(1, 1, 1)
+	seen := map[*Upstream]bool{}
+	for range 3 {
+		seen[picker.Pick(nil, ups)] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("ties picked only %d different upstreams in 3 picks", len(seen))
+	}
+}
+
+func TestPowerOfTwo(t *testing.T) {
+	picker := PowerOfTwo()
+	if one := upstreams(5); picker.Pick(nil, one) != one[0] {
+		t.Fatal("did not pick the only candidate")
+	}
+	// 两个候选时总是比较这两个，选负载轻的
+	two := upstreams(3, 1)
+	for range 100 {
+		if got := picker.Pick(nil, two); got != two[1] {
+			t.Fatalf("picked %s, want the less loaded u1", got.Name)
+		}
+	}
+	// 负载最重的上游永远不会胜出，其余的都有机会
+	ups := upstreams(1, 2, 3, 9)
+	counts := map[*Upstream]int{}
+	for range 3000 {
+		counts[picker.Pick(nil, ups)]++
+	}
+	if counts[ups[3]] != 0 {
+		t.Fatalf("the most loaded upstream was picked %d times", counts[ups[3]])
+	}
+	// 期望次数：u0 只要被抽中就胜出，是 3000/2；u1 只赢 u2、u3，是 3000/3；u2 只赢 u3，是 3000/6
+	for i, want := range []int{1500, 1000, 500} {
+		if c := counts[ups[i]]; c < want*8/10 || c > want*12/10 {
+			t.Errorf("%s picked %d times, want about %d", ups[i].Name, c, want)
+		}
+	}
+}
+
+func TestRetryOnTimeout(t *testing.T) {
+	slow, fast := newBackend(t, "slow"), newBackend(t, "fast")
+	slow.delay.Store(int64(time.Second))
+	opts := Options{Timeout: 50 * time.Millisecond, Retries: 1}
+
+	p := newPool(t, RoundRobin(), opts, slow, fast)
+	for i := range 4 {
+		if code, body := get(p, "/"); code != 200 || body != "fast" {
+			t.Fatalf("request %d = %d %q, want 200 from fast", i, code, body)
+		}
+	}
+
+	// 不重试时超时返回 504
+	opts.Retries = 0
+	p = newPool(t, RoundRobin(), opts, slow, fast)
+	if code, _ := get(p, "/"); code != http.StatusGatewayTimeout {
+		t.Fatalf("timeout without retries = %d, want 504", code)
+	}
+
+	// 非幂等的请求不重试
+	opts.Retries = 1
+	p = newPool(t, RoundRobin(), opts, slow, fast)
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("POST", "/", nil))
+	if rec.Code != http.StatusGatewayTimeout || fast.hits.Load() != 4 {
+		t.Fatalf("POST = %d, fast hits %d, want 504 without a retry", rec.Code, fast.hits.Load())
+	}
+}
+
+// TestNoRetryOn5xx 收到了上游的响应就不重试，哪怕是 5xx。
+func TestNoRetryOn5xx(t *testing.T) {
+	bad, good := newBackend(t, "bad"), newBackend(t, "good")
+	bad.failing.Store(true)
+	p := newPool(t, RoundRobin(), Options{Retries: 3}, bad, good)
+	if code, _ := get(p, "/"); code != 500 || good.hits.Load() != 0 {
+		t.Fatalf("got %d with %d retries to good, want 500 and no retry", code, good.hits.Load())
+	}
+}
+
+func TestOutlierEjection(t *testing.T) {
+	clock := newFakeClock()
+	var backends []*backend
+	for i := range 4 {
+		backends = append(backends, newBackend(t, fmt.Sprintf("b%d", i)))
+	}
+	for _, b := range backends[:3] {
+		b.failing.Store(true)
+	}
+	p := newPool(t, RoundRobin(), Options{
+		Clock:   clock,
+		Outlier: OutlierOptions{ConsecutiveFailures: 2, BaseEjection: time.Minute, MaxEjectedPercent: 50},
+	}, backends...)
+
+	ejected := func() []string {
+		var names []string
+		for _, s := range p.Status() {
+			if s.Ejected {
+				names = append(names, s.Name)
+			}
+		}
+		return names
+	}
+	for range 20 {
+		get(p, "/")
+	}
+	// 4 个上游最多摘除 50%，第三个失败的上游留在池中
+	if got := ejected(); len(got) != 2 {
+		t.Fatalf("ejected %v, want 2 of the 3 failing upstreams", got)
+	}
+
+	// 摘除到期后恢复，再次失败时摘除时长翻倍
+	for _, b := range backends[:3] {
+		b.failing.Store(false)
+	}
+	clock.Advance(time.Minute)
+	if got := ejected(); len(got) != 0 {
+		t.Fatalf("still ejected after BaseEjection: %v", got)
+	}
+	first := p.upstreams[0]
+	backends[0].failing.Store(true)
+	for range 20 {
+		get(p, "/")
+	}
+	p.mu.Lock()
+	until, ejections := first.ejectedUntil, first.ejections
+	p.mu.Unlock()
+	if ejections != 2 || !until.Equal(clock.Now().Add(2*time.Minute)) {
+		t.Fatalf("second ejection: %d ejections until %v, want 2 until +2m", ejections, until.Sub(clock.Now()))
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBreaker(BreakerOptions{Window: 4, MinRequests: 4, FailureRatio: 0.5, OpenFor: time.Second, Probes: 2})
+	record := func(oks ...bool) {
+		for _, ok := range oks {
+			if !b.Allow(now) {
+				t.Fatalf("Allow = false in state %s", b.State(now))
+			}
+			b.Record(now, ok)
+		}
+	}
+	record(true, false, true)
+	if b.State(now) != Closed {
+		t.Fatal("opened before MinRequests")
+	}
+	record(false) // 4 次中 2 次失败
+	if b.State(now) != Open || b.Allow(now) || b.Ready(now) {
+		t.Fatalf("state %s, want open and rejecting", b.State(now))
+	}
+
+	now = now.Add(time.Second)
+	if b.State(now) != HalfOpen {
+		t.Fatalf("state %s after OpenFor, want half-open", b.State(now))
+	}
+	// 半开时只放行 Probes 个探测
+	if !b.Allow(now) || !b.Allow(now) || b.Allow(now) || b.Ready(now) {
+		t.Fatal("half-open breaker should allow exactly 2 probes")
+	}
+	// 放弃的探测归还名额
+	b.Release()
+	if !b.Ready(now) || !b.Allow(now) {
+		t.Fatal("released probe slot was not returned")
+	}
+	b.Record(now, true)
+	if b.State(now) != HalfOpen {
+		t.Fatal("closed before every probe succeeded")
+	}
+	b.Record(now, false)
+	if b.State(now) != Open {
+		t.Fatalf("state %s after a failed probe, want open", b.State(now))
+	}
+
+	now = now.Add(time.Second)
+	record(true, true)
+	if b.State(now) != Closed {
+		t.Fatalf("state %s after successful probes, want closed", b.State(now))
+	}
+	// 关闭后重新开始统计
+	record(false, false, false)
+	if b.State(now) != Closed {
+		t.Fatal("old outcomes were not cleared when closing")
+	}
+}
+
+func TestBreakerInPool(t *testing.T) {
+	clock := newFakeClock()
+	flaky := newBackend(t, "flaky")
+	flaky.failing.Store(true)
+	p := newPool(t, RoundRobin(), Options{
+		Clock:   clock,
+		Breaker: BreakerOptions{Window: 4, MinRequests: 4, OpenFor: time.Second},
+		Outlier: OutlierOptions{ConsecutiveFailures: 1000},
+	}, flaky)
+	for range 4 {
+		get(p, "/")
+	}
+	if code, _ := get(p, "/"); code != http.StatusServiceUnavailable || flaky.hits.Load() != 4 {
+		t.Fatalf("open breaker: %d after %d upstream hits, want 503 after 4", code, flaky.hits.Load())
+	}
+	flaky.failing.Store(false)
+	clock.Advance(time.Second)
+	if code, body := get(p, "/"); code != 200 || body != "flaky" || p.Status()[0].Breaker != Closed {
+		t.Fatalf("probe = %d %q, breaker %s, want 200 and closed", code, body, p.Status()[0].Breaker)
+	}
+}
+
+// TestClientCancel 客户端取消的请求不算上游失败，也不占用半开的探测名额。
+func TestClientCancel(t *testing.T) {
+	clock := newFakeClock()
+	slow := newBackend(t, "slow")
+	p := newPool(t, RoundRobin(), Options{
+		Clock:   clock,
+		Retries: 2,
+		Breaker: BreakerOptions{Window: 2, MinRequests: 2, OpenFor: time.Second},
+		Outlier: OutlierOptions{ConsecutiveFailures: 1},
+	}, slow)
+	cancelled := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		rec := httptest.NewRecorder()
+		p.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+	}
+
+	slow.delay.Store(int64(time.Second))
+	for range 3 {
+		cancelled()
+	}
+	if s := p.Status()[0]; s.Failures != 0 || s.Ejected || s.Breaker != Closed {
+		t.Fatalf("after client cancellations: %s, ejected %v", s, s.Ejected)
+	}
+	if hits := slow.hits.Load(); hits != 3 {
+		t.Fatalf("upstream got %d requests, want 3 without retries", hits)
+	}
+
+	// 让熔断器进入半开，唯一的探测被客户端取消后，下一个请求还能去探测
+	u := p.upstreams[0]
+	u.breaker.Record(clock.Now(), false)
+	u.breaker.Record(clock.Now(), false)
+	clock.Advance(time.Second)
+	cancelled()
+	slow.delay.Store(0)
+	if code, _ := get(p, "/"); code != 200 {
+		t.Fatalf("after a cancelled probe = %d, want 200", code)
+	}
+}
+
+func TestConsistentHash(t *testing.T) {
+	var ups []*Upstream
+	for i := range 5 {
+		ups = append(ups, &Upstream{Name: fmt.Sprintf("u%d", i), Weight: 1})
+	}
+	picker := ConsistentHash(func(r *http.Request) string { return r.URL.Path }, 50)
+	pick := func(key string, candidates []*Upstream) *Upstream {
+		return picker.Pick(httptest.NewRequest("GET", "/"+key, nil), candidates)
+	}
+
+	before := map[string]*Upstream{}
+	counts := map[*Upstream]int{}
+	for i := range 1000 {
+		key := fmt.Sprint(i)
+		before[key] = pick(key, ups)
+		counts[before[key]]++
+	}
+	for _, u := range ups {
+		if counts[u] < 100 {
+			t.Errorf("%s got %d of 1000 keys", u.Name, counts[u])
+		}
+	}
+
+	// 去掉 u2 后只有原来落在 u2 上的键会移动；u2 恢复后回到原处
+	without := []*Upstream{ups[0], ups[1], ups[3], ups[4]}
+	for key, u := range before {
+		got := pick(key, without)
+		if u != ups[2] && got != u {
+			t.Fatalf("key %s moved from %s to %s", key, u.Name, got.Name)
+		}
+		if got == ups[2] {
+			t.Fatalf("key %s picked the unavailable upstream", key)
+		}
+	}
+	for key, u := range before {
+		if got := pick(key, ups); got != u {
+			t.Fatalf("key %s moved from %s to %s after the upstream came back", key, u.Name, got.Name)
+		}
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	b := newBackend(t, "b")
+	p := newPool(t, RoundRobin(), Options{Health: HealthOptions{Unhealthy: 2, Healthy: 2}}, b)
+	healthy := func() bool { return p.Status()[0].Healthy }
+
+	b.failing.Store(true)
+	p.CheckHealth(context.Background())
+	if !healthy() {
+		t.Fatal("unhealthy after a single failed check")
+	}
+	p.CheckHealth(context.Background())
+	if healthy() {
+		t.Fatal("still healthy after 2 failed checks")
+	}
+	if code, _ := get(p, "/"); code != http.StatusServiceUnavailable {
+		t.Fatalf("request to an unhealthy pool = %d, want 503", code)
+	}
+	b.failing.Store(false)
+	p.CheckHealth(context.Background())
+	p.CheckHealth(context.Background())
+	if !healthy() {
+		t.Fatal("not healthy after 2 successful checks")
+	}
+}
@@ -0,0 +1,429 @@
+/**
+ * 带淘汰策略的泛型缓存：淘汰策略（FIFO、LRU、LFU、2Q、ARC）可以在运行时切换，
+ * 支持 TTL、后台清理、带 singleflight 的加载、负缓存、淘汰回调，以及分片和读多写少的无锁读。
+ * 设计模式/strategy.go 用它演示策略模式，设计模式/proxy.go 用它缓存 HTTP 响应。
+ * notes: 笔记/redis.md#缓存淘汰策略
+ */
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Stats 缓存的命中统计。
+type Stats struct {
+	Hits         int
+	Misses       int
+	Evictions    int
+	Expirations  int
+	Loads        int // 调用 Loader 的次数，并发的相同加载只算一次
+	LoadErrors   int
+	NegativeHits int // 命中缓存的加载错误
+}
+
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("hits=%d misses=%d evictions=%d hit rate=%.1f%%", s.Hits, s.Misses, s.Evictions, s.HitRate()*100)
+}
+
+// Loader 缓存未命中时加载 key 对应的值。
+type Loader[K comparable, V any] func(key K) (V, error)
+
+// EvictReason 条目被动离开缓存的原因，调用方 Remove 的不算。
+type EvictReason int
+
+const (
+	Evicted EvictReason = iota // 容量已满被淘汰策略选中
+	Expired                    // TTL 到期
+)
+
+func (r EvictReason) String() string {
+	if r == Expired {
+		return "expired"
+	}
+	return "evicted"
+}
+
+var ErrNoLoader = errors.New("cache: no loader")
+
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// loadCall 一次进行中的加载，同一个 key 的并发 GetOrLoad 共享结果。
+// 加载期间 key 被 Add 或 Remove 时 stale 置位，加载结果不再写回缓存，以免覆盖更新的值或复活删除的 key。
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	stale bool
+}
+
+type negativeEntry struct {
+	err     error
+	expires time.Time
+}
+
+// Cache 并发安全。TTL 到期的条目在访问时和容量已满写入时删除，也可以用 StartJanitor 在后台定期清理。
+// 淘汰和过期回调在释放锁之后调用，回调中可以再访问缓存。
+type Cache[K comparable, V any] struct {
+	mu           sync.Mutex
+	storage      map[K]V
+	expiry       *expiryQueue[K] // 只记录有 TTL 的条目
+	evictionAlgo EvictionAlgo[K]
+	maxCapacity  int
+	stats        Stats
+
+	clock       Clock
+	defaultTTL  time.Duration // 0 表示不过期
+	loader      Loader[K, V]
+	negativeTTL time.Duration // 加载失败的结果缓存多久，0 表示不缓存
+	negative    map[K]negativeEntry
+	calls       map[K]*loadCall[V]
+	onEvict     func(key K, value V, reason EvictReason)
+	pending     []eviction[K, V] // 等待释放锁后回调的事件
+}
+
+func New[K comparable, V any](e EvictionAlgo[K], maxCapacity int) *Cache[K, V] {
+	if maxCapacity < 1 {
+		panic("cache: maxCapacity must be positive")
+	}
+	c := &Cache[K, V]{
+		storage:     make(map[K]V),
+		expiry:      newExpiryQueue[K](),
+		maxCapacity: maxCapacity,
+		clock:       realClock{},
+		negative:    make(map[K]negativeEntry),
+		calls:       make(map[K]*loadCall[V]),
+	}
+	c.SetEvictionAlgo(e)
+	return c
+}
+
+// unlock 释放锁，然后调用期间积累的淘汰和过期回调。
+func (c *Cache[K, V]) unlock() {
+	events, cb := c.pending, c.onEvict
+	c.pending = nil
+	c.mu.Unlock()
+	if cb == nil {
+		return
+	}
+	for _, e := range events {
+		cb(e.key, e.value, e.reason)
+	}
+}
+
+// SetEvictionAlgo 切换淘汰策略，已有的条目按原策略的淘汰顺序交给新策略，不会丢失。
+func (c *Cache[K, V]) SetEvictionAlgo(e EvictionAlgo[K]) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.setEvictionAlgoLocked(e)
+}
+
+func (c *Cache[K, V]) setEvictionAlgoLocked(e EvictionAlgo[K]) {
+	var keys []K
+	if c.evictionAlgo != nil {
+		keys = c.evictionAlgo.Keys()
+	}
+	e.Reset(c.maxCapacity)
+	for _, k := range keys {
+		e.Add(k)
+	}
+	c.evictionAlgo = e
+}
+
+// SetMaxCapacity 修改容量，缩小时按当前策略淘汰多出的条目。
+func (c *Cache[K, V]) SetMaxCapacity(n int) {
+	if n < 1 {
+		panic("cache: maxCapacity must be positive")
+	}
+	c.mu.Lock()
+	defer c.unlock()
+	for len(c.storage) > n {
+		var zero K
+		c.evict(zero)
+	}
+	c.maxCapacity = n
+	c.setEvictionAlgoLocked(c.evictionAlgo)
+}
+
+func (c *Cache[K, V]) SetClock(clock Clock) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.clock = clock
+}
+
+// SetDefaultTTL 设置 Add 使用的 TTL，只影响之后写入的条目。
+func (c *Cache[K, V]) SetDefaultTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.defaultTTL = ttl
+}
+
+// SetLoader 设置 GetOrLoad 使用的加载函数，negativeTTL > 0 时加载失败的错误也会缓存这么久。
+func (c *Cache[K, V]) SetLoader(l Loader[K, V], negativeTTL time.Duration) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.loader, c.negativeTTL = l, negativeTTL
+}
+
+func (c *Cache[K, V]) SetOnEvict(f func(key K, value V, reason EvictReason)) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.onEvict = f
+}
+
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.add(key, value, c.defaultTTL)
+}
+
+// AddWithTTL 写入一个单独指定 TTL 的条目，ttl 为 0 表示不过期。
+func (c *Cache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.unlock()
+	c.add(key, value, ttl)
+}
+
+func (c *Cache[K, V]) add(key K, value V, ttl time.Duration) {
+	delete(c.negative, key)
+	if call, ok := c.calls[key]; ok {
+		call.stale = true
+	}
+	if _, ok := c.storage[key]; ok {
+		c.evictionAlgo.Touch(key)
+	} else {
+		if len(c.storage) == c.maxCapacity {
+			c.evict(key)
+		}
+		c.evictionAlgo.Add(key)
+	}
+	c.storage[key] = value
+	if ttl > 0 {
+		c.expiry.set(key, c.clock.Now().Add(ttl))
+	} else {
+		c.expiry.remove(key)
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.get(key)
+}
+
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	v, ok := c.storage[key]
+	if ok && c.expired(key, c.clock.Now()) {
+		c.expire(key)
+		ok = false
+	}
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.evictionAlgo.Touch(key)
+	return v, true
+}
+
+// GetOrLoad 命中时直接返回，否则调用 Loader 加载并写入缓存。同一个 key 的并发加载只调用一次 Loader，
+// 其余调用方等待并共享结果；加载失败且开启了负缓存时，在 negativeTTL 内直接返回同一个错误。
+// 加载期间该 key 被 Add 或 Remove 时，结果仍返回给调用方，但不写入缓存。
+func (c *Cache[K, V]) GetOrLoad(key K) (V, error) {
+	c.mu.Lock()
+	if v, ok := c.get(key); ok {
+		c.unlock()
+		return v, nil
+	}
+	if neg, ok := c.negative[key]; ok {
+		if c.clock.Now().Before(neg.expires) {
+			c.stats.NegativeHits++
+			c.unlock()
+			var zero V
+			return zero, neg.err
+		}
+		delete(c.negative, key)
+	}
+	if call, ok := c.calls[key]; ok {
+		c.unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	loader := c.loader
+	if loader == nil {
+		c.unlock()
+		var zero V
+		return zero, ErrNoLoader
+	}
+	call := &loadCall[V]{done: make(chan struct{})}
+	c.calls[key] = call
+	c.stats.Loads++
+	c.unlock()
+
+	call.value, call.err = load(loader, key)
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	if call.err != nil {
+		c.stats.LoadErrors++
+	}
+	// 加载期间有更新的写入或删除时以它为准，不写回
+	if !call.stale {
+		if call.err == nil {
+			c.add(key, call.value, c.defaultTTL)
+		} else if c.negativeTTL > 0 {
+			c.negative[key] = negativeEntry{call.err, c.clock.Now().Add(c.negativeTTL)}
+		}
+	}
+	c.unlock()
+	close(call.done)
+	return call.value, call.err
+}
+
+// load 调用 loader，panic 转成错误，避免等待同一个 key 的调用方永远阻塞。
+func load[K comparable, V any](loader Loader[K, V], key K) (v V, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cache: loader panicked: %v", r)
+		}
+	}()
+	return loader(key)
+}
+
+// Remove 删除 key，返回 key 是否存在，同时清除该 key 的负缓存。
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.remove(key)
+}
+
+func (c *Cache[K, V]) remove(key K) bool {
+	delete(c.negative, key)
+	if call, ok := c.calls[key]; ok {
+		call.stale = true
+	}
+	if _, ok := c.storage[key]; !ok {
+		return false
+	}
+	c.drop(key)
+	return true
+}
+
+func (c *Cache[K, V]) drop(key K) {
+	delete(c.storage, key)
+	c.expiry.remove(key)
+	c.evictionAlgo.Remove(key)
+}
+
+// Keys 列出缓存中的 key，顺序不定，不影响统计和淘汰顺序。
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.unlock()
+	keys := make([]K, 0, len(c.storage))
+	for k := range c.storage {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Len 返回条目数，包括已经过期但还没被清理的条目。
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.unlock()
+	return len(c.storage)
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.unlock()
+	return c.stats
+}
+
+func (c *Cache[K, V]) expired(key K, now time.Time) bool {
+	t, ok := c.expiry.deadline(key)
+	return ok && !now.Before(t)
+}
+
+func (c *Cache[K, V]) expire(key K) {
+	c.pending = append(c.pending, eviction[K, V]{key, c.storage[key], Expired})
+	c.drop(key)
+	c.stats.Expirations++
+}
+
+// evict 容量已满时写入前调用。先清理已过期的条目，腾不出位置再按策略淘汰。
+func (c *Cache[K, V]) evict(incoming K) {
+	if c.deleteExpired() > 0 && len(c.storage) < c.maxCapacity {
+		return
+	}
+	key := c.evictionAlgo.Evict(incoming)
+	c.pending = append(c.pending, eviction[K, V]{key, c.storage[key], Evicted})
+	delete(c.storage, key)
+	c.expiry.remove(key)
+	c.stats.Evictions++
+}
+
+// deleteExpired 从堆顶依次删除过期的条目，返回删除的条目数，耗时只与过期的条目数有关。
+func (c *Cache[K, V]) deleteExpired() int {
+	now := c.clock.Now()
+	n := 0
+	for {
+		key, deadline, ok := c.expiry.next()
+		if !ok || now.Before(deadline) {
+			return n
+		}
+		c.expire(key)
+		n++
+	}
+}
+
+// deleteNegative 删除过期的负缓存。负缓存需要遍历，只在后台清理时调用。
+func (c *Cache[K, V]) deleteNegative() {
+	now := c.clock.Now()
+	for key, neg := range c.negative {
+		if !now.Before(neg.expires) {
+			delete(c.negative, key)
+		}
+	}
+}
+
+// StartJanitor 启动后台清理，每隔 interval 删除过期的条目和负缓存，返回的函数用于停止。
+func (c *Cache[K, V]) StartJanitor(interval time.Duration) (stop func()) {
+	c.mu.Lock()
+	ticker := c.clock.NewTicker(interval)
+	c.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C():
+				c.mu.Lock()
+				c.deleteExpired()
+				c.deleteNegative()
+				c.unlock()
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			ticker.Stop()
+			close(done)
+		})
+	}
+}
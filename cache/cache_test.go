@@ -0,0 +1,312 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestCache(capacity int) (*Cache[string, int], *FakeClock) {
+	clock := NewFakeClock(epoch)
+	c := New[string, int](NewLru[string](), capacity)
+	c.SetClock(clock)
+	return c, clock
+}
+
+func TestTTL(t *testing.T) {
+	c, clock := newTestCache(10)
+	var expired []string
+	c.SetOnEvict(func(key string, _ int, reason EvictReason) {
+		if reason == Expired {
+			expired = append(expired, key)
+		}
+	})
+	c.SetDefaultTTL(time.Minute)
+	c.Add("a", 1)
+	c.AddWithTTL("b", 2, 2*time.Minute)
+	c.AddWithTTL("forever", 3, 0)
+
+	clock.Advance(time.Minute - time.Nanosecond)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("a expired early")
+	}
+	clock.Advance(time.Nanosecond)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("a did not expire at its deadline")
+	}
+	// 重新写入会刷新到期时间
+	c.AddWithTTL("b", 20, time.Hour)
+	clock.Advance(time.Hour - time.Second)
+	if v, ok := c.Get("b"); !ok || v != 20 {
+		t.Fatalf(`Get("b") = %d, %v, want 20, true`, v, ok)
+	}
+	if _, ok := c.Get("forever"); !ok {
+		t.Fatal("entry without TTL expired")
+	}
+	if !slices.Equal(expired, []string{"a"}) {
+		t.Fatalf("expired %v, want [a]", expired)
+	}
+	if st := c.Stats(); st.Expirations != 1 {
+		t.Fatalf("Expirations = %d, want 1", st.Expirations)
+	}
+}
+
+// TestExpiredBeforeEvict 容量已满时先删掉过期的条目，不淘汰仍然有效的条目。
+func TestExpiredBeforeEvict(t *testing.T) {
+	c, clock := newTestCache(3)
+	c.AddWithTTL("short", 1, time.Second)
+	c.AddWithTTL("long", 2, time.Hour)
+	c.Add("forever", 3)
+	clock.Advance(time.Minute)
+	c.Add("new", 4)
+	if got := slices.Sorted(slices.Values(c.Keys())); !slices.Equal(got, []string{"forever", "long", "new"}) {
+		t.Fatalf("Keys() = %v", got)
+	}
+	if st := c.Stats(); st.Evictions != 0 || st.Expirations != 1 {
+		t.Fatalf("Stats() = %+v, want 0 evictions and 1 expiration", st)
+	}
+	// 没有过期的条目时按 LRU 淘汰
+	c.Add("newer", 5)
+	if _, ok := c.Get("long"); ok {
+		t.Fatal("least recently used entry was not evicted")
+	}
+}
+
+// TestExpiryOrder 随机写入、覆盖和删除带 TTL 的条目，每一步都与逐个检查到期时间的结果比较。
+func TestExpiryOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	c, clock := newTestCache(1000)
+	deadlines := map[string]time.Time{}
+	for range 5000 {
+		key := fmt.Sprint(r.Intn(50))
+		switch r.Intn(4) {
+		case 0:
+			c.Remove(key)
+			delete(deadlines, key)
+		case 1:
+			clock.Advance(time.Duration(r.Intn(10)) * time.Second)
+		default:
+			ttl := time.Duration(r.Intn(30)) * time.Second
+			c.AddWithTTL(key, 0, ttl)
+			deadlines[key] = clock.Now().Add(ttl)
+			if ttl == 0 {
+				deadlines[key] = time.Time{}
+			}
+		}
+		c.mu.Lock()
+		c.deleteExpired()
+		c.mu.Unlock()
+		now := clock.Now()
+		for k, d := range deadlines {
+			if !d.IsZero() && !now.Before(d) {
+				delete(deadlines, k)
+			}
+		}
+		got := slices.Sorted(slices.Values(c.Keys()))
+		want := slices.Sorted(func(yield func(string) bool) {
+			for k := range deadlines {
+				if !yield(k) {
+					return
+				}
+			}
+		})
+		if !slices.Equal(got, want) {
+			t.Fatalf("at %v: Keys() = %v, want %v", now.Sub(epoch), got, want)
+		}
+	}
+}
+
+func TestJanitor(t *testing.T) {
+	c, clock := newTestCache(10)
+	expired := make(chan string, 10)
+	c.SetOnEvict(func(key string, _ int, reason EvictReason) { expired <- key })
+	c.SetLoader(func(string) (int, error) { return 0, errors.New("down") }, time.Second)
+	stop := c.StartJanitor(time.Minute)
+	defer stop()
+
+	c.AddWithTTL("a", 1, 30*time.Second)
+	c.AddWithTTL("b", 2, 90*time.Second)
+	c.GetOrLoad("missing")
+
+	clock.Advance(time.Minute)
+	if key := <-expired; key != "a" {
+		t.Fatalf("janitor expired %q, want a", key)
+	}
+	clock.Advance(time.Minute)
+	if key := <-expired; key != "b" {
+		t.Fatalf("janitor expired %q, want b", key)
+	}
+	// 回调在清理完成、释放锁之后调用，此时负缓存也已清理
+	c.mu.Lock()
+	n := len(c.negative)
+	c.mu.Unlock()
+	if n != 0 || c.Len() != 0 {
+		t.Fatalf("after janitor: %d entries, %d negative entries", c.Len(), n)
+	}
+
+	stop()
+	c.AddWithTTL("c", 3, time.Second)
+	clock.Advance(time.Hour)
+	if c.Len() != 1 {
+		t.Fatal("janitor ran after stop")
+	}
+}
+
+func TestNegativeCache(t *testing.T) {
+	c, clock := newTestCache(10)
+	calls := 0
+	c.SetLoader(func(string) (int, error) {
+		calls++
+		return 0, errors.New("down")
+	}, time.Minute)
+	for range 3 {
+		if _, err := c.GetOrLoad("k"); err == nil {
+			t.Fatal("GetOrLoad succeeded")
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("loader called %d times within negativeTTL, want 1", calls)
+	}
+	clock.Advance(time.Minute)
+	c.GetOrLoad("k")
+	if calls != 2 {
+		t.Fatalf("loader called %d times after negativeTTL, want 2", calls)
+	}
+}
+
+// TestStaleLoad 加载期间的 Add 和 Remove 优先于较早开始的加载结果。
+func TestStaleLoad(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		write func(c *Cache[string, int])
+		want  []string
+	}{
+		{"add", func(c *Cache[string, int]) { c.Add("k", 2) }, []string{"k"}},
+		{"remove", func(c *Cache[string, int]) { c.Remove("k") }, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newTestCache(10)
+			started, release := make(chan struct{}), make(chan struct{})
+			c.SetLoader(func(string) (int, error) {
+				close(started)
+				<-release
+				return 1, nil
+			}, 0)
+			done := make(chan int)
+			go func() {
+				v, _ := c.GetOrLoad("k")
+				done <- v
+			}()
+			<-started
+			tc.write(c)
+			close(release)
+			if v := <-done; v != 1 {
+				t.Fatalf("GetOrLoad = %d, want the loaded value 1", v)
+			}
+			if got := c.Keys(); !slices.Equal(got, tc.want) {
+				t.Fatalf("Keys() = %v, want %v", got, tc.want)
+			}
+			if v, ok := c.Get("k"); ok && v != 2 {
+				t.Fatalf(`Get("k") = %d, the slow load overwrote the newer value`, v)
+			}
+		})
+	}
+}
+
+func TestSingleflight(t *testing.T) {
+	c, _ := newTestCache(10)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	c.SetLoader(func(key string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(key), nil
+	}, 0)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.GetOrLoad("four"); v != 4 || err != nil {
+				t.Errorf("GetOrLoad = %d, %v", v, err)
+			}
+		}()
+	}
+	// 等加载开始后再让它完成。还没进入 GetOrLoad 的调用方要么加入这次加载，要么命中加载的结果
+	for {
+		c.mu.Lock()
+		_, loading := c.calls["four"]
+		c.mu.Unlock()
+		if loading {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if n := calls.Load(); n != 1 || c.Stats().Loads != 1 {
+		t.Fatalf("loader called %d times, Loads = %d, want 1", n, c.Stats().Loads)
+	}
+}
+
+// benchImpl 参与基准测试的一种实现。
+type benchImpl struct {
+	name string
+	get  func(key int) (int, bool)
+	add  func(key, value int)
+}
+
+func benchImpls(capacity int) []benchImpl {
+	single := New[int, int](NewLru[int](), capacity)
+	sharded := NewSharded[int, int](16, capacity, func() EvictionAlgo[int] { return NewLru[int]() }, false)
+	readMostly := NewSharded[int, int](16, capacity, func() EvictionAlgo[int] { return NewLru[int]() }, true)
+	var m sync.Map
+	return []benchImpl{
+		{"single-mutex", single.Get, single.Add},
+		{"sharded-x16", sharded.Get, sharded.Add},
+		{"read-mostly-x16", readMostly.Get, readMostly.Add},
+		{"sync.Map", func(k int) (int, bool) {
+			v, ok := m.Load(k)
+			if !ok {
+				return 0, false
+			}
+			return v.(int), true
+		}, func(k, v int) { m.Store(k, v) }},
+	}
+}
+
+// BenchmarkCaches 在不同读写比例下并发读写各实现，用 -cpu 调整并发度。
+// key 空间与容量相同，不发生淘汰；sync.Map 没有容量限制和淘汰策略，只作为参照。
+func BenchmarkCaches(b *testing.B) {
+	const keys = 10000
+	for _, readPct := range []int{99, 90, 50} {
+		for _, impl := range benchImpls(keys) {
+			b.Run(fmt.Sprintf("reads=%d%%/%s", readPct, impl.name), func(b *testing.B) {
+				for k := 0; k < keys; k++ {
+					impl.add(k, k)
+				}
+				var seed atomic.Int64
+				b.ReportAllocs()
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					r := rand.New(rand.NewSource(seed.Add(1)))
+					for pb.Next() {
+						k := r.Intn(keys)
+						if r.Intn(100) < readPct {
+							impl.get(k)
+						} else {
+							impl.add(k, k)
+						}
+					}
+				})
+			})
+		}
+	}
+}
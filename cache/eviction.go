@@ -0,0 +1,311 @@
+package cache
+
+import "container/list"
+
+// EvictionAlgo 淘汰策略。Cache 在写入、命中、删除时通知策略，缓存满时由策略选出被淘汰的 key，
+// 策略只维护 key 的顺序，不保存值。本包的 NewFifo、NewLru、NewLfu、NewTwoQ、NewArc 的所有操作都是 O(1)，
+// 调用方也可以实现自己的策略（见 设计模式/strategy.go 中的随机淘汰）。
+//
+// Cache 持有锁时调用这些方法，同一个策略不会被并发调用，方法中也不能再调用 Cache。
+// Add 只用于策略中没有的 key，Touch 只用于已有的 key；Evict 只在缓存已满（策略中至少有一个 key）时调用。
+type EvictionAlgo[K comparable] interface {
+	Reset(capacity int) // 装入 Cache 时调用，清空状态
+	Add(key K)          // 新 key 写入缓存
+	Touch(key K)        // 已有的 key 被读取或覆盖
+	Remove(key K)       // key 被调用方删除或过期
+	Evict(incoming K) K // 缓存已满、即将写入 incoming 时选出并移除一个 key
+	Keys() []K          // 所有 key，按淘汰顺序（最先被淘汰的在前），用于切换策略
+}
+
+// Fifo 先进先出，命中不影响顺序。
+type Fifo[K comparable] struct {
+	order *list.List // 队首最新，队尾最先被淘汰
+	items map[K]*list.Element
+}
+
+func NewFifo[K comparable]() *Fifo[K] {
+	return &Fifo[K]{}
+}
+
+func (f *Fifo[K]) Reset(int) {
+	f.order = list.New()
+	f.items = map[K]*list.Element{}
+}
+
+func (f *Fifo[K]) Add(key K) {
+	f.items[key] = f.order.PushFront(key)
+}
+
+func (f *Fifo[K]) Touch(K) {}
+
+func (f *Fifo[K]) Remove(key K) {
+	if e, ok := f.items[key]; ok {
+		f.order.Remove(e)
+		delete(f.items, key)
+	}
+}
+
+func (f *Fifo[K]) Evict(K) K {
+	key := f.order.Back().Value.(K)
+	f.Remove(key)
+	return key
+}
+
+func (f *Fifo[K]) Keys() []K {
+	return backToFront[K](f.order)
+}
+
+// Lru 最近最少使用，与 Fifo 的区别只是命中时移到队首。
+type Lru[K comparable] struct {
+	Fifo[K]
+}
+
+func NewLru[K comparable]() *Lru[K] {
+	return &Lru[K]{}
+}
+
+func (l *Lru[K]) Touch(key K) {
+	l.order.MoveToFront(l.items[key])
+}
+
+// Lfu 最不经常使用，访问次数相同时淘汰最久未访问的。
+// 按访问次数升序串起频次桶，每个桶内是一个 LRU 链表，增加次数只需移到相邻的桶。
+type Lfu[K comparable] struct {
+	buckets *list.List // 元素为 *lfuBucket，频次升序
+	items   map[K]*lfuItem[K]
+}
+
+type lfuBucket struct {
+	freq int
+	keys *list.List // 队首最近访问
+}
+
+type lfuItem[K comparable] struct {
+	bucket *list.Element
+	elem   *list.Element
+}
+
+func NewLfu[K comparable]() *Lfu[K] {
+	return &Lfu[K]{}
+}
+
+func (l *Lfu[K]) Reset(int) {
+	l.buckets = list.New()
+	l.items = map[K]*lfuItem[K]{}
+}
+
+func (l *Lfu[K]) Add(key K) {
+	front := l.buckets.Front()
+	if front == nil || front.Value.(*lfuBucket).freq != 1 {
+		front = l.buckets.PushFront(&lfuBucket{freq: 1, keys: list.New()})
+	}
+	l.items[key] = &lfuItem[K]{front, front.Value.(*lfuBucket).keys.PushFront(key)}
+}
+
+func (l *Lfu[K]) Touch(key K) {
+	it := l.items[key]
+	cur := it.bucket.Value.(*lfuBucket)
+	next := it.bucket.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != cur.freq+1 {
+		next = l.buckets.InsertAfter(&lfuBucket{freq: cur.freq + 1, keys: list.New()}, it.bucket)
+	}
+	l.unlink(it)
+	it.bucket = next
+	it.elem = next.Value.(*lfuBucket).keys.PushFront(key)
+}
+
+// unlink 把 key 从所在的桶中移除，桶空了就删掉桶。
+func (l *Lfu[K]) unlink(it *lfuItem[K]) {
+	b := it.bucket.Value.(*lfuBucket)
+	b.keys.Remove(it.elem)
+	if b.keys.Len() == 0 {
+		l.buckets.Remove(it.bucket)
+	}
+}
+
+func (l *Lfu[K]) Remove(key K) {
+	if it, ok := l.items[key]; ok {
+		l.unlink(it)
+		delete(l.items, key)
+	}
+}
+
+func (l *Lfu[K]) Evict(K) K {
+	key := l.buckets.Front().Value.(*lfuBucket).keys.Back().Value.(K)
+	l.Remove(key)
+	return key
+}
+
+// Keys 切换到别的策略后访问次数会丢失，只保留相对顺序。
+func (l *Lfu[K]) Keys() []K {
+	var res []K
+	for b := l.buckets.Front(); b != nil; b = b.Next() {
+		res = append(res, backToFront[K](b.Value.(*lfuBucket).keys)...)
+	}
+	return res
+}
+
+// TwoQ 2Q 算法：新 key 先进入 FIFO 队列 a1in，从 a1in 淘汰的 key 记入幽灵队列 a1out，
+// 在 a1out 中的 key 再次写入时才进入 LRU 队列 am，只访问一次的扫描不会冲掉热点数据。
+type TwoQ[K comparable] struct {
+	kin, kout   int // a1in 和 a1out 的容量
+	a1in, a1out *Fifo[K]
+	am          *Lru[K]
+}
+
+func NewTwoQ[K comparable]() *TwoQ[K] {
+	return &TwoQ[K]{}
+}
+
+func (q *TwoQ[K]) Reset(capacity int) {
+	q.kin, q.kout = max(capacity/4, 1), max(capacity/2, 1)
+	q.a1in, q.a1out, q.am = NewFifo[K](), NewFifo[K](), NewLru[K]()
+	q.a1in.Reset(capacity)
+	q.a1out.Reset(capacity)
+	q.am.Reset(capacity)
+}
+
+func (q *TwoQ[K]) Add(key K) {
+	if _, ok := q.a1out.items[key]; ok {
+		q.a1out.Remove(key)
+		q.am.Add(key)
+		return
+	}
+	q.a1in.Add(key)
+}
+
+func (q *TwoQ[K]) Touch(key K) {
+	if _, ok := q.am.items[key]; ok {
+		q.am.Touch(key)
+	}
+}
+
+func (q *TwoQ[K]) Remove(key K) {
+	q.a1in.Remove(key)
+	q.am.Remove(key)
+}
+
+func (q *TwoQ[K]) Evict(incoming K) K {
+	if q.a1in.order.Len() > q.kin || q.am.order.Len() == 0 {
+		key := q.a1in.Evict(incoming)
+		q.a1out.Add(key)
+		if q.a1out.order.Len() > q.kout {
+			q.a1out.Evict(incoming)
+		}
+		return key
+	}
+	return q.am.Evict(incoming)
+}
+
+func (q *TwoQ[K]) Keys() []K {
+	return append(q.a1in.Keys(), q.am.Keys()...)
+}
+
+// Arc 自适应替换缓存：t1 保存只访问过一次的 key，t2 保存访问过多次的 key，
+// b1、b2 是它们淘汰出去的幽灵 key。幽灵 key 再次写入说明对应的队列太短，
+// 据此调整 t1 的目标长度 p，在偏向最近访问和偏向访问频率之间自动平衡。
+type Arc[K comparable] struct {
+	c, p           int
+	t1, t2, b1, b2 *Lru[K]
+	adapted        bool // evict 已经为即将写入的幽灵 key 调整过 p
+}
+
+func NewArc[K comparable]() *Arc[K] {
+	return &Arc[K]{}
+}
+
+func (a *Arc[K]) Reset(capacity int) {
+	a.c, a.p, a.adapted = capacity, 0, false
+	a.t1, a.t2, a.b1, a.b2 = NewLru[K](), NewLru[K](), NewLru[K](), NewLru[K]()
+	for _, l := range []*Lru[K]{a.t1, a.t2, a.b1, a.b2} {
+		l.Reset(capacity)
+	}
+}
+
+func has[K comparable](l *Lru[K], key K) bool {
+	_, ok := l.items[key]
+	return ok
+}
+
+// adapt 幽灵 key 命中时调整 p：命中 b1 说明 t1 太短，命中 b2 说明 t2 太短。
+func (a *Arc[K]) adapt(key K) {
+	switch {
+	case has(a.b1, key):
+		a.p = min(a.c, a.p+max(a.b2.order.Len()/a.b1.order.Len(), 1))
+	case has(a.b2, key):
+		a.p = max(0, a.p-max(a.b1.order.Len()/a.b2.order.Len(), 1))
+	default:
+		return
+	}
+	a.adapted = true
+}
+
+func (a *Arc[K]) Add(key K) {
+	if !a.adapted {
+		a.adapt(key)
+	}
+	a.adapted = false
+
+	if has(a.b1, key) || has(a.b2, key) {
+		a.b1.Remove(key)
+		a.b2.Remove(key)
+		a.t2.Add(key)
+		return
+	}
+	a.t1.Add(key)
+	if a.t1.order.Len()+a.b1.order.Len() > a.c && a.b1.order.Len() > 0 {
+		a.b1.Evict(key)
+	}
+	if a.t1.order.Len()+a.t2.order.Len()+a.b1.order.Len()+a.b2.order.Len() > 2*a.c {
+		a.b2.Evict(key)
+	}
+}
+
+func (a *Arc[K]) Touch(key K) {
+	if has(a.t1, key) {
+		a.t1.Remove(key)
+		a.t2.Add(key)
+		return
+	}
+	a.t2.Touch(key)
+}
+
+func (a *Arc[K]) Remove(key K) {
+	a.t1.Remove(key)
+	a.t2.Remove(key)
+}
+
+func (a *Arc[K]) Evict(incoming K) K {
+	a.adapt(incoming)
+	inGhost := has(a.b1, incoming) || has(a.b2, incoming)
+	// t1 和 b1 已经占满 c 个位置：丢掉 b1 最旧的幽灵，b1 为空时 t1 的 key 直接淘汰不留幽灵
+	if !inGhost && a.t1.order.Len()+a.b1.order.Len() >= a.c {
+		if a.b1.order.Len() == 0 {
+			return a.t1.Evict(incoming)
+		}
+		a.b1.Evict(incoming)
+	}
+
+	t1 := a.t1.order.Len()
+	if t1 > 0 && (t1 > a.p || (has(a.b2, incoming) && t1 == a.p) || a.t2.order.Len() == 0) {
+		key := a.t1.Evict(incoming)
+		a.b1.Add(key)
+		return key
+	}
+	key := a.t2.Evict(incoming)
+	a.b2.Add(key)
+	return key
+}
+
+func (a *Arc[K]) Keys() []K {
+	return append(a.t1.Keys(), a.t2.Keys()...)
+}
+
+// backToFront 从队尾到队首列出链表中的 key。
+func backToFront[K any](l *list.List) []K {
+	res := make([]K, 0, l.Len())
+	for e := l.Back(); e != nil; e = e.Prev() {
+		res = append(res, e.Value.(K))
+	}
+	return res
+}
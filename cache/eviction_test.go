@@ -0,0 +1,196 @@
+package cache
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type namedAlgo struct {
+	name string
+	new  func() EvictionAlgo[string]
+}
+
+var algos = []namedAlgo{
+	{"fifo", func() EvictionAlgo[string] { return NewFifo[string]() }},
+	{"lru", func() EvictionAlgo[string] { return NewLru[string]() }},
+	{"lfu", func() EvictionAlgo[string] { return NewLfu[string]() }},
+	{"2q", func() EvictionAlgo[string] { return NewTwoQ[string]() }},
+	{"arc", func() EvictionAlgo[string] { return NewArc[string]() }},
+}
+
+// add 写入 key，返回因此被淘汰的 key：写入前后 Keys() 的差。Keys 不影响统计和淘汰顺序。
+func add(c *Cache[string, int], key string, value int) []string {
+	before := c.Keys()
+	c.Add(key, value)
+	after := c.Keys()
+	return slices.DeleteFunc(before, func(k string) bool { return slices.Contains(after, k) })
+}
+
+// run 按 ops 操作缓存并返回被淘汰的 key：+k 写入，?k 读取，-k 删除。
+func run(c *Cache[string, int], ops string) []string {
+	var evicted []string
+	for i, op := range strings.Fields(ops) {
+		switch key := op[1:]; op[0] {
+		case '+':
+			evicted = append(evicted, add(c, key, i)...)
+		case '?':
+			c.Get(key)
+		case '-':
+			c.Remove(key)
+		}
+	}
+	return evicted
+}
+
+// scan 生成只写入一次的 key：+s0 +s1 ...
+func scan(n int) string {
+	var b strings.Builder
+	for i := range n {
+		b.WriteString(" +s")
+		b.WriteString(string(rune('0' + i)))
+	}
+	return b.String()
+}
+
+func TestEvictionOrder(t *testing.T) {
+	tests := []struct {
+		algo     string
+		capacity int
+		ops      string
+		evicted  []string
+		keys     []string
+	}{
+		// 命中不影响 FIFO 的顺序
+		{"fifo", 2, "+a +b ?a +c", []string{"a"}, []string{"b", "c"}},
+		{"fifo", 3, "+a +b +c -a +d +e", []string{"b"}, []string{"c", "d", "e"}},
+		{"lru", 2, "+a +b ?a +c", []string{"b"}, []string{"a", "c"}},
+		// 覆盖写入也算访问
+		{"lru", 2, "+a +b +a +c +d", []string{"b", "a"}, []string{"c", "d"}},
+		// 次数相同时淘汰最久未访问的
+		{"lfu", 2, "+a +b ?a ?a ?b +c +d", []string{"b", "c"}, []string{"a", "d"}},
+		{"lfu", 3, "+a +b +c ?c ?b ?a +d", []string{"c"}, []string{"a", "b", "d"}},
+		// 2Q：从 a1in 淘汰的 a 再次写入时进入 am，之后的扫描只淘汰 a1in 中的 key
+		{"2q", 4, "+a +b +c +d +e +a" + scan(6), []string{"a", "b", "c", "d", "e", "s0", "s1", "s2"}, []string{"a", "s3", "s4", "s5"}},
+		// ARC：访问过两次的 a 在 t2 中，一次性的扫描只从 t1 淘汰
+		{"arc", 3, "+a ?a" + scan(8), []string{"s0", "s1", "s2", "s3", "s4", "s5"}, []string{"a", "s6", "s7"}},
+		// 同样的负载下 LRU 会被扫描冲掉
+		{"lru", 3, "+a ?a" + scan(8), []string{"a", "s0", "s1", "s2", "s3", "s4"}, []string{"s5", "s6", "s7"}},
+	}
+	for _, tt := range tests {
+		i := slices.IndexFunc(algos, func(a namedAlgo) bool { return a.name == tt.algo })
+		c := New[string, int](algos[i].new(), tt.capacity)
+		evicted := run(c, tt.ops)
+		keys := c.Keys()
+		slices.Sort(keys)
+		if !slices.Equal(evicted, tt.evicted) || !slices.Equal(keys, tt.keys) {
+			t.Errorf("%s(%d) %q: evicted %v, keys %v, want %v, %v", tt.algo, tt.capacity, tt.ops, evicted, keys, tt.evicted, tt.keys)
+		}
+	}
+}
+
+func TestSetEvictionAlgo(t *testing.T) {
+	for _, from := range algos {
+		for _, to := range algos {
+			c := New[string, int](from.new(), 5)
+			run(c, "+a +b +c +d ?a ?c +e ?e")
+			before := c.Stats()
+			c.SetEvictionAlgo(to.new())
+			keys := c.Keys()
+			slices.Sort(keys)
+			if !slices.Equal(keys, []string{"a", "b", "c", "d", "e"}) || c.Stats() != before {
+				t.Fatalf("%s -> %s: keys %v, stats %v", from.name, to.name, keys, c.Stats())
+			}
+			for i, k := range []string{"a", "b", "c", "d", "e"} {
+				if v, ok := c.Get(k); !ok || v != []int{0, 1, 2, 3, 6}[i] {
+					t.Fatalf("%s -> %s: Get(%s) = %d, %v", from.name, to.name, k, v, ok)
+				}
+			}
+			// 新策略接管了所有 key：继续写入时容量不变，淘汰的都是已有的 key
+			if evicted := run(c, "+f +g +h +i +j"); len(evicted) != 5 || c.Len() != 5 {
+				t.Fatalf("%s -> %s: evicted %v, Len = %d after refill", from.name, to.name, evicted, c.Len())
+			}
+		}
+	}
+	// 切换时保留原策略的淘汰顺序
+	c := New[string, int](NewLru[string](), 3)
+	run(c, "+a +b +c ?a")
+	c.SetEvictionAlgo(NewFifo[string]())
+	if evicted := run(c, "+d ?b +e"); !slices.Equal(evicted, []string{"b", "c"}) {
+		t.Fatalf("FIFO after LRU evicted %v, want [b c]", evicted)
+	}
+}
+
+// refModel FIFO 和 LRU 的参照实现：用切片保存淘汰顺序。
+type refModel struct {
+	lru   bool
+	order []string // 最先被淘汰的在前
+}
+
+func (m *refModel) access(key string, write bool, capacity int) (hit bool, evicted string) {
+	i := slices.Index(m.order, key)
+	if i >= 0 {
+		if m.lru {
+			m.order = append(slices.Delete(m.order, i, i+1), key)
+		}
+		return true, ""
+	}
+	if !write {
+		return false, ""
+	}
+	if len(m.order) == capacity {
+		evicted, m.order = m.order[0], m.order[1:]
+	}
+	m.order = append(m.order, key)
+	return false, evicted
+}
+
+func TestStats(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, a := range algos {
+		const capacity = 8
+		c := New[string, int](a.new(), capacity)
+		ref := &refModel{lru: a.name == "lru"}
+		var want Stats
+		present := map[string]bool{}
+		for i := range 5000 {
+			key := string(rune('a' + r.Intn(20)))
+			if r.Intn(2) == 0 {
+				_, ok := c.Get(key)
+				if ok != present[key] {
+					t.Fatalf("%s: Get(%s) = %v, want %v", a.name, key, ok, present[key])
+				}
+				if ok {
+					want.Hits++
+				} else {
+					want.Misses++
+				}
+				if a.name == "fifo" || a.name == "lru" {
+					if hit, _ := ref.access(key, false, capacity); hit != ok {
+						t.Fatalf("%s: Get(%s) = %v, reference %v", a.name, key, ok, hit)
+					}
+				}
+				continue
+			}
+			evicted := add(c, key, i)
+			if len(evicted) > 0 {
+				want.Evictions++
+				delete(present, evicted[0])
+			}
+			present[key] = true
+			if len(evicted) > 1 || c.Len() != len(present) || c.Len() > capacity {
+				t.Fatalf("%s: Add(%s) evicted %v, Len = %d", a.name, key, evicted, c.Len())
+			}
+			if a.name == "fifo" || a.name == "lru" {
+				_, wantKey := ref.access(key, true, capacity)
+				if got := slices.Concat(evicted, []string{""})[0]; got != wantKey {
+					t.Fatalf("%s: Add(%s) evicted %q, reference %q", a.name, key, got, wantKey)
+				}
+			}
+		}
+		if st := c.Stats(); st != want {
+			t.Fatalf("%s: Stats() = %+v, want %+v", a.name, st, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"container/heap"
+	"time"
+)
+
+// expiryQueue 有 TTL 的条目按到期时间组成最小堆，清理时只需弹出已到期的堆顶，
+// 不用每次扫描所有条目。index 记录每个 key 在堆中的结点，用于更新和删除。
+type expiryQueue[K comparable] struct {
+	heap  expiryHeap[K]
+	index map[K]*expiryItem[K]
+}
+
+type expiryItem[K comparable] struct {
+	key      K
+	deadline time.Time
+	pos      int // 在堆中的下标
+}
+
+func newExpiryQueue[K comparable]() *expiryQueue[K] {
+	return &expiryQueue[K]{index: make(map[K]*expiryItem[K])}
+}
+
+// deadline 返回 key 的到期时间，没有 TTL 时返回 false。
+func (q *expiryQueue[K]) deadline(key K) (time.Time, bool) {
+	if it, ok := q.index[key]; ok {
+		return it.deadline, true
+	}
+	return time.Time{}, false
+}
+
+func (q *expiryQueue[K]) set(key K, deadline time.Time) {
+	if it, ok := q.index[key]; ok {
+		it.deadline = deadline
+		heap.Fix(&q.heap, it.pos)
+		return
+	}
+	it := &expiryItem[K]{key: key, deadline: deadline}
+	q.index[key] = it
+	heap.Push(&q.heap, it)
+}
+
+func (q *expiryQueue[K]) remove(key K) {
+	if it, ok := q.index[key]; ok {
+		heap.Remove(&q.heap, it.pos)
+		delete(q.index, key)
+	}
+}
+
+// next 返回最早到期的 key。
+func (q *expiryQueue[K]) next() (K, time.Time, bool) {
+	if len(q.heap) == 0 {
+		var zero K
+		return zero, time.Time{}, false
+	}
+	return q.heap[0].key, q.heap[0].deadline, true
+}
+
+type expiryHeap[K comparable] []*expiryItem[K]
+
+func (h expiryHeap[K]) Len() int           { return len(h) }
+func (h expiryHeap[K]) Less(i, j int) bool { return h[i].deadline.Before(h[j].deadline) }
+
+func (h expiryHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos, h[j].pos = i, j
+}
+
+func (h *expiryHeap[K]) Push(x any) {
+	it := x.(*expiryItem[K])
+	it.pos = len(*h)
+	*h = append(*h, it)
+}
+
+func (h *expiryHeap[K]) Pop() any {
+	old := *h
+	it := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return it
+}
@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"fmt"
+	"hash/maphash"
+	"sync/atomic"
+	"time"
+)
+
+// ShardedCache 把 key 按哈希分到 N 个分片，每个分片是一个独立加锁、有自己淘汰策略的 Cache，
+// 不同分片上的操作互不阻塞。容量平均分给各个分片，所以整体的淘汰顺序只是近似的。
+//
+// 读多写少模式下，每个分片在写入后把内容发布成一份只读快照，Get 通过原子指针读快照，完全不加锁；
+// 读到的 key 放进有界的访问缓冲区，下次写入时再交给淘汰策略，缓冲区满时丢弃，LRU 等策略的顺序因此是近似的。
+// 每次写入都要复制整个分片，只适合写很少的场景。
+type ShardedCache[K comparable, V any] struct {
+	shards     []*shard[K, V]
+	hash       func(K) uint64
+	readMostly bool
+}
+
+type shard[K comparable, V any] struct {
+	*Cache[K, V]
+	snapshot     atomic.Pointer[map[K]snapEntry[V]]
+	reads        chan K
+	hits, misses atomic.Int64 // 快照上的读
+}
+
+type snapEntry[V any] struct {
+	value   V
+	expires time.Time // 零值表示不过期
+}
+
+const readBufferSize = 64
+
+// NewSharded 创建 n 个分片，maxCapacity 是总容量，newAlgo 为每个分片创建淘汰策略。
+func NewSharded[K comparable, V any](n, maxCapacity int, newAlgo func() EvictionAlgo[K], readMostly bool) *ShardedCache[K, V] {
+	if n < 1 || maxCapacity < n {
+		panic("cache: need at least one shard and one entry per shard")
+	}
+	sc := &ShardedCache[K, V]{hash: hashKey[K], readMostly: readMostly}
+	for i := 0; i < n; i++ {
+		// 把除不尽的容量分给前面的分片
+		capacity := maxCapacity / n
+		if i < maxCapacity%n {
+			capacity++
+		}
+		s := &shard[K, V]{Cache: New[K, V](newAlgo(), capacity)}
+		if readMostly {
+			s.reads = make(chan K, readBufferSize)
+			s.publish()
+		}
+		sc.shards = append(sc.shards, s)
+	}
+	return sc
+}
+
+var hashSeed = maphash.MakeSeed()
+
+// hashKey 字符串和整数直接哈希，其他类型先格式化成字符串。
+func hashKey[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hashSeed, k)
+	case int:
+		return mix(uint64(k))
+	case int64:
+		return mix(uint64(k))
+	case uint64:
+		return mix(k)
+	}
+	return maphash.String(hashSeed, fmt.Sprint(key))
+}
+
+// mix 是 splitmix64 的收尾步骤，让相邻的整数落到不同分片。
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+func (sc *ShardedCache[K, V]) shardOf(key K) *shard[K, V] {
+	return sc.shards[sc.hash(key)%uint64(len(sc.shards))]
+}
+
+// Configure 对每个分片执行 f，用于设置 Loader、TTL、回调和时钟。
+func (sc *ShardedCache[K, V]) Configure(f func(c *Cache[K, V])) {
+	for _, s := range sc.shards {
+		f(s.Cache)
+	}
+}
+
+// drainReads 在持有分片锁时调用，把缓冲的读访问交给淘汰策略。
+func (s *shard[K, V]) drainReads() {
+	for {
+		select {
+		case key := <-s.reads:
+			if _, ok := s.storage[key]; ok {
+				s.evictionAlgo.Touch(key)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// publish 在持有分片锁时调用，发布新快照。
+func (s *shard[K, V]) publish() {
+	m := make(map[K]snapEntry[V], len(s.storage))
+	for k, v := range s.storage {
+		expires, _ := s.expiry.deadline(k)
+		m[k] = snapEntry[V]{v, expires}
+	}
+	s.snapshot.Store(&m)
+}
+
+// write 在分片锁内执行 f。读多写少模式下先把缓冲的读访问交给淘汰策略，写入时的淘汰才能参考这些访问，
+// 随后发布快照。
+func (sc *ShardedCache[K, V]) write(s *shard[K, V], f func()) {
+	s.mu.Lock()
+	defer s.unlock()
+	if sc.readMostly {
+		s.drainReads()
+	}
+	f()
+	if sc.readMostly {
+		s.publish()
+	}
+}
+
+func (sc *ShardedCache[K, V]) Add(key K, value V) {
+	s := sc.shardOf(key)
+	sc.write(s, func() { s.add(key, value, s.defaultTTL) })
+}
+
+func (sc *ShardedCache[K, V]) AddWithTTL(key K, value V, ttl time.Duration) {
+	s := sc.shardOf(key)
+	sc.write(s, func() { s.add(key, value, ttl) })
+}
+
+func (sc *ShardedCache[K, V]) Remove(key K) bool {
+	s := sc.shardOf(key)
+	removed := false
+	sc.write(s, func() { removed = s.remove(key) })
+	return removed
+}
+
+func (sc *ShardedCache[K, V]) Get(key K) (V, bool) {
+	s := sc.shardOf(key)
+	if !sc.readMostly {
+		return s.Get(key)
+	}
+	v, ok := s.fromSnapshot(key)
+	if ok {
+		s.hits.Add(1)
+	} else {
+		s.misses.Add(1)
+	}
+	return v, ok
+}
+
+// fromSnapshot 无锁地从快照读取，命中时记录一次访问，不计入统计。
+func (s *shard[K, V]) fromSnapshot(key K) (V, bool) {
+	e, ok := (*s.snapshot.Load())[key]
+	if !ok || (!e.expires.IsZero() && !s.clock.Now().Before(e.expires)) {
+		var zero V
+		return zero, false // 过期的条目留给写入或后台清理删除
+	}
+	select {
+	case s.reads <- key:
+	default:
+	}
+	return e.value, true
+}
+
+// GetOrLoad 与 Cache.GetOrLoad 相同。读多写少模式下先查快照，未命中时在锁内再查一次并加载，之后发布快照。
+func (sc *ShardedCache[K, V]) GetOrLoad(key K) (V, error) {
+	s := sc.shardOf(key)
+	if !sc.readMostly {
+		return s.GetOrLoad(key)
+	}
+	if v, ok := s.fromSnapshot(key); ok {
+		s.hits.Add(1)
+		return v, nil
+	}
+	v, err := s.Cache.GetOrLoad(key)
+	if err == nil {
+		sc.write(s, func() {})
+	}
+	return v, err
+}
+
+func (sc *ShardedCache[K, V]) Len() int {
+	n := 0
+	for _, s := range sc.shards {
+		n += s.Len()
+	}
+	return n
+}
+
+// Stats 汇总各分片的统计。
+func (sc *ShardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for _, s := range sc.shards {
+		st := s.Stats()
+		total.Hits += st.Hits + int(s.hits.Load())
+		total.Misses += st.Misses + int(s.misses.Load())
+		total.Evictions += st.Evictions
+		total.Expirations += st.Expirations
+		total.Loads += st.Loads
+		total.LoadErrors += st.LoadErrors
+		total.NegativeHits += st.NegativeHits
+	}
+	return total
+}
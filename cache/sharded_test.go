@@ -0,0 +1,184 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestSharded(n, capacity int, readMostly bool) (*ShardedCache[string, int], *FakeClock) {
+	clock := NewFakeClock(epoch)
+	sc := NewSharded[string, int](n, capacity, func() EvictionAlgo[string] { return NewLru[string]() }, readMostly)
+	sc.Configure(func(c *Cache[string, int]) { c.SetClock(clock) })
+	return sc, clock
+}
+
+func TestShardedSnapshot(t *testing.T) {
+	for _, readMostly := range []bool{false, true} {
+		sc, _ := newTestSharded(4, 40, readMostly)
+		loads := 0
+		sc.Configure(func(c *Cache[string, int]) {
+			c.SetLoader(func(key string) (int, error) {
+				loads++
+				if key == "bad" {
+					return 0, errors.New("bad key")
+				}
+				return len(key), nil
+			}, 0)
+		})
+		for i := range 20 {
+			sc.Add(fmt.Sprint("k", i), i)
+		}
+		for i := range 20 {
+			if v, ok := sc.Get(fmt.Sprint("k", i)); !ok || v != i {
+				t.Fatalf("readMostly=%v: Get(k%d) = %d, %v after Add", readMostly, i, v, ok)
+			}
+		}
+		// 每次写入后快照都重新发布
+		sc.Add("k3", 33)
+		if !sc.Remove("k5") || sc.Remove("k5") {
+			t.Fatalf("readMostly=%v: Remove(k5) is wrong", readMostly)
+		}
+		if v, _ := sc.Get("k3"); v != 33 {
+			t.Fatalf("readMostly=%v: Get(k3) = %d after overwrite", readMostly, v)
+		}
+		if _, ok := sc.Get("k5"); ok {
+			t.Fatalf("readMostly=%v: k5 still readable after Remove", readMostly)
+		}
+		if v, err := sc.GetOrLoad("hello"); v != 5 || err != nil {
+			t.Fatalf("readMostly=%v: GetOrLoad(hello) = %d, %v", readMostly, v, err)
+		}
+		if v, ok := sc.Get("hello"); !ok || v != 5 {
+			t.Fatalf("readMostly=%v: Get(hello) = %d, %v after GetOrLoad", readMostly, v, ok)
+		}
+		if _, err := sc.GetOrLoad("hello"); err != nil || loads != 1 {
+			t.Fatalf("readMostly=%v: second GetOrLoad loaded again (%d loads)", readMostly, loads)
+		}
+		if _, err := sc.GetOrLoad("bad"); err == nil {
+			t.Fatalf("readMostly=%v: GetOrLoad(bad) succeeded", readMostly)
+		}
+		if _, ok := sc.Get("bad"); ok {
+			t.Fatalf("readMostly=%v: failed load was cached", readMostly)
+		}
+		if sc.Len() != 20 {
+			t.Fatalf("readMostly=%v: Len = %d, want 20", readMostly, sc.Len())
+		}
+	}
+}
+
+func TestShardedTTL(t *testing.T) {
+	for _, readMostly := range []bool{false, true} {
+		sc, clock := newTestSharded(2, 10, readMostly)
+		sc.AddWithTTL("a", 1, time.Minute)
+		sc.Add("forever", 2)
+		clock.Advance(time.Minute - time.Nanosecond)
+		if _, ok := sc.Get("a"); !ok {
+			t.Fatalf("readMostly=%v: a expired early", readMostly)
+		}
+		// 无锁读也在到期时刻判定过期，不需要等写入或清理
+		clock.Advance(time.Nanosecond)
+		if _, ok := sc.Get("a"); ok {
+			t.Fatalf("readMostly=%v: a readable at its deadline", readMostly)
+		}
+		if _, ok := sc.Get("forever"); !ok {
+			t.Fatalf("readMostly=%v: entry without TTL expired", readMostly)
+		}
+		// 重新写入刷新快照中的到期时间
+		sc.AddWithTTL("a", 3, time.Hour)
+		clock.Advance(30 * time.Minute)
+		if v, ok := sc.Get("a"); !ok || v != 3 {
+			t.Fatalf("readMostly=%v: Get(a) = %d, %v after rewrite", readMostly, v, ok)
+		}
+	}
+}
+
+func TestShardedReadBuffer(t *testing.T) {
+	// 一个分片、容量 3：无锁读到的 a 在下次写入前交给 LRU，淘汰的是 b
+	sc, _ := newTestSharded(1, 3, true)
+	var evicted []string
+	sc.Configure(func(c *Cache[string, int]) {
+		c.SetOnEvict(func(key string, _ int, _ EvictReason) { evicted = append(evicted, key) })
+	})
+	sc.Add("a", 1)
+	sc.Add("b", 2)
+	sc.Add("c", 3)
+	sc.Get("a")
+	sc.Add("d", 4) // 顺序 c a d
+	sc.Get("c")
+	sc.Get("d")
+	sc.Add("e", 5) // 读过的 c、d 排到 a 之后
+	if !slices.Equal(evicted, []string{"b", "a"}) {
+		t.Fatalf("evicted %v, want [b a]", evicted)
+	}
+
+	// 缓冲区满了就丢弃访问，不会阻塞读
+	for range 10 * readBufferSize {
+		sc.Get("c")
+	}
+	sc.Add("f", 6)
+	if !slices.Equal(evicted, []string{"b", "a", "d"}) {
+		t.Fatalf("evicted %v, want [b a d]", evicted)
+	}
+}
+
+func TestShardedStats(t *testing.T) {
+	for _, readMostly := range []bool{false, true} {
+		sc, _ := newTestSharded(4, 8, readMostly)
+		sc.Configure(func(c *Cache[string, int]) {
+			c.SetLoader(func(key string) (int, error) { return 0, nil }, 0)
+		})
+		want := Stats{}
+		for i := range 40 {
+			sc.Add(fmt.Sprint(i), i)
+		}
+		for i := range 40 {
+			if _, ok := sc.Get(fmt.Sprint(i)); ok {
+				want.Hits++
+			} else {
+				want.Misses++
+			}
+		}
+		want.Evictions = 40 - sc.Len()
+		// GetOrLoad 未命中时由分片的 Cache 记一次未命中和一次加载
+		sc.GetOrLoad("new")
+		want.Misses++
+		want.Loads++
+		want.Evictions++
+		if st := sc.Stats(); st != want || want.Hits != 8 {
+			t.Fatalf("readMostly=%v: Stats() = %+v, want %+v", readMostly, st, want)
+		}
+	}
+}
+
+// TestShardedConcurrent 用 -race 运行：无锁读与写入、加载并发进行。
+func TestShardedConcurrent(t *testing.T) {
+	sc, _ := newTestSharded(4, 64, true)
+	sc.Configure(func(c *Cache[string, int]) {
+		c.SetLoader(func(key string) (int, error) { return len(key), nil }, 0)
+	})
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				key := fmt.Sprint(i % 100)
+				switch g % 3 {
+				case 0:
+					sc.Add(key, i)
+				case 1:
+					sc.Get(key)
+				case 2:
+					sc.GetOrLoad(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := sc.Len(); n > 64 {
+		t.Fatalf("Len = %d exceeds the capacity", n)
+	}
+}
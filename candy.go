@@ -10,35 +10,170 @@ import "fmt"
 func main() {
 	ratings := []int{1, 2, 2}
 	fmt.Println(candy(ratings))
+	fmt.Println(candyAllocation(ratings))
+	fmt.Println(candySlope(ratings))
+
+	circle := []int{1, 3, 2, 2, 5}
+	alloc := candyCircularAllocation(circle)
+	fmt.Println(candyCircular(circle), alloc, checkCandy(circle, alloc, true))
+
+	stream := &CandyStream{}
+	for _, r := range []int{1, 0, 2} {
+		fmt.Println("online : ", stream.Add(r))
+	}
 }
 
 func candy(ratings []int) int {
+	res := 0
+	for _, c := range candyAllocation(ratings) {
+		res += c
+	}
+	return res
+}
+
+// candyAllocation 返回每个孩子分到的糖果数，只用一个数组：
+// 先从左往右满足左规则，再从右往左用一个变量维护右规则。
+func candyAllocation(ratings []int) []int {
 	n := len(ratings)
+	if n == 0 {
+		return nil
+	}
 
-	left := make([]int, n)
-	left[0] = 1
-	for i := 1; i <= n-1; i++ {
+	res := make([]int, n)
+	res[0] = 1
+	for i := 1; i < n; i++ {
 		if ratings[i] > ratings[i-1] {
-			left[i] = left[i-1] + 1
+			res[i] = res[i-1] + 1
 		} else {
-			left[i] = 1
+			res[i] = 1
 		}
 	}
 
-	right := make([]int, n)
-	right[n-1] = 1
+	right := 1
 	for i := n - 2; i >= 0; i-- {
 		if ratings[i] > ratings[i+1] {
-			right[i] = right[i+1] + 1
+			right++
 		} else {
-			right[i] = 1
+			right = 1
 		}
+		res[i] = max(res[i], right)
 	}
+	return res
+}
 
-	res := 0
+// candySlope 按上坡、下坡计数，O(1) 额外空间求最少糖果总数。
+func candySlope(ratings []int) int {
+	s := &CandyStream{}
+	total := 0
+	for _, r := range ratings {
+		total = s.Add(r)
+	}
+	return total
+}
+
+// candyCircularAllocation 孩子围成一圈时的分配方案。
+// 评分最低的孩子一定只拿 1 个，从它处断开成一条链，并在链尾再放一次它，
+// 这样首尾相邻的约束也能被两次扫描覆盖。
+func candyCircularAllocation(ratings []int) []int {
+	n := len(ratings)
+	if n == 0 {
+		return nil
+	}
+
+	start := 0
+	for i, r := range ratings {
+		if r < ratings[start] {
+			start = i
+		}
+	}
+
+	line := make([]int, n+1)
+	for i := 0; i <= n; i++ {
+		line[i] = ratings[(start+i)%n]
+	}
+	alloc := candyAllocation(line)
+
+	res := make([]int, n)
 	for i := 0; i < n; i++ {
-		res += max(left[i], right[i])
+		res[(start+i)%n] = alloc[i]
 	}
+	return res
+}
 
+func candyCircular(ratings []int) int {
+	res := 0
+	for _, c := range candyCircularAllocation(ratings) {
+		res += c
+	}
 	return res
 }
+
+// CandyStream 在线模式：评分逐个到来，Add 返回当前前缀的最少糖果总数。
+type CandyStream struct {
+	total int
+	last  int // 上一个评分
+	inc   int // 最近一段上坡的长度（坡顶拿到的糖果数）
+	dec   int // 当前下坡的长度
+	pre   int // 上一个孩子拿到的糖果数
+	count int
+}
+
+func (s *CandyStream) Add(rating int) int {
+	if s.count == 0 {
+		s.total, s.inc, s.dec, s.pre = 1, 1, 0, 1
+	} else if rating >= s.last {
+		s.dec = 0
+		if rating == s.last {
+			s.pre = 1
+		} else {
+			s.pre++
+		}
+		s.total += s.pre
+		s.inc = s.pre
+	} else {
+		s.dec++
+		// 下坡和上坡一样长时，坡顶需要再多拿一个
+		if s.dec == s.inc {
+			s.dec++
+		}
+		s.total += s.dec
+		s.pre = 1
+	}
+	s.last = rating
+	s.count++
+	return s.total
+}
+
+func (s *CandyStream) Total() int {
+	return s.total
+}
+
+// checkCandy 校验分配方案是否满足规则，返回第一个违反规则的下标，合法时返回 -1。
+// 长度不一致时返回较短一方的长度。
+func checkCandy(ratings, alloc []int, circular bool) int {
+	n := len(ratings)
+	if len(alloc) != n {
+		return min(n, len(alloc))
+	}
+
+	for i := 0; i < n; i++ {
+		if alloc[i] < 1 {
+			return i
+		}
+		if n == 1 {
+			break
+		}
+
+		prev, next := i-1, i+1
+		if circular {
+			prev, next = (i-1+n)%n, (i+1)%n
+		}
+		if prev >= 0 && ratings[i] > ratings[prev] && alloc[i] <= alloc[prev] {
+			return i
+		}
+		if next < n && ratings[i] > ratings[next] && alloc[i] <= alloc[next] {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,174 @@
+/**
+ * 离线题目目录：题号、中英文标题、slug、Go 函数签名和示例，供 `lc new` 生成题解模板。
+ * 数据在 catalog.json 中，编译时嵌入。
+ */
+package catalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type Param struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Example 一组示例，Args 与 Want 使用 LeetCode 的 JSON 写法。
+type Example struct {
+	Args []json.RawMessage `json:"args"`
+	Want json.RawMessage   `json:"want"`
+}
+
+type Problem struct {
+	ID         int       `json:"id"`
+	Slug       string    `json:"slug"`
+	Title      string    `json:"title"`
+	TitleEn    string    `json:"titleEn"`
+	Difficulty string    `json:"difficulty"`
+	Topics     []string  `json:"topics"`
+	Func       string    `json:"func"`
+	Params     []Param   `json:"params"`
+	Result     string    `json:"result"`            // 为空表示原地修改
+	InPlace    string    `json:"inPlace,omitempty"` // 原地修改的参数，示例的 Want 是它修改后的值
+	Examples   []Example `json:"examples"`
+}
+
+func (p *Problem) URL() string {
+	return "https://leetcode.cn/problems/" + p.Slug + "/"
+}
+
+// Signature 返回 Go 函数签名，如 func twoSum(nums []int, target int) []int。
+func (p *Problem) Signature() string {
+	params := make([]string, len(p.Params))
+	for i, pa := range p.Params {
+		params[i] = pa.Name + " " + pa.Type
+	}
+	sig := "func " + p.Func + "(" + strings.Join(params, ", ") + ")"
+	if p.Result != "" {
+		sig += " " + p.Result
+	}
+	return sig
+}
+
+// WantType 返回示例期望值的 Go 类型。
+func (p *Problem) WantType() string {
+	if p.Result != "" {
+		return p.Result
+	}
+	for _, pa := range p.Params {
+		if pa.Name == p.InPlace {
+			return pa.Type
+		}
+	}
+	return ""
+}
+
+//go:embed catalog.json
+var data []byte
+
+var (
+	loadOnce sync.Once
+	problems []*Problem
+	loadErr  error
+)
+
+// All 返回目录中的所有题目，按题号升序。
+func All() ([]*Problem, error) {
+	loadOnce.Do(func() {
+		if loadErr = json.Unmarshal(data, &problems); loadErr != nil {
+			loadErr = fmt.Errorf("catalog: %w", loadErr)
+			return
+		}
+		slices.SortFunc(problems, func(a, b *Problem) int { return a.ID - b.ID })
+	})
+	return problems, loadErr
+}
+
+// Lookup 按题号或 slug 查找题目。
+func Lookup(key string) (*Problem, error) {
+	all, err := All()
+	if err != nil {
+		return nil, err
+	}
+	id, numErr := strconv.Atoi(key)
+	for _, p := range all {
+		if (numErr == nil && p.ID == id) || p.Slug == key {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("catalog: problem %q not found", key)
+}
+
+// GoLiteral 把 LeetCode 的 JSON 值转成类型为 typ 的 Go 字面量，
+// 例如 [[1,2],[3]] 和 [][]int 得到 [][]int{{1, 2}, {3}}；byte 类型的值写作 "1"，转成 '1'。
+func GoLiteral(typ string, raw json.RawMessage) (string, error) {
+	var v any
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return "", fmt.Errorf("catalog: bad value %s: %w", raw, err)
+	}
+	return literal(typ, v, true)
+}
+
+func literal(typ string, v any, top bool) (string, error) {
+	if elem, ok := strings.CutPrefix(typ, "[]"); ok {
+		arr, ok := v.([]any)
+		if !ok {
+			return "", fmt.Errorf("catalog: %v is not a %s", v, typ)
+		}
+		items := make([]string, len(arr))
+		for i, x := range arr {
+			s, err := literal(elem, x, false)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		// 内层的复合字面量可以省略类型
+		prefix := ""
+		if top {
+			prefix = typ
+		}
+		return prefix + "{" + strings.Join(items, ", ") + "}", nil
+	}
+
+	switch typ {
+	case "int", "int64", "float64":
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("catalog: %v is not a number", v)
+		}
+		if typ != "float64" {
+			if _, err := n.Int64(); err != nil {
+				return "", fmt.Errorf("catalog: %v is not an integer", v)
+			}
+		}
+		return n.String(), nil
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return "", fmt.Errorf("catalog: %v is not a bool", v)
+		}
+		return strconv.FormatBool(b), nil
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return "", fmt.Errorf("catalog: %v is not a string", v)
+		}
+		return strconv.Quote(s), nil
+	case "byte":
+		s, ok := v.(string)
+		if !ok || len(s) != 1 {
+			return "", fmt.Errorf("catalog: %v is not a single character", v)
+		}
+		return strconv.QuoteRune(rune(s[0])), nil
+	}
+	return "", fmt.Errorf("catalog: unsupported type %s", typ)
+}
@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestAll(t *testing.T) {
+	all, err := All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 || !slices.IsSortedFunc(all, func(a, b *Problem) int { return a.ID - b.ID }) {
+		t.Fatalf("All() returned %d problems, not sorted by ID", len(all))
+	}
+	seen := map[string]bool{}
+	for i, p := range all {
+		if i > 0 && all[i-1].ID == p.ID || seen[p.Slug] || seen[p.Func] {
+			t.Errorf("%d. %s: duplicate ID, slug or func", p.ID, p.Slug)
+		}
+		seen[p.Slug], seen[p.Func] = true, true
+		if p.WantType() == "" {
+			t.Errorf("%d: no result and no in-place parameter", p.ID)
+		}
+		// 每个示例都能转成 Go 字面量，scaffold 依赖这一点
+		for j, ex := range p.Examples {
+			if len(ex.Args) != len(p.Params) {
+				t.Errorf("%d: example %d has %d args, want %d", p.ID, j+1, len(ex.Args), len(p.Params))
+				continue
+			}
+			for k, arg := range ex.Args {
+				if _, err := GoLiteral(p.Params[k].Type, arg); err != nil {
+					t.Errorf("%d: example %d: %v", p.ID, j+1, err)
+				}
+			}
+			if _, err := GoLiteral(p.WantType(), ex.Want); err != nil {
+				t.Errorf("%d: example %d: %v", p.ID, j+1, err)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, key := range []string{"1", "two-sum"} {
+		if p, err := Lookup(key); err != nil || p.ID != 1 || p.Func != "twoSum" {
+			t.Errorf("Lookup(%q) = %v, %v", key, p, err)
+		}
+	}
+	if p, err := Lookup("no-such-problem"); err == nil {
+		t.Errorf("Lookup(no-such-problem) = %v", p)
+	}
+	p, _ := Lookup("1")
+	if got, want := p.Signature(), "func twoSum(nums []int, target int) []int"; got != want {
+		t.Errorf("Signature() = %q, want %q", got, want)
+	}
+	if got := p.URL(); got != "https://leetcode.cn/problems/two-sum/" {
+		t.Errorf("URL() = %q", got)
+	}
+	inPlace := &Problem{Func: "rotate", Params: []Param{{"matrix", "[][]int"}}, InPlace: "matrix"}
+	if got := inPlace.Signature(); got != "func rotate(matrix [][]int)" || inPlace.WantType() != "[][]int" {
+		t.Errorf("in-place Signature() = %q, WantType() = %q", got, inPlace.WantType())
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	for _, tc := range []struct {
+		typ, raw, want string
+	}{
+		{"int", "42", "42"},
+		{"int", "-3", "-3"},
+		{"float64", "2.5", "2.5"},
+		{"bool", "true", "true"},
+		{"string", `"a\"b"`, `"a\"b"`},
+		{"byte", `"1"`, "'1'"},
+		{"[]int", "[]", "[]int{}"},
+		{"[][]int", "[[1,2],[3]]", "[][]int{{1, 2}, {3}}"},
+		{"[][]byte", `[["a","."]]`, `[][]byte{{'a', '.'}}`},
+		{"[]string", `["x","y"]`, `[]string{"x", "y"}`},
+	} {
+		got, err := GoLiteral(tc.typ, json.RawMessage(tc.raw))
+		if err != nil || got != tc.want {
+			t.Errorf("GoLiteral(%s, %s) = %q, %v, want %q", tc.typ, tc.raw, got, err, tc.want)
+		}
+	}
+	for _, tc := range []struct{ typ, raw string }{
+		{"int", "1.5"},
+		{"int", `"1"`},
+		{"bool", "1"},
+		{"string", "1"},
+		{"byte", `"ab"`},
+		{"[]int", "1"},
+		{"[]int", "[true]"},
+		{"complex128", "1"},
+		{"int", "[1"},
+	} {
+		if got, err := GoLiteral(tc.typ, json.RawMessage(tc.raw)); err == nil {
+			t.Errorf("GoLiteral(%s, %s) = %q, want an error", tc.typ, tc.raw, got)
+		}
+	}
+}
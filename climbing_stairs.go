@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"leetcode-go/dp"
+)
 
 /**
  * 爬楼梯
@@ -13,6 +17,9 @@ func main() {
 
 	total = climbStairs2(45)
 	fmt.Println("动态规划 : ", total)
+
+	total = climbStairs3(45)
+	fmt.Println("记忆化递归 : ", total)
 }
 
 func climbStairs(n int) int {
@@ -38,3 +45,21 @@ func climbStairs2(n int) int {
 	}
 	return dp[n]
 }
+
+// climbStairs3 与 climbStairs 的递归写法相同，递归调用换成 rec 后由 dp.Memo 记忆化，每次调用新建缓存
+func climbStairs3(n int) int {
+
+	climb := dp.Memo(func(rec func(int) int, n int) int {
+
+		if n < 2 {
+			return 1
+		}
+
+		if n == 2 {
+			return 2
+		}
+
+		return rec(n-1) + rec(n-2)
+	})
+	return climb(n)
+}
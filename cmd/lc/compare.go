@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"leetcode-go/compare"
+	"leetcode-go/registry"
+)
+
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	format := fs.String("format", "md", "report format: md or html")
+	out := fs.String("o", "", "write the report to file instead of stdout")
+	sizes := fs.String("sizes", "10,100,1000,10000", "comma-separated input sizes to benchmark")
+	cases := fs.Int("cases", 200, "number of random cases to judge")
+	maxN := fs.Int("maxn", 64, "maximum size of random cases")
+	seed := fs.Int64("seed", 1, "random seed")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("usage: lc compare [flags] <number-or-slug>")
+	}
+	p, err := lookupProblem(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	opts := compare.Options{Cases: *cases, MaxN: *maxN, Seed: *seed}
+	for _, s := range strings.Split(*sizes, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || n < 1 {
+			return fmt.Errorf("bad size %q", s)
+		}
+		opts.Sizes = append(opts.Sizes, n)
+	}
+
+	write := (*compare.Report).WriteMarkdown
+	switch *format {
+	case "md":
+	case "html":
+		write = (*compare.Report).WriteHTML
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	rep, err := compare.Run(p, opts)
+	if err != nil {
+		return err
+	}
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := write(rep, w); err != nil {
+		return err
+	}
+
+	for _, v := range rep.Verdicts {
+		if !v.OK() {
+			return exitError(1)
+		}
+	}
+	return nil
+}
+
+// lookupProblem 按题号或 slug 查找已登记的题目。
+func lookupProblem(key string) (*registry.Problem, error) {
+	var p *registry.Problem
+	var ok bool
+	if id, err := strconv.Atoi(key); err == nil {
+		p, ok = registry.Lookup(id)
+	} else {
+		p, ok = registry.LookupSlug(key)
+	}
+	if !ok {
+		return nil, fmt.Errorf("problem %q is not registered", key)
+	}
+	return p, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"leetcode-go/interview"
+	"leetcode-go/registry"
+)
+
+func runInterview(args []string) error {
+	fs := flag.NewFlagSet("interview", flag.ExitOnError)
+	difficulty := fs.String("difficulty", "", "pick from Easy, Medium or Hard")
+	topic := fs.String("topic", "", "pick problems with this topic")
+	fresh := fs.Bool("fresh", false, "skip problems already accepted in earlier sessions")
+	limit := fs.Duration("limit", 0, "time limit (default 15m/30m/45m by difficulty)")
+	dir := fs.String("dir", "_interviews", "directory for session workspaces and transcripts")
+	hints := fs.String("hints", "", "directory of hint files (default hints/ in the module root)")
+	seed := fs.Int64("seed", 0, "random seed for picking and judging (default current time)")
+	cases := fs.Int("cases", 200, "number of random cases to judge")
+	maxN := fs.Int("maxn", 64, "maximum size of random cases")
+	timeout := fs.Duration("timeout", 10*time.Second, "run time limit of each submission")
+	fs.Parse(args)
+
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	if *hints == "" {
+		*hints = filepath.Join(root, "hints")
+	}
+
+	var p *registry.Problem
+	if fs.NArg() > 0 {
+		if p, err = lookupProblem(fs.Arg(0)); err != nil {
+			return err
+		}
+		if !interview.Judgeable(p) {
+			return fmt.Errorf("%d. %s cannot be judged: it is a design problem or has no examples and no generator", p.ID, p.Title)
+		}
+	} else {
+		exclude := map[int]bool{}
+		if *fresh {
+			exclude = acceptedProblems(*dir)
+		}
+		f := interview.Filter{Difficulty: registry.Difficulty(*difficulty), Topic: *topic}
+		p, err = interview.Pick(registry.All(), f, exclude, rand.New(rand.NewSource(*seed)))
+		if err != nil {
+			return err
+		}
+	}
+
+	hintList, err := interview.LoadHints(*hints, p.Slug)
+	if err != nil {
+		return err
+	}
+	if *limit == 0 {
+		*limit = time.Duration(interview.Limit[p.Difficulty]) * time.Minute
+	}
+
+	ws := filepath.Join(*dir, time.Now().Format("20060102-150405")+"-"+p.Slug)
+	if err := os.MkdirAll(ws, 0o755); err != nil {
+		return err
+	}
+	stub, err := interview.Stub(p)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(ws, "solution.go")
+	if err := os.WriteFile(file, stub, 0o644); err != nil {
+		return err
+	}
+
+	opts := interview.JudgeOptions{Cases: *cases, MaxN: *maxN, Seed: *seed, Timeout: *timeout}
+	s := &interview.Session{
+		Problem:    p,
+		Hints:      hintList,
+		Limit:      *limit,
+		File:       file,
+		Transcript: filepath.Join(ws, "transcript.json"),
+		Judge: func(file string) (*interview.Result, error) {
+			return interview.Judge(root, file, p, opts)
+		},
+	}
+	return s.Run(os.Stdin, os.Stdout)
+}
+
+// acceptedProblems 读取 dir 下各次面试的记录，返回已经通过的题号。
+func acceptedProblems(dir string) map[int]bool {
+	res := map[int]bool{}
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "transcript.json"))
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		var t interview.Transcript
+		if json.Unmarshal(data, &t) == nil && t.Accepted {
+			res[t.ID] = true
+		}
+	}
+	return res
+}
@@ -0,0 +1,66 @@
+/**
+ * lc 是本仓库的命令行工具：
+ *
+ *	lc vet [dir | dir/...]   检查题解质量，见 lint 包
+ *	lc new <number-or-slug>  从离线题目目录生成题解、测试和基准测试模板
+ *	lc compare <problem>     对拍同一道题的各种解法并比较性能，输出 Markdown 或 HTML 报告
+ *	lc interview [problem]   模拟面试：抽题、计时、分阶段提示、评测提交并记录过程
+ *	lc notes search <query>  全文搜索 Markdown 笔记；lc notes for <problem> 列出题目相关的笔记
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"vet", "vet [dir | dir/...]", runVet},
+	{"new", "new [-dir dir] [-n] [-list] <number-or-slug>", runNew},
+	{"compare", "compare [-format md|html] [-o file] [-sizes n,...] <number-or-slug>", runCompare},
+	{"interview", "interview [-difficulty d] [-topic t] [-limit dur] [number-or-slug]", runInterview},
+	{"notes", "notes search <query> | for <problem | file.go> | check", runNotes},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: lc <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	for _, c := range commands {
+		fmt.Fprintln(os.Stderr, "  lc "+c.usage)
+	}
+	os.Exit(2)
+}
+
+// exitError 让命令指定退出码而不打印额外信息，例如 vet 发现问题时返回 1。
+type exitError int
+
+func (e exitError) Error() string {
+	return fmt.Sprintf("exit status %d", int(e))
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		err := c.run(os.Args[2:])
+		if code, ok := err.(exitError); ok {
+			os.Exit(int(code))
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "lc "+c.name+":", err)
+			os.Exit(1)
+		}
+		return
+	}
+	usage()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"leetcode-go/catalog"
+	_ "leetcode-go/problems"
+	"leetcode-go/scaffold"
+)
+
+func runNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	dir := fs.String("dir", "problems", "directory of the problems package")
+	list := fs.Bool("list", false, "list the catalog and exit")
+	dryRun := fs.Bool("n", false, "print the generated files instead of writing them")
+	fs.Parse(args)
+
+	if *list {
+		all, err := catalog.All()
+		if err != nil {
+			return err
+		}
+		for _, p := range all {
+			fmt.Printf("%4d  %-6s  %s  %s\n", p.ID, p.Difficulty, p.Slug, p.Title)
+		}
+		return nil
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: lc new [-dir dir] [-n] <number-or-slug>")
+	}
+
+	p, err := catalog.Lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	if err := scaffold.CheckDuplicate(p, root); err != nil {
+		return err
+	}
+	files, err := scaffold.Render(p, *dir)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		for _, f := range files {
+			fmt.Printf("// %s\n%s\n", f.Path, f.Content)
+		}
+		return nil
+	}
+	if err := scaffold.Write(files); err != nil {
+		return err
+	}
+	for _, f := range files {
+		fmt.Println(f.Path)
+	}
+	return nil
+}
+
+// moduleRoot 从当前目录向上找 go.mod 所在目录。
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"leetcode-go/catalog"
+	"leetcode-go/notes"
+)
+
+func runNotes(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: lc notes search <query> | for <problem | file.go> | check")
+	}
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	// hints 目录是面试用的提示，不进索引
+	idx, err := notes.Load(root, "hints")
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "search":
+		return notesSearch(idx, args[1:])
+	case "for":
+		return notesFor(idx, root, args[1:])
+	case "check":
+		return notesCheck(idx, root)
+	}
+	return fmt.Errorf("unknown subcommand %q", args[0])
+}
+
+func notesSearch(idx *notes.Index, args []string) error {
+	fs := flag.NewFlagSet("notes search", flag.ExitOnError)
+	limit := fs.Int("n", 10, "maximum number of results")
+	fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errors.New("usage: lc notes search [-n limit] <query>")
+	}
+
+	hits := idx.Search(strings.Join(fs.Args(), " "), *limit)
+	if len(hits) == 0 {
+		fmt.Println("no matches")
+	}
+	for _, h := range hits {
+		printSection(h.Section)
+		fmt.Printf("    %s\n", h.Snippet)
+	}
+	return nil
+}
+
+func printSection(s *notes.Section) {
+	fmt.Printf("%s:%d  %s\n", s.File, s.Line, strings.Join(s.Path, " > "))
+}
+
+// notesFor 列出题目（或 Go 文件）声明的笔记，再按题目标题全文搜索补充相关小节。
+func notesFor(idx *notes.Index, root string, args []string) error {
+	fs := flag.NewFlagSet("notes for", flag.ExitOnError)
+	limit := fs.Int("n", 5, "maximum number of related sections found by search")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return errors.New("usage: lc notes for [-n limit] <number | slug | file.go>")
+	}
+	srcs, err := notes.ScanSources(root)
+	if err != nil {
+		return err
+	}
+
+	key := fs.Arg(0)
+	var links []notes.Link
+	query := ""
+	if strings.HasSuffix(key, ".go") {
+		for _, src := range srcs {
+			if src.File == strings.TrimPrefix(key, "./") {
+				links = src.Links
+			}
+		}
+	} else {
+		slug, title, err := resolveProblem(key)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n\n", title)
+		links = notes.LinksFor(srcs, slug)
+		query = title
+	}
+
+	seen := map[string]bool{}
+	fmt.Println("declared:")
+	if len(links) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, l := range links {
+		s, ok := idx.Lookup(l.Ref)
+		if !ok {
+			fmt.Printf("  %s  (unknown, declared in %s:%d)\n", l.Ref, l.File, l.Line)
+			continue
+		}
+		seen[s.Ref()] = true
+		fmt.Printf("  ")
+		printSection(s)
+		fmt.Printf("    declared in %s:%d\n", l.File, l.Line)
+	}
+
+	if query == "" {
+		return nil
+	}
+	fmt.Println("\nrelated:")
+	n := 0
+	for _, h := range idx.Search(query, *limit+len(seen)) {
+		if seen[h.Ref()] || n == *limit {
+			continue
+		}
+		n++
+		fmt.Printf("  ")
+		printSection(h.Section)
+	}
+	if n == 0 {
+		fmt.Println("  (none)")
+	}
+	return nil
+}
+
+// resolveProblem 按题号或 slug 在 registry 和题目目录中查找题目，找不到时把参数当作 slug。
+func resolveProblem(key string) (slug, title string, err error) {
+	if p, err := lookupProblem(key); err == nil {
+		return p.Slug, fmt.Sprintf("%d. %s", p.ID, p.Title), nil
+	}
+	if p, err := catalog.Lookup(key); err == nil {
+		return p.Slug, fmt.Sprintf("%d. %s", p.ID, p.Title), nil
+	}
+	if _, err := strconv.Atoi(key); err == nil {
+		return "", "", fmt.Errorf("problem %s not found", key)
+	}
+	return key, key, nil
+}
+
+// notesCheck 报告无法解析的笔记声明。
+func notesCheck(idx *notes.Index, root string) error {
+	srcs, err := notes.ScanSources(root)
+	if err != nil {
+		return err
+	}
+	bad := 0
+	for _, src := range srcs {
+		for _, l := range src.Links {
+			if _, ok := idx.Lookup(l.Ref); !ok {
+				fmt.Printf("%s:%d: unknown note %s\n", l.File, l.Line, l.Ref)
+				bad++
+			}
+		}
+	}
+	if bad > 0 {
+		return exitError(1)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"leetcode-go/lint"
+)
+
+func runVet(args []string) error {
+	fs := flag.NewFlagSet("vet", flag.ExitOnError)
+	only := fs.String("only", "", "comma-separated analyzers to run (default all)")
+	list := fs.Bool("list", false, "list analyzers and exit")
+	fs.Parse(args)
+
+	if *list {
+		for _, a := range lint.Analyzers {
+			fmt.Printf("%-14s %s\n", a.Name, a.Doc)
+		}
+		return nil
+	}
+
+	analyzers := lint.Analyzers
+	if *only != "" {
+		analyzers = nil
+		for _, name := range strings.Split(*only, ",") {
+			a := findAnalyzer(name)
+			if a == nil {
+				return fmt.Errorf("unknown analyzer %q", name)
+			}
+			analyzers = append(analyzers, a)
+		}
+	}
+
+	diags, err := lint.Run(fs.Args(), analyzers)
+	if err != nil {
+		return err
+	}
+	wd, _ := os.Getwd()
+	for _, d := range diags {
+		if rel, err := filepath.Rel(wd, d.Pos.Filename); err == nil {
+			d.Pos.Filename = rel
+		}
+		fmt.Println(d)
+	}
+	if len(diags) > 0 {
+		return exitError(1)
+	}
+	return nil
+}
+
+func findAnalyzer(name string) *analysis.Analyzer {
+	for _, a := range lint.Analyzers {
+		if a.Name == strings.TrimSpace(name) {
+			return a
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package compare
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"time"
+
+	"leetcode-go/registry"
+)
+
+// Row 一种解法在一个输入规模上的基准结果。
+type Row struct {
+	Approach    string
+	N           int
+	NsPerOp     int64
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+// Benchmark 对每个规模用 p.Gen 生成一组输入，各解法在同一组输入上计时并统计内存分配。
+// 调用经过反射，每次都有相同的固定开销：ns/op 多几十纳秒，allocs/op 和 B/op 包含反射返回结果时的分配，
+// 所以结果只用于解法之间的比较，报告中也注明了这一点。运行出错的解法不出现在结果中。
+func Benchmark(p *registry.Problem, sizes []int, seed int64) ([]Row, error) {
+	if p.Gen == nil {
+		return nil, errors.New("compare: problem has no input generator")
+	}
+	var rows []Row
+	for _, n := range sizes {
+		args := p.Gen(rand.New(rand.NewSource(seed)), n)
+		for _, a := range p.Solutions() {
+			if a.MaxN > 0 && n > a.MaxN {
+				continue
+			}
+			row, ok := benchmark(a.Func, args)
+			if !ok {
+				continue
+			}
+			row.Approach, row.N = a.Name, n
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// benchTime 每个解法在每个规模上的计时总时长，与 go test -bench 的默认值相同。
+const benchTime = time.Second
+
+// benchmark 返回 fn 的 NsPerOp、AllocsPerOp 和 BytesPerOp。
+func benchmark(fn any, args []any) (Row, bool) {
+	f := reflect.ValueOf(fn)
+	in := func() []reflect.Value {
+		vals := make([]reflect.Value, len(args))
+		for i, a := range args {
+			vals[i] = reflect.ValueOf(clone(a))
+			if !vals[i].IsValid() {
+				vals[i] = reflect.Zero(f.Type().In(i))
+			}
+		}
+		return vals
+	}
+
+	out := call(fn, args)
+	if out.err != nil {
+		return Row{}, false
+	}
+	// 不修改参数的解法可以反复使用同一组参数，否则每次调用前拷贝一份，拷贝不计入结果
+	reuse := reflect.DeepEqual(out.args, args)
+	shared := in()
+
+	// 每轮先准备好 batch 组参数再统一计时。单轮太短时 batch 翻倍，摊薄计时和读取内存统计的开销；
+	// 慢的解法 batch 保持很小，不会一次拷贝出大量输入
+	var elapsed time.Duration
+	var ops, mallocs, bytes uint64
+	var before, after runtime.MemStats
+	for batch := 1; elapsed < benchTime; {
+		vals := make([][]reflect.Value, batch)
+		for i := range vals {
+			if reuse {
+				vals[i] = shared
+			} else {
+				vals[i] = in()
+			}
+		}
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		for _, v := range vals {
+			f.Call(v)
+		}
+		round := time.Since(start)
+		runtime.ReadMemStats(&after)
+		elapsed += round
+		ops += uint64(batch)
+		mallocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+		if round < benchTime/100 {
+			batch *= 2
+		}
+	}
+	return Row{
+		NsPerOp:     elapsed.Nanoseconds() / int64(ops),
+		AllocsPerOp: int64(mallocs / ops),
+		BytesPerOp:  int64(bytes / ops),
+	}, true
+}
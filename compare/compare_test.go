@@ -0,0 +1,137 @@
+package compare
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"leetcode-go/registry"
+)
+
+func sum(nums []int) int {
+	s := 0
+	for _, x := range nums {
+		s += x
+	}
+	return s
+}
+
+// problem 一道不在题目目录中的题，只能用随机用例对拍。
+var problem = &registry.Problem{
+	ID: 99999, Slug: "compare-test-sum", Title: "求和",
+	Solution: sum,
+	Approaches: []registry.Approach{
+		{Name: "loop", Func: sum},
+		{Name: "wrong", Func: func(nums []int) int { return len(nums) }},
+		{Name: "panics", Func: func(nums []int) int { return nums[len(nums)] }},
+		{Name: "tiny", Func: sum, MaxN: 1},
+	},
+	Gen: func(r *rand.Rand, n int) []any {
+		nums := make([]int, n)
+		for i := range nums {
+			nums[i] = r.Intn(10) + 2
+		}
+		return []any{nums}
+	},
+}
+
+func TestVerify(t *testing.T) {
+	cases := Random(problem, 20, 5, 1)
+	cases = append(cases, Case{Name: "n=10", N: 10, Args: []any{[]int{1, 2}}})
+	got := map[string]Verdict{}
+	for _, v := range Verify(problem, cases) {
+		got[v.Approach] = v
+	}
+	for name, want := range map[string]struct {
+		ok       bool
+		status   string
+		failures bool
+	}{
+		"loop":   {true, "PASS", false},
+		"wrong":  {false, "FAIL", true},
+		"panics": {false, "FAIL", true},
+	} {
+		v := got[name]
+		if v.OK() != want.ok || v.status() != want.status || (len(v.Failures) > 0) != want.failures {
+			t.Errorf("%s: %+v, status %s", name, v, v.status())
+		}
+	}
+	if v := got["tiny"]; v.Skipped == 0 || v.Passed+v.Skipped != len(cases) {
+		t.Errorf("tiny: %+v", v)
+	}
+	if v := got["wrong"]; len(v.Failures) != maxFailures {
+		t.Errorf("wrong recorded %d failures, want %d", len(v.Failures), maxFailures)
+	}
+}
+
+func TestNoCases(t *testing.T) {
+	// 所有用例都被跳过或者没有用例时，不算通过
+	for _, v := range []Verdict{{Approach: "none"}, {Approach: "skipped", Skipped: 3}} {
+		if v.OK() || v.status() != "NO CASES" {
+			t.Errorf("%+v: OK() = %v, status %s", v, v.OK(), v.status())
+		}
+	}
+	p := *problem
+	p.Gen = nil
+	rep, err := Run(&p, Options{Cases: 10, MaxN: 5, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rep.Cases != 0 || len(rep.Rows) != 0 {
+		t.Fatalf("Run without a generator or examples: %d cases, %d rows", rep.Cases, len(rep.Rows))
+	}
+	for _, write := range []func(*Report, *strings.Builder) error{
+		func(r *Report, b *strings.Builder) error { return r.WriteMarkdown(b) },
+		func(r *Report, b *strings.Builder) error { return r.WriteHTML(b) },
+	} {
+		var b strings.Builder
+		if err := write(rep, &b); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(b.String(), "| PASS |") || strings.Contains(b.String(), ">PASS<") || !strings.Contains(b.String(), "NO CASES") {
+			t.Errorf("report without cases:\n%s", b.String())
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	rep := &Report{
+		Problem:  problem,
+		Cases:    2,
+		Verdicts: []Verdict{{Approach: "loop", Passed: 2}, {Approach: "wrong", Passed: 1, Failures: []Failure{{"random 1", "[3]", "1", "3"}}}},
+		Rows: []Row{
+			{"loop", 10, 100, 0, 0},
+			{"wrong", 10, 50, 2, 64},
+			{"loop", 100, 900, 0, 0},
+		},
+	}
+	var md, html strings.Builder
+	if err := rep.WriteMarkdown(&md); err != nil {
+		t.Fatal(err)
+	}
+	if err := rep.WriteHTML(&html); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"| loop | PASS | 2 | 0 |", "| wrong | FAIL | 1 | 0 |", "`wrong` random 1: args `[3]` got `1` want `3`", "| wrong | 10 | 50 | 2 | 64 |", allocNote} {
+		if !strings.Contains(md.String(), want) {
+			t.Errorf("markdown has no %q:\n%s", want, md.String())
+		}
+	}
+	if !strings.Contains(html.String(), `<td class="FAIL">FAIL</td>`) || !strings.Contains(html.String(), allocNote) {
+		t.Errorf("html:\n%s", html.String())
+	}
+
+	var b strings.Builder
+	if err := Chart(&b, rep.Rows, Metrics[0], 10); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprint("n=10\n",
+		"  loop  |##########|      100\n",
+		"  wrong |#####     |       50\n",
+		"n=100\n",
+		"  loop  |##########|      900\n")
+	if b.String() != want {
+		t.Errorf("Chart:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
@@ -0,0 +1,265 @@
+/**
+ * 对比同一道题的多种解法：先用题目目录中的示例和随机用例对拍，再在不同输入规模上测
+ * ns/op 和 allocs/op，生成带表格和 ASCII 图表的 Markdown 或 HTML 报告，见 lc compare。
+ */
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"leetcode-go/catalog"
+	"leetcode-go/registry"
+)
+
+// Case 一组对拍用例。Want 非 nil 时是示例给出的期望值，否则以 Solution 的结果为准；
+// 题目提供 Check 时总是以 Check 为准。
+type Case struct {
+	Name string
+	N    int // 输入规模，示例为 0
+	Args []any
+	Want any
+}
+
+// Failure 一个没有通过的用例。
+type Failure struct {
+	Case string
+	Args string
+	Got  string
+	Want string
+}
+
+// Verdict 一种解法的对拍结果。
+type Verdict struct {
+	Approach string
+	Passed   int
+	Skipped  int // 超过 MaxN 的用例
+	Failures []Failure
+}
+
+// OK 没有失败且至少通过了一个用例。一个用例都没有运行（没有用例，或都超过了 MaxN）不算通过。
+func (v Verdict) OK() bool {
+	return len(v.Failures) == 0 && v.Passed > 0
+}
+
+// maxFailures 每种解法最多记录的失败用例数。
+const maxFailures = 5
+
+// outcome 一次调用的结果：返回值以及调用后的参数（原地修改的题目看参数）。
+type outcome struct {
+	rets []any
+	args []any
+	err  error
+}
+
+// call 用参数的深拷贝调用 fn，fn 中的 panic 作为错误返回。
+func call(fn any, args []any) (out outcome) {
+	f := reflect.ValueOf(fn)
+	t := f.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != len(args) {
+		return outcome{err: fmt.Errorf("%s does not take %d arguments", t, len(args))}
+	}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		in[i] = reflect.ValueOf(clone(a))
+		if !in[i].IsValid() {
+			in[i] = reflect.Zero(t.In(i))
+		}
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			out = outcome{err: fmt.Errorf("panic: %v", r)}
+		}
+	}()
+	for _, v := range f.Call(in) {
+		out.rets = append(out.rets, v.Interface())
+	}
+	for _, v := range in {
+		out.args = append(out.args, v.Interface())
+	}
+	return out
+}
+
+// clone 深拷贝切片，其余值原样返回。
+func clone(x any) any {
+	if x == nil {
+		return nil
+	}
+	return cloneValue(reflect.ValueOf(x)).Interface()
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Slice || v.IsNil() {
+		return v
+	}
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	for i := 0; i < v.Len(); i++ {
+		c.Index(i).Set(cloneValue(v.Index(i)))
+	}
+	return c
+}
+
+// Examples 把题目目录中的示例转成用例，参数按 Solution 的参数类型解码，解码失败的示例跳过。
+// 目录中原地修改的题目，Want 对应的是被修改的参数。
+func Examples(p *registry.Problem) []Case {
+	cp, err := catalog.Lookup(p.Slug)
+	if err != nil {
+		return nil
+	}
+	t := reflect.TypeOf(p.Solution)
+	if t.NumIn() != len(cp.Params) {
+		return nil
+	}
+
+	var cases []Case
+next:
+	for i, ex := range cp.Examples {
+		c := Case{Name: fmt.Sprintf("example %d", i+1)}
+		for j, raw := range ex.Args {
+			v := reflect.New(t.In(j))
+			if json.Unmarshal(raw, v.Interface()) != nil {
+				continue next
+			}
+			c.Args = append(c.Args, v.Elem().Interface())
+		}
+		wt := wantType(t, cp)
+		if wt == nil {
+			continue
+		}
+		w := reflect.New(wt)
+		if json.Unmarshal(ex.Want, w.Interface()) != nil {
+			continue
+		}
+		c.Want = w.Elem().Interface()
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+func wantType(t reflect.Type, cp *catalog.Problem) reflect.Type {
+	if cp.InPlace == "" {
+		if t.NumOut() == 0 {
+			return nil
+		}
+		return t.Out(0)
+	}
+	if i := inPlaceIndex(cp); i >= 0 {
+		return t.In(i)
+	}
+	return nil
+}
+
+func inPlaceIndex(cp *catalog.Problem) int {
+	for i, pa := range cp.Params {
+		if pa.Name == cp.InPlace {
+			return i
+		}
+	}
+	return -1
+}
+
+// Random 用 p.Gen 生成 count 个规模在 [1, maxN] 内的随机用例。
+func Random(p *registry.Problem, count, maxN int, seed int64) []Case {
+	if p.Gen == nil || maxN < 1 {
+		return nil
+	}
+	r := rand.New(rand.NewSource(seed))
+	cases := make([]Case, count)
+	for i := range cases {
+		n := r.Intn(maxN) + 1
+		cases[i] = Case{Name: fmt.Sprintf("random %d (n=%d)", i+1, n), N: n, Args: p.Gen(r, n)}
+	}
+	return cases
+}
+
+// Verify 对每种解法运行所有用例。
+func Verify(p *registry.Problem, cases []Case) []Verdict {
+	inPlace := -1
+	if cp, err := catalog.Lookup(p.Slug); err == nil {
+		inPlace = inPlaceIndex(cp)
+	}
+
+	// 标准答案只算一次
+	refs := make([]outcome, len(cases))
+	if p.Check == nil {
+		for i, c := range cases {
+			if c.Want == nil {
+				refs[i] = call(p.Solution, c.Args)
+			}
+		}
+	}
+
+	var verdicts []Verdict
+	for _, a := range p.Solutions() {
+		v := Verdict{Approach: a.Name}
+		for i, c := range cases {
+			if a.MaxN > 0 && c.N > a.MaxN {
+				v.Skipped++
+				continue
+			}
+			got := call(a.Func, c.Args)
+			if msg, want := judge(p, c, refs[i], got, inPlace); msg != "" {
+				if len(v.Failures) < maxFailures {
+					v.Failures = append(v.Failures, Failure{c.Name, format(c.Args), msg, want})
+				}
+				continue
+			}
+			v.Passed++
+		}
+		verdicts = append(verdicts, v)
+	}
+	return verdicts
+}
+
+// judge 判断一次调用的结果，通过时返回空串，否则返回实际结果和期望结果的描述。
+func judge(p *registry.Problem, c Case, ref, got outcome, inPlace int) (string, string) {
+	if got.err != nil {
+		return got.err.Error(), ""
+	}
+	switch {
+	case p.Check != nil:
+		if err := p.Check(c.Args, got.rets); err != nil {
+			return format(got.rets), err.Error()
+		}
+	case c.Want != nil:
+		res := got.args
+		if inPlace < 0 {
+			res = got.rets
+		} else {
+			res = res[inPlace:]
+		}
+		if len(res) == 0 || !reflect.DeepEqual(res[0], c.Want) {
+			return format(res), format([]any{c.Want})
+		}
+	default:
+		if ref.err != nil {
+			return "-", "solution: " + ref.err.Error()
+		}
+		// 没有返回值的是原地修改的题目，比较调用后的参数；有返回值时参数可以被随意修改
+		if len(ref.rets) == 0 {
+			if !reflect.DeepEqual(got.args, ref.args) {
+				return format(got.args), format(ref.args)
+			}
+		} else if !reflect.DeepEqual(got.rets, ref.rets) {
+			return format(got.rets), format(ref.rets)
+		}
+	}
+	return "", ""
+}
+
+// format 以逗号分隔输出，过长时截断。
+func format(vals []any) string {
+	s := make([]string, len(vals))
+	for i, v := range vals {
+		s[i] = fmt.Sprint(v)
+	}
+	res := strings.Join(s, ", ")
+	if r := []rune(res); len(r) > 80 {
+		res = string(r[:77]) + "..."
+	}
+	return res
+}
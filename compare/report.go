@@ -0,0 +1,225 @@
+package compare
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"leetcode-go/registry"
+)
+
+// Options lc compare 的参数。
+type Options struct {
+	Sizes []int // 基准测试的输入规模
+	Cases int   // 随机对拍用例数
+	MaxN  int   // 随机对拍用例的最大规模
+	Seed  int64
+}
+
+// Report 一道题的对比报告。
+type Report struct {
+	Problem  *registry.Problem
+	Cases    int
+	Verdicts []Verdict
+	Rows     []Row // 没有 Gen 的题目为空
+}
+
+// Run 对拍并测性能。
+func Run(p *registry.Problem, opts Options) (*Report, error) {
+	cases := append(Examples(p), Random(p, opts.Cases, opts.MaxN, opts.Seed)...)
+	rep := &Report{Problem: p, Cases: len(cases), Verdicts: Verify(p, cases)}
+	if p.Gen != nil {
+		rows, err := Benchmark(p, opts.Sizes, opts.Seed)
+		if err != nil {
+			return nil, err
+		}
+		rep.Rows = rows
+	}
+	return rep, nil
+}
+
+// Metric 图表展示的一项指标。
+type Metric struct {
+	Name  string
+	Value func(Row) int64
+}
+
+var Metrics = []Metric{
+	{"ns/op", func(r Row) int64 { return r.NsPerOp }},
+	{"allocs/op", func(r Row) int64 { return r.AllocsPerOp }},
+}
+
+// Chart 按输入规模分组画横向条形图，每组内按最大值缩放到 width 个字符：
+//
+//	n=1000
+//	  hash      |##########                    |     1520
+//	  sort      |##############################|     4711
+func Chart(w io.Writer, rows []Row, m Metric, width int) error {
+	nameWidth := 0
+	for _, r := range rows {
+		nameWidth = max(nameWidth, len(r.Approach))
+	}
+	for _, group := range groupBySize(rows) {
+		var peak int64
+		for _, r := range group {
+			peak = max(peak, m.Value(r))
+		}
+		if _, err := fmt.Fprintf(w, "n=%d\n", group[0].N); err != nil {
+			return err
+		}
+		for _, r := range group {
+			v := m.Value(r)
+			bar := 0
+			if peak > 0 {
+				bar = int(int64(width) * v / peak)
+			}
+			// 非零值至少画一格，和零区分开
+			if v > 0 && bar == 0 {
+				bar = 1
+			}
+			if _, err := fmt.Fprintf(w, "  %-*s |%s%s| %8d\n", nameWidth, r.Approach,
+				strings.Repeat("#", bar), strings.Repeat(" ", width-bar), v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func groupBySize(rows []Row) [][]Row {
+	var groups [][]Row
+	for i, r := range rows {
+		if i == 0 || r.N != rows[i-1].N {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], r)
+	}
+	return groups
+}
+
+const chartWidth = 40
+
+func (rep *Report) chart(m Metric) string {
+	var sb strings.Builder
+	Chart(&sb, rep.Rows, m, chartWidth)
+	return sb.String()
+}
+
+func (v Verdict) status() string {
+	switch {
+	case v.OK():
+		return "PASS"
+	case len(v.Failures) == 0:
+		return "NO CASES"
+	}
+	return "FAIL"
+}
+
+// allocNote 内存统计包含经反射调用的固定开销。
+const allocNote = "allocs/op 和 B/op 包含经反射调用解法的固定开销（返回值等），各解法相同，只适合相互比较，不是解法本身的绝对值。"
+
+// WriteMarkdown 输出 Markdown 格式的报告。
+func (rep *Report) WriteMarkdown(w io.Writer) error {
+	p := rep.Problem
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %d. %s\n\n%s\n\n", p.ID, p.Title, p.URL())
+
+	fmt.Fprintf(&sb, "## 对拍\n\n共 %d 个用例。\n\n", rep.Cases)
+	sb.WriteString("| approach | result | passed | skipped |\n|---|---|---|---|\n")
+	for _, v := range rep.Verdicts {
+		fmt.Fprintf(&sb, "| %s | %s | %d | %d |\n", v.Approach, v.status(), v.Passed, v.Skipped)
+	}
+	sep := "\n"
+	for _, v := range rep.Verdicts {
+		for _, f := range v.Failures {
+			fmt.Fprintf(&sb, "%s- `%s` %s: args `%s` got `%s` want `%s`\n", sep, v.Approach, f.Case, f.Args, f.Got, f.Want)
+			sep = ""
+		}
+	}
+
+	sb.WriteString("\n## 性能\n\n")
+	if len(rep.Rows) == 0 {
+		sb.WriteString("这道题没有随机输入生成器，跳过。\n")
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+	sb.WriteString("| approach | n | ns/op | allocs/op | B/op |\n|---|---|---|---|---|\n")
+	for _, r := range rep.Rows {
+		fmt.Fprintf(&sb, "| %s | %d | %d | %d | %d |\n", r.Approach, r.N, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp)
+	}
+	fmt.Fprintf(&sb, "\n%s\n", allocNote)
+	for _, m := range Metrics {
+		fmt.Fprintf(&sb, "\n### %s\n\n```\n%s```\n", m.Name, rep.chart(m))
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteHTML 输出单个 HTML 页面的报告，图表与 Markdown 相同，放在 <pre> 中。
+func (rep *Report) WriteHTML(w io.Writer) error {
+	type chart struct {
+		Name string
+		Text string
+	}
+	data := struct {
+		*Report
+		Charts []chart
+	}{Report: rep}
+	for _, m := range Metrics {
+		data.Charts = append(data.Charts, chart{m.Name, rep.chart(m)})
+	}
+	return htmlTmpl.Execute(w, data)
+}
+
+var htmlTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"status":    Verdict.status,
+	"allocNote": func() string { return allocNote },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Problem.ID}}. {{.Problem.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+.PASS { color: green; } .FAIL { color: red; }
+</style>
+</head>
+<body>
+<h1>{{.Problem.ID}}. {{.Problem.Title}}</h1>
+<p><a href="{{.Problem.URL}}">{{.Problem.URL}}</a></p>
+
+<h2>对拍</h2>
+<p>共 {{.Cases}} 个用例。</p>
+<table>
+<tr><th>approach</th><th>result</th><th>passed</th><th>skipped</th></tr>
+{{- range .Verdicts}}
+<tr><td>{{.Approach}}</td><td class="{{if .OK}}PASS{{else}}FAIL{{end}}">{{status .}}</td><td>{{.Passed}}</td><td>{{.Skipped}}</td></tr>
+{{- end}}
+</table>
+{{- range $v := .Verdicts}}{{range .Failures}}
+<p><code>{{$v.Approach}}</code> {{.Case}}: args <code>{{.Args}}</code> got <code>{{.Got}}</code> want <code>{{.Want}}</code></p>
+{{- end}}{{end}}
+
+<h2>性能</h2>
+{{- if .Rows}}
+<table>
+<tr><th>approach</th><th>n</th><th>ns/op</th><th>allocs/op</th><th>B/op</th></tr>
+{{- range .Rows}}
+<tr><td>{{.Approach}}</td><td>{{.N}}</td><td>{{.NsPerOp}}</td><td>{{.AllocsPerOp}}</td><td>{{.BytesPerOp}}</td></tr>
+{{- end}}
+</table>
+<p>{{allocNote}}</p>
+{{- range .Charts}}
+<h3>{{.Name}}</h3>
+<pre>{{.Text}}</pre>
+{{- end}}
+{{- else}}
+<p>这道题没有随机输入生成器，跳过。</p>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,161 @@
+/**
+ * 贪心、分治和动态规划的小框架，对应 面试准备/算法.md 中的 爬楼梯、完全背包问题 等。
+ * Memo 给递归写法加记忆化，Table 自底向上填表（支持滚动数组），Path 记录选择以便还原最优方案。
+ */
+package dp
+
+// Memo 给递归函数加上记忆化。f 中的递归调用改为调用 rec 即可，其余写法不变：
+//
+//	climb := dp.Memo(func(rec func(int) int, n int) int {
+//		if n < 2 {
+//			return 1
+//		}
+//		return rec(n-1) + rec(n-2)
+//	})
+//
+// 返回的函数持有一个只增不减的缓存，也不能被多个 goroutine 同时调用。
+// 通常每次求解时新建一个，求解结束后缓存随之释放，而不是保存在包级变量里。
+func Memo[K comparable, V any](f func(rec func(K) V, k K) V) func(K) V {
+	cache := map[K]V{}
+	var rec func(K) V
+	rec = func(k K) V {
+		if v, ok := cache[k]; ok {
+			return v
+		}
+		v := f(rec, k)
+		cache[k] = v
+		return v
+	}
+	return rec
+}
+
+// Table 多维 DP 表，按行优先存放在一个切片中。
+// 滚动表只保存第一维最近的 keep 层，下标对 keep 取模，适合 dp[i] 只依赖 dp[i-1..i-keep+1] 的转移。
+type Table[V any] struct {
+	dims    []int
+	strides []int
+	keep    int
+	data    []V
+}
+
+// NewTable 创建维度为 dims 的表。
+func NewTable[V any](dims ...int) *Table[V] {
+	return NewRollingTable[V](0, dims...)
+}
+
+// NewRollingTable 创建第一维只保留 keep 层的滚动表，keep <= 0 或不小于 dims[0] 时等同于 NewTable。
+func NewRollingTable[V any](keep int, dims ...int) *Table[V] {
+	if len(dims) == 0 {
+		panic("dp: table needs at least one dimension")
+	}
+	t := &Table[V]{dims: dims, strides: make([]int, len(dims))}
+	if keep <= 0 || keep > dims[0] {
+		keep = dims[0]
+	}
+	t.keep = keep
+
+	size := 1
+	for i := len(dims) - 1; i >= 0; i-- {
+		t.strides[i] = size
+		if i == 0 {
+			size *= keep
+		} else {
+			size *= dims[i]
+		}
+	}
+	t.data = make([]V, size)
+	return t
+}
+
+func (t *Table[V]) Dims() []int {
+	return t.dims
+}
+
+func (t *Table[V]) offset(idx []int) int {
+	if len(idx) != len(t.dims) {
+		panic("dp: wrong number of indices")
+	}
+	off := 0
+	for i, x := range idx {
+		if x < 0 || x >= t.dims[i] {
+			panic("dp: index out of range")
+		}
+		if i == 0 {
+			x %= t.keep
+		}
+		off += x * t.strides[i]
+	}
+	return off
+}
+
+func (t *Table[V]) At(idx ...int) V {
+	return t.data[t.offset(idx)]
+}
+
+func (t *Table[V]) Set(v V, idx ...int) {
+	t.data[t.offset(idx)] = v
+}
+
+// Fill 按行优先顺序遍历所有下标，用 f 的返回值填表。
+// f 中可以通过 At 读取已经填好的格子；idx 在回调之间复用，不要保存它。
+func (t *Table[V]) Fill(f func(idx []int) V) {
+	idx := make([]int, len(t.dims))
+	for {
+		t.data[t.offset(idx)] = f(idx)
+		i := len(idx) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < t.dims[i] {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// Path 记录每个状态由哪个前驱状态经过什么选择得到，用于还原最优方案。
+type Path[K comparable, C any] struct {
+	from map[K]step[K, C]
+}
+
+type step[K comparable, C any] struct {
+	prev   K
+	choice C
+}
+
+func NewPath[K comparable, C any]() *Path[K, C] {
+	return &Path[K, C]{from: map[K]step[K, C]{}}
+}
+
+// Record 记录 state 由 prev 经过 choice 转移而来，重复记录时覆盖之前的选择。
+func (p *Path[K, C]) Record(state, prev K, choice C) {
+	p.from[state] = step[K, C]{prev, choice}
+}
+
+// Walk 从 end 沿前驱回溯到没有记录的初始状态，按从前往后的顺序返回所有选择。
+func (p *Path[K, C]) Walk(end K) []C {
+	res := []C{}
+	for s, ok := p.from[end]; ok; s, ok = p.from[s.prev] {
+		res = append(res, s.choice)
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// DivideAndConquer 分治模板：规模足够小时直接求解，否则拆分、递归、合并。
+func DivideAndConquer[P, R any](p P, small func(P) bool, solve func(P) R, split func(P) []P, combine func(P, []R) R) R {
+	if small(p) {
+		return solve(p)
+	}
+	parts := split(p)
+	res := make([]R, len(parts))
+	for i, sub := range parts {
+		res[i] = DivideAndConquer(sub, small, solve, split, combine)
+	}
+	return combine(p, res)
+}
@@ -0,0 +1,286 @@
+package dp
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func randInts(r *rand.Rand, n, lo, hi int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = lo + r.Intn(hi-lo+1)
+	}
+	return nums
+}
+
+func TestClimbStairs(t *testing.T) {
+	a, b := 1, 1 // f(0), f(1)
+	for n := 0; n <= 45; n++ {
+		if got := ClimbStairs(n); got != a {
+			t.Fatalf("ClimbStairs(%d) = %d, want %d", n, got, a)
+		}
+		if got := ClimbStairsMemo(n); got != a {
+			t.Fatalf("ClimbStairsMemo(%d) = %d, want %d", n, got, a)
+		}
+		a, b = b, a+b
+	}
+}
+
+// TestMemoConcurrent 每次调用新建缓存，可以被多个 goroutine 同时调用，用 -race 运行。
+func TestMemoConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := ClimbStairsMemo(30 + i); got != ClimbStairs(30+i) {
+				t.Errorf("ClimbStairsMemo(%d) = %d", 30+i, got)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTable(t *testing.T) {
+	full := NewTable[int](4, 3)
+	full.Fill(func(idx []int) int { return idx[0]*10 + idx[1] })
+	if full.At(3, 2) != 32 || full.At(0, 1) != 1 || !slices.Equal(full.Dims(), []int{4, 3}) {
+		t.Fatalf("table filled wrong: At(3, 2) = %d", full.At(3, 2))
+	}
+	// 滚动表只保留最近 keep 层，旧的层被覆盖
+	rolling := NewRollingTable[int](2, 5, 2)
+	rolling.Fill(func(idx []int) int { return idx[0]*10 + idx[1] })
+	if rolling.At(4, 1) != 41 || rolling.At(3, 0) != 30 || rolling.At(0, 0) != 40 {
+		t.Fatalf("rolling table: At(4, 1) = %d, At(3, 0) = %d, At(0, 0) = %d", rolling.At(4, 1), rolling.At(3, 0), rolling.At(0, 0))
+	}
+	for _, tc := range []struct {
+		name string
+		f    func()
+	}{
+		{"no dims", func() { NewTable[int]() }},
+		{"out of range", func() { full.At(4, 0) }},
+		{"negative", func() { full.Set(1, 0, -1) }},
+		{"wrong arity", func() { full.At(1) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tc.name)
+				}
+			}()
+			tc.f()
+		}()
+	}
+}
+
+func TestPath(t *testing.T) {
+	p := NewPath[string, int]()
+	p.Record("b", "a", 1)
+	p.Record("c", "b", 2)
+	p.Record("c", "a", 3) // 覆盖
+	p.Record("d", "c", 4)
+	if got := p.Walk("d"); !slices.Equal(got, []int{3, 4}) {
+		t.Fatalf("Walk(d) = %v, want [3 4]", got)
+	}
+	if got := p.Walk("a"); len(got) != 0 {
+		t.Fatalf("Walk(a) = %v, want []", got)
+	}
+}
+
+func TestKnapsack(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 500 {
+		n := r.Intn(8)
+		weights, values := randInts(r, n, 0, 6), randInts(r, n, 0, 9)
+		capacity := r.Intn(15)
+		// 穷举所有子集
+		want := 0
+		for mask := 0; mask < 1<<n; mask++ {
+			w, v := 0, 0
+			for i := range n {
+				if mask>>i&1 == 1 {
+					w, v = w+weights[i], v+values[i]
+				}
+			}
+			if w <= capacity {
+				want = max(want, v)
+			}
+		}
+		got, items := Knapsack(weights, values, capacity)
+		if got != want {
+			t.Fatalf("Knapsack(%v, %v, %d) = %d, want %d", weights, values, capacity, got, want)
+		}
+		w, v := 0, 0
+		for _, i := range items {
+			w, v = w+weights[i], v+values[i]
+		}
+		if w > capacity || v != got || !slices.IsSorted(items) || len(slices.Compact(slices.Clone(items))) != len(items) {
+			t.Fatalf("Knapsack(%v, %v, %d) items %v weigh %d and are worth %d", weights, values, capacity, items, w, v)
+		}
+	}
+}
+
+func TestUnboundedKnapsack(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 500 {
+		n := r.Intn(5)
+		weights, values := randInts(r, n, 0, 6), randInts(r, n, 0, 9)
+		capacity := r.Intn(20)
+		// 按剩余容量递归穷举
+		var best func(c int) int
+		best = func(c int) int {
+			res := 0
+			for i, wi := range weights {
+				if wi > 0 && wi <= c {
+					res = max(res, best(c-wi)+values[i])
+				}
+			}
+			return res
+		}
+		want := best(capacity)
+		got, items := UnboundedKnapsack(weights, values, capacity)
+		w, v := 0, 0
+		for _, i := range items {
+			w, v = w+weights[i], v+values[i]
+		}
+		if got != want || w > capacity || v != got {
+			t.Fatalf("UnboundedKnapsack(%v, %v, %d) = %d, %v, want %d", weights, values, capacity, got, items, want)
+		}
+	}
+}
+
+func TestLengthOfLIS(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 500 {
+		nums := randInts(r, r.Intn(10), 0, 6)
+		want := 0
+		for mask := 0; mask < 1<<len(nums); mask++ {
+			var sub []int
+			for i, x := range nums {
+				if mask>>i&1 == 1 {
+					sub = append(sub, x)
+				}
+			}
+			if strictlyIncreasing(sub) {
+				want = max(want, len(sub))
+			}
+		}
+		got, seq := LengthOfLIS(nums)
+		if got != want || len(seq) != got || !strictlyIncreasing(seq) || !isSubsequence(seq, nums) {
+			t.Fatalf("LengthOfLIS(%v) = %d, %v, want length %d", nums, got, seq, want)
+		}
+	}
+}
+
+func strictlyIncreasing(s []int) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i-1] >= s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isSubsequence(sub, s []int) bool {
+	i := 0
+	for _, x := range s {
+		if i < len(sub) && sub[i] == x {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+func TestMinDistance(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	word := func() string {
+		b := make([]byte, r.Intn(7))
+		for i := range b {
+			b[i] = "abc"[r.Intn(3)]
+		}
+		return string(b)
+	}
+	for range 500 {
+		a, b := word(), word()
+		// 经典的二维表写法
+		d := make([][]int, len(a)+1)
+		for i := range d {
+			d[i] = make([]int, len(b)+1)
+			for j := range d[i] {
+				switch {
+				case i == 0:
+					d[i][j] = j
+				case j == 0:
+					d[i][j] = i
+				case a[i-1] == b[j-1]:
+					d[i][j] = d[i-1][j-1]
+				default:
+					d[i][j] = 1 + min(d[i-1][j-1], d[i-1][j], d[i][j-1])
+				}
+			}
+		}
+		got, ops := MinDistance(a, b)
+		if want := d[len(a)][len(b)]; got != want || len(ops) != got {
+			t.Fatalf("MinDistance(%q, %q) = %d, %q, want %d", a, b, got, ops, want)
+		}
+	}
+	if _, ops := MinDistance("horse", "ros"); !slices.Equal(ops, []string{"replace h with r", "delete r", "delete e"}) {
+		t.Fatalf("MinDistance(horse, ros) ops = %q", ops)
+	}
+}
+
+func TestJump(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for range 500 {
+		nums := randInts(r, r.Intn(10)+1, 0, 3)
+		// BFS 求最少步数
+		dist := make([]int, len(nums))
+		for i := range dist {
+			dist[i] = -1
+		}
+		dist[0] = 0
+		for i := range nums {
+			for j := i + 1; dist[i] >= 0 && j <= min(i+nums[i], len(nums)-1); j++ {
+				if dist[j] < 0 {
+					dist[j] = dist[i] + 1
+				}
+			}
+		}
+		want := dist[len(nums)-1]
+		steps, path := Jump(nums)
+		if steps != want || CanJump(nums) != (want >= 0) {
+			t.Fatalf("Jump(%v) = %d, CanJump = %v, want %d", nums, steps, CanJump(nums), want)
+		}
+		if want < 0 {
+			continue
+		}
+		if len(path) != steps+1 || path[0] != 0 || path[steps] != len(nums)-1 {
+			t.Fatalf("Jump(%v) path %v", nums, path)
+		}
+		for k := 1; k < len(path); k++ {
+			if path[k] <= path[k-1] || path[k]-path[k-1] > nums[path[k-1]] {
+				t.Fatalf("Jump(%v) path %v makes an impossible jump", nums, path)
+			}
+		}
+	}
+}
+
+func TestMaxSubArray(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for range 500 {
+		nums := randInts(r, r.Intn(12)+1, -9, 9)
+		want := nums[0]
+		for i := range nums {
+			sum := 0
+			for j := i; j < len(nums); j++ {
+				sum += nums[j]
+				want = max(want, sum)
+			}
+		}
+		if got := MaxSubArray(nums); got != want {
+			t.Fatalf("MaxSubArray(%v) = %d, want %d", nums, got, want)
+		}
+	}
+}
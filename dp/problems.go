@@ -0,0 +1,243 @@
+package dp
+
+import "fmt"
+
+/**
+ * 70. 爬楼梯
+ * https://leetcode.cn/problems/climbing-stairs/
+ * 与 climbing_stairs.go 中的递归写法相同，只是递归调用换成了 rec。
+ */
+func ClimbStairsMemo(n int) int {
+	climb := Memo(func(rec func(int) int, n int) int {
+		if n < 2 {
+			return 1
+		}
+		if n == 2 {
+			return 2
+		}
+		return rec(n-1) + rec(n-2)
+	})
+	return climb(n)
+}
+
+// ClimbStairs 自底向上填表，dp[i] 只依赖前两项，用 3 层的滚动表。
+func ClimbStairs(n int) int {
+	t := NewRollingTable[int](3, n+1)
+	t.Fill(func(idx []int) int {
+		i := idx[0]
+		if i < 2 {
+			return 1
+		}
+		return t.At(i-1) + t.At(i-2)
+	})
+	return t.At(n)
+}
+
+/**
+ * 55. 跳跃游戏
+ * https://leetcode.cn/problems/jump-game/
+ * 贪心：维护能到达的最远位置，与 jump_game.go 相同。
+ */
+func CanJump(nums []int) bool {
+	mx := 0
+	for i, jump := range nums {
+		if i > mx {
+			return false
+		}
+		mx = max(mx, i+jump)
+	}
+	return true
+}
+
+/**
+ * 45. 跳跃游戏 II
+ * https://leetcode.cn/problems/jump-game-ii/
+ * 按下标顺序扩展能到达的范围，每个位置记录第一次到达它的起跳点，
+ * 第一次到达时用的步数一定最少。返回最少步数和依次经过的下标，到不了终点时返回 -1。
+ */
+func Jump(nums []int) (int, []int) {
+	n := len(nums)
+	path := NewPath[int, int]()
+	reach := 0
+	for i := 0; i < n && i <= reach; i++ {
+		for j := reach + 1; j <= min(i+nums[i], n-1); j++ {
+			path.Record(j, i, i)
+		}
+		reach = max(reach, i+nums[i])
+	}
+	if n == 0 || reach < n-1 {
+		return -1, nil
+	}
+	res := append(path.Walk(n-1), n-1)
+	return len(res) - 1, res
+}
+
+/**
+ * 0-1 背包：每件物品最多选一次，返回最大价值和选中的物品下标。
+ * dp[i][w] 为前 i 件物品、容量 w 时的最大价值。
+ */
+func Knapsack(weights, values []int, capacity int) (int, []int) {
+	n := len(weights)
+	t := NewTable[int](n+1, capacity+1)
+	path := NewPath[[2]int, int]()
+	t.Fill(func(idx []int) int {
+		i, w := idx[0], idx[1]
+		if i == 0 {
+			return 0
+		}
+		best := t.At(i-1, w)
+		path.Record([2]int{i, w}, [2]int{i - 1, w}, -1)
+		if wi := weights[i-1]; wi <= w && t.At(i-1, w-wi)+values[i-1] > best {
+			best = t.At(i-1, w-wi) + values[i-1]
+			path.Record([2]int{i, w}, [2]int{i - 1, w - wi}, i-1)
+		}
+		return best
+	})
+
+	items := []int{}
+	for _, c := range path.Walk([2]int{n, capacity}) {
+		if c >= 0 {
+			items = append(items, c)
+		}
+	}
+	return t.At(n, capacity), items
+}
+
+/**
+ * 完全背包：每件物品可以选任意次，返回最大价值和选中的物品下标（可重复）。
+ * 只需要一维表，dp[w] 为容量 w 时的最大价值。
+ */
+func UnboundedKnapsack(weights, values []int, capacity int) (int, []int) {
+	t := NewTable[int](capacity + 1)
+	path := NewPath[int, int]()
+	t.Fill(func(idx []int) int {
+		w := idx[0]
+		best := 0
+		for i, wi := range weights {
+			if wi > 0 && wi <= w && t.At(w-wi)+values[i] > best {
+				best = t.At(w-wi) + values[i]
+				path.Record(w, w-wi, i)
+			}
+		}
+		return best
+	})
+	return t.At(capacity), path.Walk(capacity)
+}
+
+/**
+ * 300. 最长递增子序列
+ * https://leetcode.cn/problems/longest-increasing-subsequence/
+ * dp[i] 为以 nums[i] 结尾的最长递增子序列长度，返回长度和其中一个最长子序列。
+ */
+func LengthOfLIS(nums []int) (int, []int) {
+	n := len(nums)
+	if n == 0 {
+		return 0, nil
+	}
+	t := NewTable[int](n)
+	path := NewPath[int, int]()
+	end := 0
+	t.Fill(func(idx []int) int {
+		i := idx[0]
+		best := 1
+		for j := 0; j < i; j++ {
+			if nums[j] < nums[i] && t.At(j)+1 > best {
+				best = t.At(j) + 1
+				path.Record(i, j, j)
+			}
+		}
+		if best > t.At(end) {
+			end = i
+		}
+		return best
+	})
+
+	seq := []int{}
+	for _, j := range append(path.Walk(end), end) {
+		seq = append(seq, nums[j])
+	}
+	return t.At(end), seq
+}
+
+/**
+ * 72. 编辑距离
+ * https://leetcode.cn/problems/edit-distance/
+ * 返回最少操作数和一组把 word1 变成 word2 的操作。
+ */
+func MinDistance(word1, word2 string) (int, []string) {
+	m, n := len(word1), len(word2)
+	t := NewTable[int](m+1, n+1)
+	path := NewPath[[2]int, string]()
+	t.Fill(func(idx []int) int {
+		i, j := idx[0], idx[1]
+		switch {
+		case i == 0 && j == 0:
+			return 0
+		case i == 0:
+			path.Record([2]int{i, j}, [2]int{i, j - 1}, fmt.Sprintf("insert %c", word2[j-1]))
+			return j
+		case j == 0:
+			path.Record([2]int{i, j}, [2]int{i - 1, j}, fmt.Sprintf("delete %c", word1[i-1]))
+			return i
+		}
+
+		if word1[i-1] == word2[j-1] {
+			path.Record([2]int{i, j}, [2]int{i - 1, j - 1}, fmt.Sprintf("keep %c", word1[i-1]))
+			return t.At(i-1, j-1)
+		}
+		best := t.At(i-1, j-1) + 1
+		path.Record([2]int{i, j}, [2]int{i - 1, j - 1}, fmt.Sprintf("replace %c with %c", word1[i-1], word2[j-1]))
+		if v := t.At(i-1, j) + 1; v < best {
+			best = v
+			path.Record([2]int{i, j}, [2]int{i - 1, j}, fmt.Sprintf("delete %c", word1[i-1]))
+		}
+		if v := t.At(i, j-1) + 1; v < best {
+			best = v
+			path.Record([2]int{i, j}, [2]int{i, j - 1}, fmt.Sprintf("insert %c", word2[j-1]))
+		}
+		return best
+	})
+
+	ops := []string{}
+	for _, op := range path.Walk([2]int{m, n}) {
+		if op[:4] != "keep" {
+			ops = append(ops, op)
+		}
+	}
+	return t.At(m, n), ops
+}
+
+/**
+ * 53. 最大子数组和
+ * https://leetcode.cn/problems/maximum-subarray/
+ * 分治：区间的答案来自左半、右半或跨过中点的子数组。
+ */
+func MaxSubArray(nums []int) int {
+	type part struct{ lo, hi int }
+	type result struct{ sum, pre, suf, best int }
+	if len(nums) == 0 {
+		return 0
+	}
+
+	res := DivideAndConquer(part{0, len(nums)},
+		func(p part) bool { return p.hi-p.lo == 1 },
+		func(p part) result {
+			v := nums[p.lo]
+			return result{v, v, v, v}
+		},
+		func(p part) []part {
+			mid := p.lo + (p.hi-p.lo)/2
+			return []part{{p.lo, mid}, {mid, p.hi}}
+		},
+		func(_ part, rs []result) result {
+			l, r := rs[0], rs[1]
+			return result{
+				sum:  l.sum + r.sum,
+				pre:  max(l.pre, l.sum+r.pre),
+				suf:  max(r.suf, r.sum+l.suf),
+				best: max(l.best, r.best, l.suf+r.pre),
+			}
+		},
+	)
+	return res.best
+}
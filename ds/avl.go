@@ -0,0 +1,212 @@
+package ds
+
+import "iter"
+
+// AVL 平衡二叉搜索树，任意结点左右子树高度差不超过 1，查找、插入、删除均为 O(log n)。
+type AVL[K, V any] struct {
+	root *avlNode[K, V]
+	cmp  func(a, b K) int
+	size int
+}
+
+type avlNode[K, V any] struct {
+	key         K
+	val         V
+	height      int
+	left, right *avlNode[K, V]
+}
+
+func NewAVL[K, V any](cmp func(a, b K) int) *AVL[K, V] {
+	return &AVL[K, V]{cmp: cmp}
+}
+
+func (t *AVL[K, V]) Len() int {
+	return t.size
+}
+
+func (t *AVL[K, V]) Get(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		c := t.cmp(key, n.key)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Put 插入或更新键值对，新插入时返回 true。
+func (t *AVL[K, V]) Put(key K, val V) bool {
+	var inserted bool
+	t.root = t.put(t.root, key, val, &inserted)
+	if inserted {
+		t.size++
+	}
+	return inserted
+}
+
+// Delete 删除键，键不存在时返回 false。
+func (t *AVL[K, V]) Delete(key K) bool {
+	var deleted bool
+	t.root = t.delete(t.root, key, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// Min 返回最小的键。
+func (t *AVL[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.val, true
+}
+
+// Max 返回最大的键。
+func (t *AVL[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		var k K
+		var v V
+		return k, v, false
+	}
+	n := t.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.val, true
+}
+
+// Height 返回树高，空树为 0。
+func (t *AVL[K, V]) Height() int {
+	return height(t.root)
+}
+
+// All 按键升序遍历。
+func (t *AVL[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		stack := []*avlNode[K, V]{}
+		for n := t.root; n != nil || len(stack) > 0; {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.left
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key, n.val) {
+				return
+			}
+			n = n.right
+		}
+	}
+}
+
+func height[K, V any](n *avlNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *avlNode[K, V]) update() {
+	n.height = max(height(n.left), height(n.right)) + 1
+}
+
+func (n *avlNode[K, V]) balance() int {
+	return height(n.left) - height(n.right)
+}
+
+func rotateRight[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+func rotateLeft[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+// rebalance 更新高度并通过旋转恢复平衡，返回子树新的根。
+func rebalance[K, V any](n *avlNode[K, V]) *avlNode[K, V] {
+	n.update()
+	switch b := n.balance(); {
+	case b > 1:
+		if n.left.balance() < 0 {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case b < -1:
+		if n.right.balance() > 0 {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+func (t *AVL[K, V]) put(n *avlNode[K, V], key K, val V, inserted *bool) *avlNode[K, V] {
+	if n == nil {
+		*inserted = true
+		return &avlNode[K, V]{key: key, val: val, height: 1}
+	}
+	c := t.cmp(key, n.key)
+	switch {
+	case c < 0:
+		n.left = t.put(n.left, key, val, inserted)
+	case c > 0:
+		n.right = t.put(n.right, key, val, inserted)
+	default:
+		n.val = val
+		return n
+	}
+	return rebalance(n)
+}
+
+func (t *AVL[K, V]) delete(n *avlNode[K, V], key K, deleted *bool) *avlNode[K, V] {
+	if n == nil {
+		return nil
+	}
+	c := t.cmp(key, n.key)
+	switch {
+	case c < 0:
+		n.left = t.delete(n.left, key, deleted)
+	case c > 0:
+		n.right = t.delete(n.right, key, deleted)
+	default:
+		*deleted = true
+		if n.left == nil {
+			return n.right
+		}
+		if n.right == nil {
+			return n.left
+		}
+		// 用右子树的最小结点替换当前结点
+		succ := n.right
+		for succ.left != nil {
+			succ = succ.left
+		}
+		n.key, n.val = succ.key, succ.val
+		var ignored bool
+		n.right = t.delete(n.right, succ.key, &ignored)
+	}
+	return rebalance(n)
+}
@@ -0,0 +1,344 @@
+package ds
+
+import (
+	"cmp"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSList(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var l SList[int]
+	var want []int
+	for range 2000 {
+		switch v := r.Intn(100); r.Intn(4) {
+		case 0:
+			l.PushFront(v)
+			want = slices.Insert(want, 0, v)
+		case 1:
+			l.PushBack(v)
+			want = append(want, v)
+		case 2:
+			got, ok := l.PopFront()
+			if ok != (len(want) > 0) || ok && got != want[0] {
+				t.Fatalf("PopFront() = %d, %v, want %v", got, ok, want)
+			}
+			if ok {
+				want = want[1:]
+			}
+		case 3:
+			l.Reverse()
+			slices.Reverse(want)
+		}
+		if got := slices.Collect(l.All()); !slices.Equal(got, want) || l.Len() != len(want) {
+			t.Fatalf("list = %v (Len %d), want %v", got, l.Len(), want)
+		}
+	}
+	// 反转后尾指针要跟着更新，PushBack 才能接在正确的位置
+	var m SList[int]
+	m.PushBack(1)
+	m.PushBack(2)
+	m.Reverse()
+	m.PushBack(3)
+	if got := slices.Collect(m.All()); !slices.Equal(got, []int{2, 1, 3}) {
+		t.Fatalf("PushBack after Reverse = %v", got)
+	}
+}
+
+func TestDList(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	l := NewDList[int]()
+	var elems []*Element[int]
+	var want []int
+	for range 2000 {
+		switch v := r.Intn(100); r.Intn(4) {
+		case 0:
+			elems = slices.Insert(elems, 0, l.PushFront(v))
+			want = slices.Insert(want, 0, v)
+		case 1:
+			elems = append(elems, l.PushBack(v))
+			want = append(want, v)
+		case 2:
+			if len(elems) == 0 {
+				continue
+			}
+			i := r.Intn(len(elems))
+			if got := l.Remove(elems[i]); got != want[i] {
+				t.Fatalf("Remove = %d, want %d", got, want[i])
+			}
+			// 再次删除同一个结点不做任何事
+			l.Remove(elems[i])
+			elems, want = slices.Delete(elems, i, i+1), slices.Delete(want, i, i+1)
+		case 3:
+			if len(elems) == 0 {
+				continue
+			}
+			i := r.Intn(len(elems))
+			e, v := elems[i], want[i]
+			l.MoveToFront(e)
+			elems, want = slices.Insert(slices.Delete(elems, i, i+1), 0, e), slices.Insert(slices.Delete(want, i, i+1), 0, v)
+		}
+		if got := slices.Collect(l.All()); !slices.Equal(got, want) || l.Len() != len(want) {
+			t.Fatalf("list = %v (Len %d), want %v", got, l.Len(), want)
+		}
+		back := slices.Collect(l.Backward())
+		slices.Reverse(back)
+		if !slices.Equal(back, want) {
+			t.Fatalf("Backward = %v, want the reverse of %v", back, want)
+		}
+		if len(want) > 0 && (l.Front().Value != want[0] || l.Back().Value != want[len(want)-1]) {
+			t.Fatalf("Front = %d, Back = %d, list %v", l.Front().Value, l.Back().Value, want)
+		}
+	}
+	other := NewDList[int]()
+	e := other.PushBack(1)
+	l.Remove(e)
+	l.MoveToFront(e)
+	if other.Len() != 1 || e.Value != 1 {
+		t.Fatal("operations on an element of another list changed it")
+	}
+}
+
+func TestHeap(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 200 {
+		items := make([]int, r.Intn(30))
+		for i := range items {
+			items[i] = r.Intn(20)
+		}
+		h := NewHeap(func(a, b int) bool { return a > b }, items...)
+		want := slices.Clone(items)
+		for range 20 {
+			v := r.Intn(20)
+			h.Push(v)
+			want = append(want, v)
+		}
+		slices.SortFunc(want, func(a, b int) int { return b - a })
+		if top, ok := h.Peek(); !ok || top != want[0] {
+			t.Fatalf("Peek() = %d, %v, want %d", top, ok, want[0])
+		}
+		if got := slices.Collect(h.Drain()); !slices.Equal(got, want) {
+			t.Fatalf("Drain() = %v, want %v", got, want)
+		}
+		if _, ok := h.Pop(); ok {
+			t.Fatal("Pop on an empty heap succeeded")
+		}
+	}
+}
+
+func TestIndexedPQ(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	pq := NewIndexedPQ[int](func(a, b int) bool { return a < b })
+	want := map[int]int{}
+	for range 5000 {
+		k := r.Intn(30)
+		switch r.Intn(3) {
+		case 0:
+			p := r.Intn(100)
+			pq.Set(k, p)
+			want[k] = p
+		case 1:
+			_, had := want[k]
+			if pq.Remove(k) != had {
+				t.Fatalf("Remove(%d) = %v", k, !had)
+			}
+			delete(want, k)
+		case 2:
+			k, p, ok := pq.Pop()
+			if ok != (len(want) > 0) {
+				t.Fatalf("Pop() ok = %v with %d keys", ok, len(want))
+			}
+			if !ok {
+				continue
+			}
+			if want[k] != p || p != slices.Min(slices.Collect(maps.Values(want))) {
+				t.Fatalf("Pop() = %d, %d, not the minimum of %v", k, p, want)
+			}
+			delete(want, k)
+		}
+		if pq.Len() != len(want) || pq.Contains(k) != has(want, k) {
+			t.Fatalf("Len = %d, Contains(%d) = %v, want %v", pq.Len(), k, pq.Contains(k), want)
+		}
+		if p, ok := pq.Priority(k); ok != has(want, k) || p != want[k] {
+			t.Fatalf("Priority(%d) = %d, %v, want %d", k, p, ok, want[k])
+		}
+	}
+	prev := -1
+	for _, p := range pq.Drain() {
+		if p < prev {
+			t.Fatalf("Drain out of order: %d after %d", p, prev)
+		}
+		prev = p
+	}
+}
+
+func has(m map[int]int, k int) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// checkAVL 检查有序性、记录的高度和平衡因子，返回子树高度。
+func checkAVL(t *testing.T, n *avlNode[int, int], lo, hi int) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	if n.key <= lo || n.key >= hi {
+		t.Fatalf("key %d outside (%d, %d)", n.key, lo, hi)
+	}
+	l, r := checkAVL(t, n.left, lo, n.key), checkAVL(t, n.right, n.key, hi)
+	if l-r > 1 || r-l > 1 {
+		t.Fatalf("node %d unbalanced: heights %d and %d", n.key, l, r)
+	}
+	if h := max(l, r) + 1; n.height != h {
+		t.Fatalf("node %d records height %d, want %d", n.key, n.height, h)
+	}
+	return n.height
+}
+
+func TestAVL(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	tree := NewAVL[int, int](cmp.Compare[int])
+	want := map[int]int{}
+	for i := range 5000 {
+		k := r.Intn(500)
+		if r.Intn(3) == 0 {
+			if tree.Delete(k) != has(want, k) {
+				t.Fatalf("Delete(%d) = %v", k, !has(want, k))
+			}
+			delete(want, k)
+		} else {
+			if tree.Put(k, i) == has(want, k) {
+				t.Fatalf("Put(%d) reported the wrong insertion", k)
+			}
+			want[k] = i
+		}
+		if v, ok := tree.Get(k); ok != has(want, k) || v != want[k] {
+			t.Fatalf("Get(%d) = %d, %v, want %d", k, v, ok, want[k])
+		}
+		if tree.Len() != len(want) {
+			t.Fatalf("Len = %d, want %d", tree.Len(), len(want))
+		}
+		if i%100 == 0 {
+			checkAVL(t, tree.root, -1, 500)
+		}
+	}
+	checkAVL(t, tree.root, -1, 500)
+	if got := maps.Collect(tree.All()); !maps.Equal(got, want) {
+		t.Fatal("All() differs from the reference map")
+	}
+	keys := slices.Sorted(maps.Keys(want))
+	if k, _, _ := tree.Min(); k != keys[0] {
+		t.Fatalf("Min = %d, want %d", k, keys[0])
+	}
+	if k, _, _ := tree.Max(); k != keys[len(keys)-1] {
+		t.Fatalf("Max = %d, want %d", k, keys[len(keys)-1])
+	}
+
+	// 顺序插入是普通二叉搜索树的最坏情况，AVL 的高度不超过 1.44 log2(n)
+	seq := NewAVL[int, struct{}](cmp.Compare[int])
+	for i := range 1 << 12 {
+		seq.Put(i, struct{}{})
+	}
+	if h := seq.Height(); h > 17 {
+		t.Fatalf("height %d after 4096 sequential inserts", h)
+	}
+}
+
+func TestUnionFind(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	const n = 40
+	uf := NewUnionFind(n)
+	// 参照实现：每个元素记录所在集合的编号，合并时整体改号
+	group := make([]int, n)
+	for i := range group {
+		group[i] = i
+	}
+	count := n
+	for range 200 {
+		x, y := r.Intn(n), r.Intn(n)
+		merged := group[x] != group[y]
+		if uf.Union(x, y) != merged {
+			t.Fatalf("Union(%d, %d) = %v", x, y, !merged)
+		}
+		if merged {
+			old := group[y]
+			for i := range group {
+				if group[i] == old {
+					group[i] = group[x]
+				}
+			}
+			count--
+		}
+		a, b := r.Intn(n), r.Intn(n)
+		if uf.Connected(a, b) != (group[a] == group[b]) || uf.Count() != count {
+			t.Fatalf("Connected(%d, %d) = %v, Count = %d", a, b, uf.Connected(a, b), uf.Count())
+		}
+		size := 0
+		for _, g := range group {
+			if g == group[a] {
+				size++
+			}
+		}
+		if uf.Size(a) != size {
+			t.Fatalf("Size(%d) = %d, want %d", a, uf.Size(a), size)
+		}
+	}
+	total := 0
+	for root, members := range uf.Groups() {
+		for _, m := range members {
+			if uf.Find(m) != root {
+				t.Fatalf("member %d of group %d has root %d", m, root, uf.Find(m))
+			}
+		}
+		total += len(members)
+	}
+	if total != n || len(uf.Groups()) != count {
+		t.Fatalf("Groups() covers %d elements in %d groups", total, len(uf.Groups()))
+	}
+}
+
+func TestLRU(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	if k, ok := c.Put("c", 3); !ok || k != "b" {
+		t.Fatalf("Put(c) evicted %q, %v, want b", k, ok)
+	}
+	if _, ok := c.Put("a", 10); ok {
+		t.Fatal("updating an existing key evicted something")
+	}
+	var order []string
+	for k := range c.All() {
+		order = append(order, k)
+	}
+	if !slices.Equal(order, []string{"a", "c"}) {
+		t.Fatalf("All() order = %v, want [a c]", order)
+	}
+	if !c.Remove("a") || c.Remove("a") || c.Len() != 1 {
+		t.Fatal("Remove is wrong")
+	}
+
+	// 146. LRU 缓存的示例
+	lc := Constructor(2)
+	lc.Put(1, 1)
+	lc.Put(2, 2)
+	got := []int{lc.Get(1)}
+	lc.Put(3, 3)
+	got = append(got, lc.Get(2))
+	lc.Put(4, 4)
+	got = append(got, lc.Get(1), lc.Get(3), lc.Get(4))
+	if !slices.Equal(got, []int{1, -1, -1, 3, 4}) {
+		t.Fatalf("LRUCache example = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NewLRU(0) did not panic")
+		}
+	}()
+	NewLRU[int, int](0)
+}
@@ -0,0 +1,218 @@
+package ds
+
+import "iter"
+
+// Heap 二叉堆，less(a, b) 为 true 时 a 更靠近堆顶，即 less 为小于时是小顶堆。
+type Heap[T any] struct {
+	data []T
+	less func(a, b T) bool
+}
+
+// NewHeap 用 items 的副本 O(n) 建堆。
+func NewHeap[T any](less func(a, b T) bool, items ...T) *Heap[T] {
+	h := &Heap[T]{data: append([]T(nil), items...), less: less}
+	for i := len(h.data)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+func (h *Heap[T]) Len() int {
+	return len(h.data)
+}
+
+func (h *Heap[T]) Push(v T) {
+	h.data = append(h.data, v)
+	h.up(len(h.data) - 1)
+}
+
+// Peek 返回堆顶元素，堆为空时返回 false。
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.data[0], true
+}
+
+// Pop 删除并返回堆顶元素，堆为空时返回 false。
+func (h *Heap[T]) Pop() (T, bool) {
+	n := len(h.data)
+	if n == 0 {
+		var zero T
+		return zero, false
+	}
+	top := h.data[0]
+	h.data[0] = h.data[n-1]
+	var zero T
+	h.data[n-1] = zero
+	h.data = h.data[:n-1]
+	if n > 1 {
+		h.down(0)
+	}
+	return top, true
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !h.less(h.data[i], h.data[p]) {
+			return
+		}
+		h.data[i], h.data[p] = h.data[p], h.data[i]
+		i = p
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.data)
+	for {
+		c := 2*i + 1
+		if c >= n {
+			return
+		}
+		if c+1 < n && h.less(h.data[c+1], h.data[c]) {
+			c++
+		}
+		if !h.less(h.data[c], h.data[i]) {
+			return
+		}
+		h.data[i], h.data[c] = h.data[c], h.data[i]
+		i = c
+	}
+}
+
+// Drain 按出堆顺序依次弹出所有元素，提前结束遍历时剩余元素保留在堆中。
+func (h *Heap[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for h.Len() > 0 {
+			v, _ := h.Pop()
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// IndexedPQ 索引优先队列：每个键最多出现一次，可以按键修改优先级或删除，
+// 适合 Dijkstra 这类需要 decrease-key 的算法。
+type IndexedPQ[K comparable, P any] struct {
+	keys  []K
+	prio  map[K]P
+	index map[K]int // 键在 keys 中的位置
+	less  func(a, b P) bool
+}
+
+func NewIndexedPQ[K comparable, P any](less func(a, b P) bool) *IndexedPQ[K, P] {
+	return &IndexedPQ[K, P]{prio: map[K]P{}, index: map[K]int{}, less: less}
+}
+
+func (pq *IndexedPQ[K, P]) Len() int {
+	return len(pq.keys)
+}
+
+func (pq *IndexedPQ[K, P]) Contains(k K) bool {
+	_, ok := pq.index[k]
+	return ok
+}
+
+// Priority 返回键的优先级。
+func (pq *IndexedPQ[K, P]) Priority(k K) (P, bool) {
+	p, ok := pq.prio[k]
+	return p, ok
+}
+
+// Set 插入键或修改已有键的优先级。
+func (pq *IndexedPQ[K, P]) Set(k K, p P) {
+	pq.prio[k] = p
+	i, ok := pq.index[k]
+	if !ok {
+		pq.keys = append(pq.keys, k)
+		i = len(pq.keys) - 1
+		pq.index[k] = i
+	}
+	pq.up(i)
+	pq.down(pq.index[k])
+}
+
+// Pop 删除并返回优先级最高的键，队列为空时返回 false。
+func (pq *IndexedPQ[K, P]) Pop() (K, P, bool) {
+	if len(pq.keys) == 0 {
+		var k K
+		var p P
+		return k, p, false
+	}
+	k := pq.keys[0]
+	p := pq.prio[k]
+	pq.Remove(k)
+	return k, p, true
+}
+
+// Remove 删除键，键不存在时返回 false。
+func (pq *IndexedPQ[K, P]) Remove(k K) bool {
+	i, ok := pq.index[k]
+	if !ok {
+		return false
+	}
+	last := len(pq.keys) - 1
+	pq.swap(i, last)
+	pq.keys = pq.keys[:last]
+	delete(pq.index, k)
+	delete(pq.prio, k)
+	if i < last {
+		pq.up(i)
+		pq.down(i)
+	}
+	return true
+}
+
+func (pq *IndexedPQ[K, P]) lessAt(i, j int) bool {
+	return pq.less(pq.prio[pq.keys[i]], pq.prio[pq.keys[j]])
+}
+
+func (pq *IndexedPQ[K, P]) swap(i, j int) {
+	pq.keys[i], pq.keys[j] = pq.keys[j], pq.keys[i]
+	pq.index[pq.keys[i]] = i
+	pq.index[pq.keys[j]] = j
+}
+
+func (pq *IndexedPQ[K, P]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !pq.lessAt(i, p) {
+			return
+		}
+		pq.swap(i, p)
+		i = p
+	}
+}
+
+func (pq *IndexedPQ[K, P]) down(i int) {
+	n := len(pq.keys)
+	for {
+		c := 2*i + 1
+		if c >= n {
+			return
+		}
+		if c+1 < n && pq.lessAt(c+1, c) {
+			c++
+		}
+		if !pq.lessAt(c, i) {
+			return
+		}
+		pq.swap(i, c)
+		i = c
+	}
+}
+
+// Drain 按优先级依次弹出所有键和优先级。
+func (pq *IndexedPQ[K, P]) Drain() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		for pq.Len() > 0 {
+			k, p, _ := pq.Pop()
+			if !yield(k, p) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package ds
+
+import (
+	"fmt"
+	"iter"
+	"strconv"
+	"strings"
+)
+
+// ListNode LeetCode 中的单链表结点。
+type ListNode struct {
+	Val  int
+	Next *ListNode
+}
+
+// TreeNode LeetCode 中的二叉树结点。
+type TreeNode struct {
+	Val   int
+	Left  *TreeNode
+	Right *TreeNode
+}
+
+// parseArray 解析 LeetCode 的数组表示，如 "[1,null,2,3]"，null 用 nil 表示。
+func parseArray(s string) ([]*int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("ds: %q is not a LeetCode array", s)
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+	if s == "" {
+		return nil, nil
+	}
+
+	res := []*int{}
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "null" {
+			res = append(res, nil)
+			continue
+		}
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, fmt.Errorf("ds: bad element %q: %w", f, err)
+		}
+		res = append(res, &v)
+	}
+	return res, nil
+}
+
+func formatArray(vals []*int) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for i, v := range vals {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if v == nil {
+			sb.WriteString("null")
+		} else {
+			sb.WriteString(strconv.Itoa(*v))
+		}
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// ParseList 把 "[1,2,3]" 解析为链表，空数组返回 nil。
+func ParseList(s string) (*ListNode, error) {
+	vals, err := parseArray(s)
+	if err != nil {
+		return nil, err
+	}
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range vals {
+		if v == nil {
+			return nil, fmt.Errorf("ds: null in list %q", s)
+		}
+		cur.Next = &ListNode{Val: *v}
+		cur = cur.Next
+	}
+	return dummy.Next, nil
+}
+
+// NewList 用给定的值创建链表。
+func NewList(vals ...int) *ListNode {
+	dummy := &ListNode{}
+	cur := dummy
+	for _, v := range vals {
+		cur.Next = &ListNode{Val: v}
+		cur = cur.Next
+	}
+	return dummy.Next
+}
+
+// All 遍历从 head 开始的每个值。
+func (head *ListNode) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for n := head; n != nil; n = n.Next {
+			if !yield(n.Val) {
+				return
+			}
+		}
+	}
+}
+
+// String 返回 LeetCode 数组表示，nil 链表为 "[]"。
+func (head *ListNode) String() string {
+	vals := []*int{}
+	for n := head; n != nil; n = n.Next {
+		vals = append(vals, &n.Val)
+	}
+	return formatArray(vals)
+}
+
+// ParseTree 把层序表示 "[1,null,2,3]" 解析为二叉树，空数组返回 nil。
+func ParseTree(s string) (*TreeNode, error) {
+	vals, err := parseArray(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 || vals[0] == nil {
+		return nil, nil
+	}
+
+	root := &TreeNode{Val: *vals[0]}
+	queue := []*TreeNode{root}
+	for i := 1; i < len(vals); {
+		if len(queue) == 0 {
+			return nil, fmt.Errorf("ds: extra elements in tree %q", s)
+		}
+		node := queue[0]
+		queue = queue[1:]
+		if v := vals[i]; v != nil {
+			node.Left = &TreeNode{Val: *v}
+			queue = append(queue, node.Left)
+		}
+		i++
+		if i < len(vals) {
+			if v := vals[i]; v != nil {
+				node.Right = &TreeNode{Val: *v}
+				queue = append(queue, node.Right)
+			}
+			i++
+		}
+	}
+	return root, nil
+}
+
+// String 返回层序表示，去掉末尾多余的 null，与 LeetCode 的输出一致。
+func (root *TreeNode) String() string {
+	vals := []*int{}
+	queue := []*TreeNode{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == nil {
+			vals = append(vals, nil)
+			continue
+		}
+		vals = append(vals, &node.Val)
+		queue = append(queue, node.Left, node.Right)
+	}
+	for len(vals) > 0 && vals[len(vals)-1] == nil {
+		vals = vals[:len(vals)-1]
+	}
+	return formatArray(vals)
+}
+
+// InOrder 中序遍历。
+func (root *TreeNode) InOrder() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		stack := []*TreeNode{}
+		for n := root; n != nil || len(stack) > 0; {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.Left
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.Val) {
+				return
+			}
+			n = n.Right
+		}
+	}
+}
+
+// LevelOrder 层序遍历，依次产出每个结点的深度（从 0 开始）和值。
+func (root *TreeNode) LevelOrder() iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		if root == nil {
+			return
+		}
+		level := []*TreeNode{root}
+		for depth := 0; len(level) > 0; depth++ {
+			next := []*TreeNode{}
+			for _, n := range level {
+				if !yield(depth, n.Val) {
+					return
+				}
+				if n.Left != nil {
+					next = append(next, n.Left)
+				}
+				if n.Right != nil {
+					next = append(next, n.Right)
+				}
+			}
+			level = next
+		}
+	}
+}
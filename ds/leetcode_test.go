@@ -0,0 +1,113 @@
+package ds
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListRoundTrip(t *testing.T) {
+	for _, s := range []string{"[]", "[1]", "[1,2,3]", "[-5,0,5]"} {
+		l, err := ParseList(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := l.String(); got != s {
+			t.Errorf("ParseList(%q).String() = %q", s, got)
+		}
+	}
+	if l, err := ParseList(" [ 1 , 2 ] "); err != nil || l.String() != "[1,2]" {
+		t.Errorf("ParseList with spaces = %v, %v", l, err)
+	}
+	for _, s := range []string{"1,2", "[1,null]", "[1,x]", "[1,,2]"} {
+		if _, err := ParseList(s); err == nil {
+			t.Errorf("ParseList(%q) succeeded", s)
+		}
+	}
+	if got := slices.Collect(NewList(3, 1, 2).All()); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("NewList(3, 1, 2).All() = %v", got)
+	}
+}
+
+func TestTreeRoundTrip(t *testing.T) {
+	for _, s := range []string{"[]", "[1]", "[1,null,2,3]", "[3,9,20,null,null,15,7]", "[1,2,null,3,null,4]"} {
+		root, err := ParseTree(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := root.String(); got != s && !(s == "[]" && root == nil) {
+			t.Errorf("ParseTree(%q).String() = %q", s, got)
+		}
+	}
+	// 末尾多余的 null 在输出时去掉
+	if root, _ := ParseTree("[1,2,null,null,null]"); root.String() != "[1,2]" {
+		t.Errorf("trailing nulls: %q", root.String())
+	}
+	if root, err := ParseTree("[null]"); root != nil || err != nil {
+		t.Errorf("ParseTree([null]) = %v, %v", root, err)
+	}
+	for _, s := range []string{"[1,null,null,2]", "(1)", "[a]"} {
+		if _, err := ParseTree(s); err == nil {
+			t.Errorf("ParseTree(%q) succeeded", s)
+		}
+	}
+
+	root, _ := ParseTree("[4,2,6,1,3,5,7]")
+	if got := slices.Collect(root.InOrder()); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("InOrder() = %v", got)
+	}
+	var depths, vals []int
+	for d, v := range root.LevelOrder() {
+		depths, vals = append(depths, d), append(vals, v)
+	}
+	if !slices.Equal(depths, []int{0, 1, 1, 2, 2, 2, 2}) || !slices.Equal(vals, []int{4, 2, 6, 1, 3, 5, 7}) {
+		t.Errorf("LevelOrder() = %v, %v", depths, vals)
+	}
+}
+
+func mustTree(t *testing.T, s string) *TreeNode {
+	t.Helper()
+	root, err := ParseTree(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// find 按值查找结点，用于构造最近公共祖先的参数。
+func find(root *TreeNode, val int) *TreeNode {
+	if root == nil || root.Val == val {
+		return root
+	}
+	if n := find(root.Left, val); n != nil {
+		return n
+	}
+	return find(root.Right, val)
+}
+
+func TestProblems(t *testing.T) {
+	if got := MaxDepth(mustTree(t, "[3,9,20,null,null,15,7]")); got != 3 {
+		t.Errorf("MaxDepth = %d, want 3", got)
+	}
+	if got := InvertTree(mustTree(t, "[4,2,7,1,3,6,9]")).String(); got != "[4,7,2,9,6,3,1]" {
+		t.Errorf("InvertTree = %s", got)
+	}
+	root := mustTree(t, "[3,5,1,6,2,0,8,null,null,7,4]")
+	for _, tc := range []struct{ p, q, want int }{{5, 1, 3}, {5, 4, 5}, {7, 8, 3}, {6, 4, 5}} {
+		if got := LowestCommonAncestor(root, find(root, tc.p), find(root, tc.q)); got.Val != tc.want {
+			t.Errorf("LowestCommonAncestor(%d, %d) = %d, want %d", tc.p, tc.q, got.Val, tc.want)
+		}
+	}
+	if got := ReverseList(NewList(1, 2, 3, 4, 5)).String(); got != "[5,4,3,2,1]" {
+		t.Errorf("ReverseList = %s", got)
+	}
+	if ReverseList(nil) != nil {
+		t.Error("ReverseList(nil) != nil")
+	}
+	lists := []*ListNode{NewList(1, 4, 5), NewList(1, 3, 4), nil, NewList(2, 6)}
+	if got := MergeKLists(lists).String(); got != "[1,1,2,3,4,4,5,6]" {
+		t.Errorf("MergeKLists = %s", got)
+	}
+	if got := FindCircleNum([][]int{{1, 1, 0}, {1, 1, 0}, {0, 0, 1}}); got != 2 {
+		t.Errorf("FindCircleNum = %d, want 2", got)
+	}
+}
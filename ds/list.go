@@ -0,0 +1,182 @@
+/**
+ * 常用数据结构：链表、堆、平衡二叉搜索树、并查集、LRU 缓存，
+ * 以及与 LeetCode 兼容的 ListNode、TreeNode 和它们的数组序列化。
+ * https://www.hello-algo.com/chapter_data_structure/
+ */
+package ds
+
+import "iter"
+
+// SList 带尾指针的单链表，零值可用。
+type SList[T any] struct {
+	head, tail *snode[T]
+	size       int
+}
+
+type snode[T any] struct {
+	val  T
+	next *snode[T]
+}
+
+func (l *SList[T]) Len() int {
+	return l.size
+}
+
+func (l *SList[T]) PushFront(v T) {
+	n := &snode[T]{val: v, next: l.head}
+	l.head = n
+	if l.tail == nil {
+		l.tail = n
+	}
+	l.size++
+}
+
+func (l *SList[T]) PushBack(v T) {
+	n := &snode[T]{val: v}
+	if l.tail == nil {
+		l.head = n
+	} else {
+		l.tail.next = n
+	}
+	l.tail = n
+	l.size++
+}
+
+// PopFront 删除并返回第一个元素，链表为空时返回 false。
+func (l *SList[T]) PopFront() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	n := l.head
+	l.head = n.next
+	if l.head == nil {
+		l.tail = nil
+	}
+	l.size--
+	return n.val, true
+}
+
+// Reverse 原地反转链表。
+func (l *SList[T]) Reverse() {
+	var prev *snode[T]
+	l.tail = l.head
+	for cur := l.head; cur != nil; {
+		next := cur.next
+		cur.next = prev
+		prev, cur = cur, next
+	}
+	l.head = prev
+}
+
+func (l *SList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.val) {
+				return
+			}
+		}
+	}
+}
+
+// DList 双向链表，用哨兵结点简化边界处理。零值不可用，需通过 NewDList 创建。
+type DList[T any] struct {
+	root Element[T]
+	size int
+}
+
+// Element 双向链表中的结点，可以在 O(1) 时间内删除或移动。
+type Element[T any] struct {
+	Value      T
+	prev, next *Element[T]
+	list       *DList[T]
+}
+
+func NewDList[T any]() *DList[T] {
+	l := &DList[T]{}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	return l
+}
+
+func (l *DList[T]) Len() int {
+	return l.size
+}
+
+// Front 返回第一个结点，链表为空时返回 nil。
+func (l *DList[T]) Front() *Element[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back 返回最后一个结点，链表为空时返回 nil。
+func (l *DList[T]) Back() *Element[T] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+func (l *DList[T]) insertAfter(e, at *Element[T]) *Element[T] {
+	e.prev = at
+	e.next = at.next
+	at.next.prev = e
+	at.next = e
+	e.list = l
+	l.size++
+	return e
+}
+
+func (l *DList[T]) PushFront(v T) *Element[T] {
+	return l.insertAfter(&Element[T]{Value: v}, &l.root)
+}
+
+func (l *DList[T]) PushBack(v T) *Element[T] {
+	return l.insertAfter(&Element[T]{Value: v}, l.root.prev)
+}
+
+// Remove 删除结点 e 并返回它的值，e 不属于该链表时不做任何事。
+func (l *DList[T]) Remove(e *Element[T]) T {
+	if e.list == l {
+		e.prev.next = e.next
+		e.next.prev = e.prev
+		e.prev, e.next, e.list = nil, nil, nil
+		l.size--
+	}
+	return e.Value
+}
+
+// MoveToFront 把结点 e 移到链表头部。
+func (l *DList[T]) MoveToFront(e *Element[T]) {
+	if e.list != l || l.root.next == e {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	l.size--
+	l.insertAfter(e, &l.root)
+}
+
+// All 从前往后遍历。
+func (l *DList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.root.next; e != &l.root; e = e.next {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 从后往前遍历。
+func (l *DList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.root.prev; e != &l.root; e = e.prev {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package ds
+
+import "iter"
+
+// LRU 最近最少使用缓存：哈希表定位结点，双向链表维护使用顺序，Get 和 Put 都是 O(1)。
+// notes: 笔记/redis.md#缓存淘汰策略
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*Element[lruEntry[K, V]]
+	order    *DList[lruEntry[K, V]] // 表头是最近使用的
+}
+
+type lruEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// NewLRU 创建容量为 capacity 的缓存，capacity 必须为正数。
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity <= 0 {
+		panic("ds: LRU capacity must be positive")
+	}
+	return &LRU[K, V]{
+		capacity: capacity,
+		items:    map[K]*Element[lruEntry[K, V]]{},
+		order:    NewDList[lruEntry[K, V]](),
+	}
+}
+
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
+
+// Get 返回键对应的值并把它标记为最近使用。
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.val, true
+}
+
+// Put 写入键值对，超出容量时淘汰最久未使用的键并返回它。
+func (c *LRU[K, V]) Put(key K, val V) (evicted K, ok bool) {
+	if e, exists := c.items[key]; exists {
+		e.Value.val = val
+		c.order.MoveToFront(e)
+		return evicted, false
+	}
+
+	c.items[key] = c.order.PushFront(lruEntry[K, V]{key, val})
+	if c.order.Len() > c.capacity {
+		old := c.order.Remove(c.order.Back())
+		delete(c.items, old.key)
+		return old.key, true
+	}
+	return evicted, false
+}
+
+// Remove 删除键，键不存在时返回 false。
+func (c *LRU[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(e)
+	delete(c.items, key)
+	return true
+}
+
+// All 从最近使用到最久未使用遍历，不改变使用顺序。
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range c.order.All() {
+			if !yield(e.key, e.val) {
+				return
+			}
+		}
+	}
+}
+
+/**
+ * 146. LRU 缓存
+ * https://leetcode.cn/problems/lru-cache/
+ */
+type LRUCache struct {
+	lru *LRU[int, int]
+}
+
+func Constructor(capacity int) LRUCache {
+	return LRUCache{lru: NewLRU[int, int](capacity)}
+}
+
+func (c *LRUCache) Get(key int) int {
+	if v, ok := c.lru.Get(key); ok {
+		return v
+	}
+	return -1
+}
+
+func (c *LRUCache) Put(key int, value int) {
+	c.lru.Put(key, value)
+}
@@ -0,0 +1,97 @@
+package ds
+
+/**
+ * 104. 二叉树的最大深度
+ * https://leetcode.cn/problems/maximum-depth-of-binary-tree/
+ */
+func MaxDepth(root *TreeNode) int {
+	if root == nil {
+		return 0
+	}
+	return max(MaxDepth(root.Left), MaxDepth(root.Right)) + 1
+}
+
+/**
+ * 226. 翻转二叉树
+ * https://leetcode.cn/problems/invert-binary-tree/
+ */
+func InvertTree(root *TreeNode) *TreeNode {
+	if root == nil {
+		return nil
+	}
+	root.Left, root.Right = InvertTree(root.Right), InvertTree(root.Left)
+	return root
+}
+
+/**
+ * 236. 二叉树的最近公共祖先
+ * https://leetcode.cn/problems/lowest-common-ancestor-of-a-binary-tree/
+ */
+func LowestCommonAncestor(root, p, q *TreeNode) *TreeNode {
+	if root == nil || root == p || root == q {
+		return root
+	}
+	left := LowestCommonAncestor(root.Left, p, q)
+	right := LowestCommonAncestor(root.Right, p, q)
+	if left != nil && right != nil {
+		return root
+	}
+	if left != nil {
+		return left
+	}
+	return right
+}
+
+/**
+ * 206. 反转链表
+ * https://leetcode.cn/problems/reverse-linked-list/
+ */
+func ReverseList(head *ListNode) *ListNode {
+	var prev *ListNode
+	for head != nil {
+		head.Next, prev, head = prev, head, head.Next
+	}
+	return prev
+}
+
+/**
+ * 23. 合并 K 个升序链表
+ * https://leetcode.cn/problems/merge-k-sorted-lists/
+ * 小顶堆里放每个链表当前的头结点。
+ */
+func MergeKLists(lists []*ListNode) *ListNode {
+	h := NewHeap(func(a, b *ListNode) bool { return a.Val < b.Val })
+	for _, l := range lists {
+		if l != nil {
+			h.Push(l)
+		}
+	}
+
+	dummy := &ListNode{}
+	cur := dummy
+	for node := range h.Drain() {
+		cur.Next = node
+		cur = node
+		if node.Next != nil {
+			h.Push(node.Next)
+		}
+	}
+	return dummy.Next
+}
+
+/**
+ * 547. 省份数量
+ * https://leetcode.cn/problems/number-of-provinces/
+ */
+func FindCircleNum(isConnected [][]int) int {
+	n := len(isConnected)
+	uf := NewUnionFind(n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if isConnected[i][j] == 1 {
+				uf.Union(i, j)
+			}
+		}
+	}
+	return uf.Count()
+}
@@ -0,0 +1,69 @@
+package ds
+
+// UnionFind 并查集，路径压缩加按大小合并，操作近似 O(1)。
+type UnionFind struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+// NewUnionFind 创建 n 个各自独立的集合 0..n-1。
+func NewUnionFind(n int) *UnionFind {
+	uf := &UnionFind{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range uf.parent {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+	return uf
+}
+
+// Find 返回 x 所在集合的代表元素，并把路径上的结点直接挂到代表元素下。
+func (uf *UnionFind) Find(x int) int {
+	root := x
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	for uf.parent[x] != root {
+		uf.parent[x], x = root, uf.parent[x]
+	}
+	return root
+}
+
+// Union 合并 x 和 y 所在的集合，已在同一集合时返回 false。
+func (uf *UnionFind) Union(x, y int) bool {
+	rx, ry := uf.Find(x), uf.Find(y)
+	if rx == ry {
+		return false
+	}
+	if uf.size[rx] < uf.size[ry] {
+		rx, ry = ry, rx
+	}
+	uf.parent[ry] = rx
+	uf.size[rx] += uf.size[ry]
+	uf.count--
+	return true
+}
+
+func (uf *UnionFind) Connected(x, y int) bool {
+	return uf.Find(x) == uf.Find(y)
+}
+
+// Size 返回 x 所在集合的大小。
+func (uf *UnionFind) Size(x int) int {
+	return uf.size[uf.Find(x)]
+}
+
+// Count 返回集合的个数。
+func (uf *UnionFind) Count() int {
+	return uf.count
+}
+
+// Groups 按代表元素分组返回所有集合。
+func (uf *UnionFind) Groups() map[int][]int {
+	res := map[int][]int{}
+	for i := range uf.parent {
+		r := uf.Find(i)
+		res[r] = append(res[r], i)
+	}
+	return res
+}
@@ -1,3 +1,10 @@
 module leetcode-go
 
 go 1.23.3
+
+require golang.org/x/tools v0.35.0
+
+require (
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+)
@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DotOptions 控制 DOT 输出。
+type DotOptions struct {
+	Name      string
+	Label     func(v int) string // 顶点标签，默认为编号
+	Highlight []Edge             // 需要高亮的边，例如最短路或最小生成树
+	Weights   bool               // 是否在边上标出权重
+}
+
+// WriteDOT 以 Graphviz DOT 格式输出图，可用 `dot -Tpng` 渲染。
+func (g *Graph) WriteDOT(w io.Writer, opts DotOptions) error {
+	bw := bufio.NewWriter(w)
+	kind, arrow := "graph", "--"
+	if g.directed {
+		kind, arrow = "digraph", "->"
+	}
+	name := opts.Name
+	if name == "" {
+		name = "G"
+	}
+
+	highlight := map[[2]int]bool{}
+	for _, e := range opts.Highlight {
+		highlight[[2]int{e.From, e.To}] = true
+		if !g.directed {
+			highlight[[2]int{e.To, e.From}] = true
+		}
+	}
+
+	fmt.Fprintf(bw, "%s %s {\n", kind, strconv.Quote(name))
+	for v := 0; v < g.Len(); v++ {
+		label := strconv.Itoa(v)
+		if opts.Label != nil {
+			label = opts.Label(v)
+		}
+		fmt.Fprintf(bw, "  %d [label=%s];\n", v, strconv.Quote(label))
+	}
+	for e := range g.Edges() {
+		attrs := ""
+		if opts.Weights {
+			attrs += fmt.Sprintf("label=\"%d\"", e.Weight)
+		}
+		if highlight[[2]int{e.From, e.To}] {
+			if attrs != "" {
+				attrs += ", "
+			}
+			attrs += "color=red, penwidth=2"
+		}
+		if attrs != "" {
+			attrs = " [" + attrs + "]"
+		}
+		fmt.Fprintf(bw, "  %d %s %d%s;\n", e.From, arrow, e.To, attrs)
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
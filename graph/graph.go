@@ -0,0 +1,151 @@
+/**
+ * 图算法：BFS/DFS、拓扑排序、最短路、最小生成树、强连通分量，
+ * 支持邻接表、邻接矩阵、边列表三种输入，以及 LeetCode 网格题的适配。
+ * 顶点用 0..n-1 的整数表示。
+ */
+package graph
+
+import (
+	"errors"
+	"iter"
+	"math"
+)
+
+// Inf 不可达顶点的距离。
+const Inf = math.MaxInt
+
+var (
+	ErrCycle         = errors.New("graph: graph has a cycle")
+	ErrNegativeCycle = errors.New("graph: negative cycle reachable from source")
+)
+
+type Edge struct {
+	From, To int
+	Weight   int
+}
+
+// Graph 邻接表存储的图，无向图的每条边在两个端点上各存一次。
+type Graph struct {
+	directed bool
+	adj      [][]Edge
+}
+
+func New(n int, directed bool) *Graph {
+	return &Graph{directed: directed, adj: make([][]Edge, n)}
+}
+
+// FromEdges 从 LeetCode 的边列表创建图，每条边为 [u, v] 或带权的 [u, v, w]，不带权时权重为 1。
+func FromEdges(n int, directed bool, edges [][]int) *Graph {
+	g := New(n, directed)
+	for _, e := range edges {
+		w := 1
+		if len(e) > 2 {
+			w = e[2]
+		}
+		g.AddEdge(e[0], e[1], w)
+	}
+	return g
+}
+
+// FromAdjList 从邻接表创建图，adj[u] 为 u 的所有邻居，权重为 1。
+// 无向图的邻接表通常两个方向都列出，这里只按 u < v 的一侧加边，避免重复。
+func FromAdjList(adj [][]int, directed bool) *Graph {
+	g := New(len(adj), directed)
+	for u, vs := range adj {
+		for _, v := range vs {
+			if directed || u <= v {
+				g.AddEdge(u, v, 1)
+			}
+		}
+	}
+	return g
+}
+
+// FromMatrix 从邻接矩阵创建图，m[u][v] 非零表示有一条权重为 m[u][v] 的边。
+// 无向图只读取上三角部分。
+func FromMatrix(m [][]int, directed bool) *Graph {
+	g := New(len(m), directed)
+	for u, row := range m {
+		for v, w := range row {
+			if w != 0 && u != v && (directed || u < v) {
+				g.AddEdge(u, v, w)
+			}
+		}
+	}
+	return g
+}
+
+func (g *Graph) Len() int {
+	return len(g.adj)
+}
+
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+func (g *Graph) AddEdge(u, v, w int) {
+	g.adj[u] = append(g.adj[u], Edge{u, v, w})
+	if !g.directed && u != v {
+		g.adj[v] = append(g.adj[v], Edge{v, u, w})
+	}
+}
+
+// Neighbors 返回从 u 出发的边，调用方不应修改返回值。
+func (g *Graph) Neighbors(u int) []Edge {
+	return g.adj[u]
+}
+
+// Edges 遍历所有边，无向图的每条边只产出一次（From <= To）。
+func (g *Graph) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for _, es := range g.adj {
+			for _, e := range es {
+				if !g.directed && e.From > e.To {
+					continue
+				}
+				if !yield(e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Matrix 返回邻接矩阵表示，没有边的位置为 0。
+func (g *Graph) Matrix() [][]int {
+	n := len(g.adj)
+	m := make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+	}
+	for u, es := range g.adj {
+		for _, e := range es {
+			m[u][e.To] = e.Weight
+		}
+	}
+	return m
+}
+
+// Reverse 返回所有边反向后的图。
+func (g *Graph) Reverse() *Graph {
+	r := New(len(g.adj), g.directed)
+	for e := range g.Edges() {
+		r.AddEdge(e.To, e.From, e.Weight)
+	}
+	return r
+}
+
+// PathTo 根据前驱数组还原到 v 的路径，v 不可达时返回 nil。
+func PathTo(prev []int, src, v int) []int {
+	path := []int{}
+	for ; v != -1; v = prev[v] {
+		path = append(path, v)
+		if v == src {
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+	}
+	return nil
+}
@@ -0,0 +1,417 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// randGraph 随机图，weight 生成每条边的权重。
+func randGraph(r *rand.Rand, n, m int, directed bool, weight func() int) *Graph {
+	g := New(n, directed)
+	for range m {
+		g.AddEdge(r.Intn(n), r.Intn(n), weight())
+	}
+	return g
+}
+
+// floyd Floyd-Warshall 全源最短路，作为 Dijkstra 和 Bellman-Ford 的参照。
+// 只在没有负环的图上使用；reach 为可达性闭包。
+func floyd(g *Graph) (dist [][]int, reach [][]bool) {
+	n := g.Len()
+	dist, reach = make([][]int, n), make([][]bool, n)
+	for i := range n {
+		dist[i], reach[i] = make([]int, n), make([]bool, n)
+		for j := range n {
+			dist[i][j] = Inf
+		}
+		dist[i][i], reach[i][i] = 0, true
+		for _, e := range g.Neighbors(i) {
+			dist[i][e.To] = min(dist[i][e.To], e.Weight)
+			reach[i][e.To] = true
+		}
+	}
+	for k := range n {
+		for i := range n {
+			for j := range n {
+				reach[i][j] = reach[i][j] || reach[i][k] && reach[k][j]
+				if dist[i][k] != Inf && dist[k][j] != Inf {
+					dist[i][j] = min(dist[i][j], dist[i][k]+dist[k][j])
+				}
+			}
+		}
+	}
+	return dist, reach
+}
+
+// hasEdge 判断 g 中有没有 u -> v 的边，权重为 w（w < 0 时不检查权重）。
+func hasEdge(g *Graph, u, v, w int) bool {
+	return slices.ContainsFunc(g.Neighbors(u), func(e Edge) bool { return e.To == v && (w < 0 || e.Weight == w) })
+}
+
+func TestTopoSortAndFindCycle(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 500 {
+		n := r.Intn(8) + 1
+		g := randGraph(r, n, r.Intn(12), true, func() int { return 1 })
+		_, reach := floyd(g)
+		acyclic := true
+		for u := range n {
+			for _, e := range g.Neighbors(u) {
+				// u -> v 且 v 能回到 u 即有环（包括自环）
+				acyclic = acyclic && !reach[e.To][u]
+			}
+		}
+
+		order, err := g.TopoSort()
+		cycle := g.FindCycle()
+		if acyclic != (err == nil) || acyclic != (cycle == nil) {
+			t.Fatalf("acyclic = %v, TopoSort error %v, FindCycle %v", acyclic, err, cycle)
+		}
+		if !acyclic {
+			if !errors.Is(err, ErrCycle) || len(cycle) < 2 || cycle[0] != cycle[len(cycle)-1] {
+				t.Fatalf("TopoSort error %v, FindCycle %v", err, cycle)
+			}
+			for i := 1; i < len(cycle); i++ {
+				if !hasEdge(g, cycle[i-1], cycle[i], -1) {
+					t.Fatalf("cycle %v uses a missing edge %d -> %d", cycle, cycle[i-1], cycle[i])
+				}
+			}
+			continue
+		}
+		pos := make([]int, n)
+		for i, u := range order {
+			pos[u] = i
+		}
+		if len(order) != n {
+			t.Fatalf("TopoSort returned %v for %d vertices", order, n)
+		}
+		for e := range g.Edges() {
+			if pos[e.From] >= pos[e.To] {
+				t.Fatalf("TopoSort %v puts %d after %d", order, e.From, e.To)
+			}
+		}
+	}
+}
+
+func TestSCC(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 500 {
+		n := r.Intn(9) + 1
+		g := randGraph(r, n, r.Intn(15), true, func() int { return 1 })
+		_, reach := floyd(g)
+		comps := g.SCC()
+		comp := make([]int, n)
+		seen := 0
+		for i, c := range comps {
+			if !slices.IsSorted(c) {
+				t.Fatalf("component %v not sorted", c)
+			}
+			for _, u := range c {
+				comp[u] = i
+			}
+			seen += len(c)
+		}
+		if seen != n {
+			t.Fatalf("SCC() = %v covers %d of %d vertices", comps, seen, n)
+		}
+		for u := range n {
+			for v := range n {
+				if same := reach[u][v] && reach[v][u]; same != (comp[u] == comp[v]) {
+					t.Fatalf("SCC() = %v, but %d and %d mutually reachable = %v", comps, u, v, same)
+				}
+			}
+		}
+		// 逆拓扑序：跨分量的边总是指向更早输出的分量
+		for e := range g.Edges() {
+			if comp[e.From] < comp[e.To] {
+				t.Fatalf("SCC() = %v not in reverse topological order: edge %d -> %d", comps, e.From, e.To)
+			}
+		}
+	}
+}
+
+// checkPaths 检查前驱数组还原出的路径确实由图中的边组成，长度等于距离。
+func checkPaths(t *testing.T, g *Graph, src int, dist, prev []int) {
+	t.Helper()
+	for v, d := range dist {
+		path := PathTo(prev, src, v)
+		if d == Inf {
+			if path != nil {
+				t.Fatalf("PathTo(%d) = %v for an unreachable vertex", v, path)
+			}
+			continue
+		}
+		if path[0] != src || path[len(path)-1] != v {
+			t.Fatalf("PathTo(%d) = %v", v, path)
+		}
+		sum := 0
+		for i := 1; i < len(path); i++ {
+			u, w := path[i-1], path[i]
+			best := Inf
+			for _, e := range g.Neighbors(u) {
+				if e.To == w {
+					best = min(best, e.Weight)
+				}
+			}
+			if best == Inf {
+				t.Fatalf("PathTo(%d) = %v uses a missing edge", v, path)
+			}
+			sum += best
+		}
+		if sum != d {
+			t.Fatalf("PathTo(%d) = %v has length %d, dist %d", v, path, sum, d)
+		}
+	}
+}
+
+func TestShortestPaths(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 300 {
+		n := r.Intn(8) + 1
+		directed := r.Intn(2) == 0
+		g := randGraph(r, n, r.Intn(20), directed, func() int { return r.Intn(10) })
+		want, _ := floyd(g)
+		src := r.Intn(n)
+		dist, prev := g.Dijkstra(src)
+		if !slices.Equal(dist, want[src]) {
+			t.Fatalf("Dijkstra(%d) = %v, want %v", src, dist, want[src])
+		}
+		checkPaths(t, g, src, dist, prev)
+		dist, prev, err := g.BellmanFord(src)
+		if err != nil || !slices.Equal(dist, want[src]) {
+			t.Fatalf("BellmanFord(%d) = %v, %v, want %v", src, dist, err, want[src])
+		}
+		checkPaths(t, g, src, dist, prev)
+	}
+}
+
+func randPotential(r *rand.Rand, n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = r.Intn(10)
+	}
+	return p
+}
+
+func TestBellmanFordNegative(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for range 300 {
+		// 非负权重加上势能差 p[u] - p[v]：任何环上势能抵消，所以有负权边而没有负环
+		n := r.Intn(8) + 1
+		p := randPotential(r, n)
+		g := New(n, true)
+		for range r.Intn(20) {
+			u, v := r.Intn(n), r.Intn(n)
+			g.AddEdge(u, v, r.Intn(5)+p[u]-p[v])
+		}
+		want, _ := floyd(g)
+		dist, prev, err := g.BellmanFord(0)
+		if err != nil || !slices.Equal(dist, want[0]) {
+			t.Fatalf("BellmanFord = %v, %v, want %v", dist, err, want[0])
+		}
+		checkPaths(t, g, 0, dist, prev)
+	}
+
+	g := FromEdges(4, true, [][]int{{0, 1, 1}, {1, 2, -2}, {2, 1, 1}, {3, 3, -1}})
+	if _, _, err := g.BellmanFord(0); !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("BellmanFord with a reachable negative cycle = %v", err)
+	}
+	if _, _, err := g.BellmanFord(3); !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("BellmanFord(3) on a negative self-loop = %v", err)
+	}
+	h := FromEdges(3, true, [][]int{{0, 1, 2}, {1, 2, -1}, {2, 1, -1}})
+	if _, _, err := h.BellmanFord(0); !errors.Is(err, ErrNegativeCycle) {
+		t.Fatalf("BellmanFord = %v, want ErrNegativeCycle", err)
+	}
+	if dist, _, err := h.BellmanFord(2); err == nil {
+		t.Fatalf("BellmanFord(2) = %v, want ErrNegativeCycle", dist)
+	}
+	// 负环从起点不可达时不影响结果
+	k := FromEdges(3, true, [][]int{{1, 2, -1}, {2, 1, -1}})
+	if dist, _, err := k.BellmanFord(0); err != nil || dist[1] != Inf {
+		t.Fatalf("BellmanFord from a vertex that cannot reach the cycle = %v, %v", dist, err)
+	}
+}
+
+// minForest 穷举边的子集求最小生成森林的权重，只用于很小的图。
+func minForest(g *Graph) int {
+	edges := slices.Collect(g.Edges())
+	_, want := componentsOf(g, edges)
+	best := Inf
+	for mask := 0; mask < 1<<len(edges); mask++ {
+		var sub []Edge
+		sum := 0
+		for i, e := range edges {
+			if mask>>i&1 == 1 {
+				sub = append(sub, e)
+				sum += e.Weight
+			}
+		}
+		// 边数等于 n - 分量数且连通性与原图相同，即为生成森林
+		if forest, count := componentsOf(g, sub); forest && count == want && len(sub) == g.Len()-count {
+			best = min(best, sum)
+		}
+	}
+	return best
+}
+
+// componentsOf 只用 edges 时的连通分量数，以及这些边是否无环。
+func componentsOf(g *Graph, edges []Edge) (bool, int) {
+	parent := make([]int, g.Len())
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(x int) int {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	count, acyclic := g.Len(), true
+	for _, e := range edges {
+		a, b := find(e.From), find(e.To)
+		if a == b {
+			acyclic = false
+			continue
+		}
+		parent[a] = b
+		count--
+	}
+	return acyclic, count
+}
+
+func TestMST(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for range 300 {
+		n := r.Intn(6) + 1
+		g := randGraph(r, n, r.Intn(10), false, func() int { return r.Intn(10) - 2 })
+		want := minForest(g)
+		for name, mst := range map[string]func() (int, []Edge){"Kruskal": g.Kruskal, "Prim": g.Prim} {
+			total, edges := mst()
+			sum := 0
+			for _, e := range edges {
+				if !hasEdge(g, e.From, e.To, e.Weight) {
+					t.Fatalf("%s used a missing edge %v", name, e)
+				}
+				sum += e.Weight
+			}
+			acyclic, count := componentsOf(g, edges)
+			_, wantCount := componentsOf(g, slices.Collect(g.Edges()))
+			if total != want || sum != total || !acyclic || count != wantCount {
+				t.Fatalf("%s = %d, %v, want total %d", name, total, edges, want)
+			}
+		}
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	g := FromAdjList([][]int{{1, 2}, {0, 3}, {0, 3}, {1, 2}, {5}, {4}}, false)
+	order, dist := g.BFS(0)
+	if !slices.Equal(order, []int{0, 1, 2, 3}) || !slices.Equal(dist, []int{0, 1, 1, 2, Inf, Inf}) {
+		t.Fatalf("BFS(0) = %v, %v", order, dist)
+	}
+	if got := g.DFS(0); !slices.Equal(got, []int{0, 1, 3, 2}) {
+		t.Fatalf("DFS(0) = %v", got)
+	}
+	if got := g.Components(); !slices.EqualFunc(got, [][]int{{0, 1, 2, 3}, {4, 5}}, slices.Equal) {
+		t.Fatalf("Components() = %v", got)
+	}
+	m := FromMatrix(g.Matrix(), false)
+	if got := slices.Collect(m.Edges()); !slices.Equal(got, slices.Collect(g.Edges())) {
+		t.Fatalf("FromMatrix(Matrix()) edges = %v", got)
+	}
+	d := FromEdges(3, true, [][]int{{0, 1, 5}, {1, 2}})
+	if got := slices.Collect(d.Reverse().Edges()); !slices.Equal(got, []Edge{{1, 0, 5}, {2, 1, 1}}) {
+		t.Fatalf("Reverse() edges = %v", got)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var b strings.Builder
+	g := FromEdges(3, false, [][]int{{0, 1, 4}, {1, 2, 7}})
+	err := g.WriteDOT(&b, DotOptions{
+		Label:     func(v int) string { return string(rune('A' + v)) },
+		Highlight: []Edge{{2, 1, 7}},
+		Weights:   true,
+	})
+	want := `graph "G" {
+  0 [label="A"];
+  1 [label="B"];
+  2 [label="C"];
+  0 -- 1 [label="4"];
+  1 -- 2 [label="7", color=red, penwidth=2];
+}
+`
+	if err != nil || b.String() != want {
+		t.Fatalf("WriteDOT = %v\n%s", err, b.String())
+	}
+
+	b.Reset()
+	d := FromEdges(2, true, [][]int{{1, 0}})
+	// 有向图的高亮区分方向
+	if err := d.WriteDOT(&b, DotOptions{Name: "dag \"x\"", Highlight: []Edge{{0, 1, 1}}}); err != nil {
+		t.Fatal(err)
+	}
+	want = `digraph "dag \"x\"" {
+  0 [label="0"];
+  1 [label="1"];
+  1 -> 0;
+}
+`
+	if b.String() != want {
+		t.Fatalf("WriteDOT directed:\n%s", b.String())
+	}
+	if err := d.WriteDOT(failWriter{}, DotOptions{}); err == nil {
+		t.Fatal("WriteDOT ignored a write error")
+	}
+}
+
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestProblems(t *testing.T) {
+	if !CanFinish(2, [][]int{{1, 0}}) || CanFinish(2, [][]int{{1, 0}, {0, 1}}) {
+		t.Error("CanFinish is wrong")
+	}
+	if got := FindOrder(4, [][]int{{1, 0}, {2, 0}, {3, 1}, {3, 2}}); !slices.Equal(got, []int{0, 1, 2, 3}) {
+		t.Errorf("FindOrder = %v", got)
+	}
+	if got := FindOrder(2, [][]int{{1, 0}, {0, 1}}); got == nil || len(got) != 0 {
+		t.Errorf("FindOrder with a cycle = %#v, want []int{}", got)
+	}
+	if got := NetworkDelayTime([][]int{{2, 1, 1}, {2, 3, 1}, {3, 4, 1}}, 4, 2); got != 2 {
+		t.Errorf("NetworkDelayTime = %d, want 2", got)
+	}
+	if got := NetworkDelayTime([][]int{{1, 2, 1}}, 2, 2); got != -1 {
+		t.Errorf("NetworkDelayTime unreachable = %d, want -1", got)
+	}
+	if got := MinCostConnectPoints([][]int{{0, 0}, {2, 2}, {3, 10}, {5, 2}, {7, 0}}); got != 20 {
+		t.Errorf("MinCostConnectPoints = %d, want 20", got)
+	}
+	grid := [][]byte{
+		[]byte("11000"),
+		[]byte("11000"),
+		[]byte("00100"),
+		[]byte("00011"),
+	}
+	if got := NumIslands(grid); got != 3 {
+		t.Errorf("NumIslands = %d, want 3", got)
+	}
+	board := [][]byte{
+		[]byte("XXXX"),
+		[]byte("XOOX"),
+		[]byte("XXOX"),
+		[]byte("XOXX"),
+	}
+	Solve(board)
+	if got := string(slices.Concat(board...)); got != "XXXXXXXXXXXXXOXX" {
+		t.Errorf("Solve = %q", got)
+	}
+}
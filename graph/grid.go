@@ -0,0 +1,108 @@
+package graph
+
+// Grid 把 LeetCode 的 [][]byte 网格看作图：每个格子是一个顶点，编号为 r*cols+c，
+// 上下左右相邻且 connect 返回 true 的两个格子之间有一条无向边。
+type Grid struct {
+	*Graph
+	Rows, Cols int
+}
+
+var dirs = [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+
+// FromGrid 创建网格图，connect 决定两个相邻格子是否连通。
+func FromGrid(grid [][]byte, connect func(a, b byte) bool) *Grid {
+	rows := len(grid)
+	cols := 0
+	if rows > 0 {
+		cols = len(grid[0])
+	}
+	g := &Grid{Graph: New(rows*cols, false), Rows: rows, Cols: cols}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			// 只连右边和下边，避免重复加边
+			if c+1 < cols && connect(grid[r][c], grid[r][c+1]) {
+				g.AddEdge(g.ID(r, c), g.ID(r, c+1), 1)
+			}
+			if r+1 < rows && connect(grid[r][c], grid[r+1][c]) {
+				g.AddEdge(g.ID(r, c), g.ID(r+1, c), 1)
+			}
+		}
+	}
+	return g
+}
+
+// SameCell 相邻格子字符相同即连通。
+func SameCell(a, b byte) bool {
+	return a == b
+}
+
+func (g *Grid) ID(r, c int) int {
+	return r*g.Cols + c
+}
+
+func (g *Grid) Cell(id int) (int, int) {
+	return id / g.Cols, id % g.Cols
+}
+
+// OnBorder 判断顶点是否在网格边缘。
+func (g *Grid) OnBorder(id int) bool {
+	r, c := g.Cell(id)
+	return r == 0 || c == 0 || r == g.Rows-1 || c == g.Cols-1
+}
+
+// Neighbors4 返回 (r, c) 在网格内的上下左右邻居。
+func (g *Grid) Neighbors4(r, c int) [][2]int {
+	res := [][2]int{}
+	for _, d := range dirs {
+		nr, nc := r+d[0], c+d[1]
+		if nr >= 0 && nr < g.Rows && nc >= 0 && nc < g.Cols {
+			res = append(res, [2]int{nr, nc})
+		}
+	}
+	return res
+}
+
+/**
+ * 200. 岛屿数量
+ * https://leetcode.cn/problems/number-of-islands/
+ * 陆地格子之间连边，岛屿数就是由陆地组成的连通分量个数。
+ */
+func NumIslands(grid [][]byte) int {
+	g := FromGrid(grid, SameCell)
+	res := 0
+	for _, comp := range g.Components() {
+		r, c := g.Cell(comp[0])
+		if grid[r][c] == '1' {
+			res++
+		}
+	}
+	return res
+}
+
+/**
+ * 130. 被围绕的区域
+ * https://leetcode.cn/problems/surrounded-regions/
+ * 不与边缘连通的 'O' 分量都被 'X' 围绕，原地改成 'X'。
+ */
+func Solve(board [][]byte) {
+	g := FromGrid(board, SameCell)
+	for _, comp := range g.Components() {
+		r, c := g.Cell(comp[0])
+		if board[r][c] != 'O' {
+			continue
+		}
+		surrounded := true
+		for _, id := range comp {
+			if g.OnBorder(id) {
+				surrounded = false
+				break
+			}
+		}
+		if surrounded {
+			for _, id := range comp {
+				r, c := g.Cell(id)
+				board[r][c] = 'X'
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package graph
+
+/**
+ * 207. 课程表
+ * https://leetcode.cn/problems/course-schedule/
+ */
+func CanFinish(numCourses int, prerequisites [][]int) bool {
+	_, err := courseGraph(numCourses, prerequisites).TopoSort()
+	return err == nil
+}
+
+/**
+ * 210. 课程表 II
+ * https://leetcode.cn/problems/course-schedule-ii/
+ */
+func FindOrder(numCourses int, prerequisites [][]int) []int {
+	order, err := courseGraph(numCourses, prerequisites).TopoSort()
+	if err != nil {
+		return []int{}
+	}
+	return order
+}
+
+// courseGraph 先修课程 [a, b] 表示 b -> a。
+func courseGraph(n int, prerequisites [][]int) *Graph {
+	g := New(n, true)
+	for _, p := range prerequisites {
+		g.AddEdge(p[1], p[0], 1)
+	}
+	return g
+}
+
+/**
+ * 743. 网络延迟时间
+ * https://leetcode.cn/problems/network-delay-time/
+ */
+func NetworkDelayTime(times [][]int, n int, k int) int {
+	g := New(n, true)
+	for _, t := range times {
+		g.AddEdge(t[0]-1, t[1]-1, t[2])
+	}
+	dist, _ := g.Dijkstra(k - 1)
+	res := 0
+	for _, d := range dist {
+		if d == Inf {
+			return -1
+		}
+		res = max(res, d)
+	}
+	return res
+}
+
+/**
+ * 1584. 连接所有点的最小费用
+ * https://leetcode.cn/problems/min-cost-to-connect-all-points/
+ */
+func MinCostConnectPoints(points [][]int) int {
+	n := len(points)
+	g := New(n, false)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			d := abs(points[i][0]-points[j][0]) + abs(points[i][1]-points[j][1])
+			g.AddEdge(i, j, d)
+		}
+	}
+	total, _ := g.Prim()
+	return total
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"cmp"
+	"slices"
+
+	"leetcode-go/ds"
+)
+
+// Dijkstra 单源最短路，要求边权非负。返回距离（不可达为 Inf）和前驱（没有为 -1）。
+func (g *Graph) Dijkstra(src int) ([]int, []int) {
+	n := g.Len()
+	dist, prev := make([]int, n), make([]int, n)
+	for i := range dist {
+		dist[i], prev[i] = Inf, -1
+	}
+	dist[src] = 0
+
+	pq := ds.NewIndexedPQ[int, int](func(a, b int) bool { return a < b })
+	pq.Set(src, 0)
+	for pq.Len() > 0 {
+		u, d, _ := pq.Pop()
+		for _, e := range g.adj[u] {
+			if nd := d + e.Weight; nd < dist[e.To] {
+				dist[e.To], prev[e.To] = nd, u
+				pq.Set(e.To, nd)
+			}
+		}
+	}
+	return dist, prev
+}
+
+// BellmanFord 单源最短路，允许负权边。从 src 可达负环时返回 ErrNegativeCycle。
+func (g *Graph) BellmanFord(src int) ([]int, []int, error) {
+	n := g.Len()
+	dist, prev := make([]int, n), make([]int, n)
+	for i := range dist {
+		dist[i], prev[i] = Inf, -1
+	}
+	dist[src] = 0
+
+	relax := func() bool {
+		changed := false
+		for u, es := range g.adj {
+			if dist[u] == Inf {
+				continue
+			}
+			for _, e := range es {
+				if nd := dist[u] + e.Weight; nd < dist[e.To] {
+					dist[e.To], prev[e.To] = nd, u
+					changed = true
+				}
+			}
+		}
+		return changed
+	}
+	for i := 0; i < n-1; i++ {
+		if !relax() {
+			return dist, prev, nil
+		}
+	}
+	if relax() {
+		return dist, prev, ErrNegativeCycle
+	}
+	return dist, prev, nil
+}
+
+// Kruskal 按边权从小到大加边，用并查集判断是否成环。
+// 返回最小生成树（图不连通时为最小生成森林）的总权重和所用的边。
+func (g *Graph) Kruskal() (int, []Edge) {
+	edges := slices.Collect(g.Edges())
+	slices.SortStableFunc(edges, func(a, b Edge) int { return cmp.Compare(a.Weight, b.Weight) })
+
+	uf := ds.NewUnionFind(g.Len())
+	total, res := 0, []Edge{}
+	for _, e := range edges {
+		if uf.Union(e.From, e.To) {
+			total += e.Weight
+			res = append(res, e)
+		}
+	}
+	return total, res
+}
+
+// Prim 从每个未访问的顶点出发扩展最小生成树，用索引优先队列维护到树的最短边。
+func (g *Graph) Prim() (int, []Edge) {
+	n := g.Len()
+	inTree := make([]bool, n)
+	best := make([]Edge, n) // 连接到树的最短边
+	total, res := 0, []Edge{}
+
+	pq := ds.NewIndexedPQ[int, int](func(a, b int) bool { return a < b })
+	for s := 0; s < n; s++ {
+		if inTree[s] {
+			continue
+		}
+		pq.Set(s, 0)
+		best[s] = Edge{-1, s, 0}
+		for pq.Len() > 0 {
+			u, _, _ := pq.Pop()
+			inTree[u] = true
+			if best[u].From >= 0 {
+				total += best[u].Weight
+				res = append(res, best[u])
+			}
+			for _, e := range g.adj[u] {
+				if inTree[e.To] {
+					continue
+				}
+				if w, ok := pq.Priority(e.To); !ok || e.Weight < w {
+					pq.Set(e.To, e.Weight)
+					best[e.To] = e
+				}
+			}
+		}
+	}
+	return total, res
+}
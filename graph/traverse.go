@@ -0,0 +1,187 @@
+package graph
+
+import "slices"
+
+// BFS 从 src 广度优先遍历，返回访问顺序和每个顶点的边数距离（不可达为 Inf）。
+func (g *Graph) BFS(src int) ([]int, []int) {
+	dist := make([]int, g.Len())
+	for i := range dist {
+		dist[i] = Inf
+	}
+	dist[src] = 0
+	order := []int{src}
+	for i := 0; i < len(order); i++ {
+		u := order[i]
+		for _, e := range g.adj[u] {
+			if dist[e.To] == Inf {
+				dist[e.To] = dist[u] + 1
+				order = append(order, e.To)
+			}
+		}
+	}
+	return order, dist
+}
+
+// DFS 从 src 深度优先遍历，返回先序访问顺序，邻居按加边顺序访问。
+func (g *Graph) DFS(src int) []int {
+	visited := make([]bool, g.Len())
+	order := []int{}
+	stack := []int{src}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+		order = append(order, u)
+		for i := len(g.adj[u]) - 1; i >= 0; i-- {
+			if v := g.adj[u][i].To; !visited[v] {
+				stack = append(stack, v)
+			}
+		}
+	}
+	return order
+}
+
+// Components 返回无向图的所有连通分量，每个分量内按顶点编号升序。
+func (g *Graph) Components() [][]int {
+	seen := make([]bool, g.Len())
+	res := [][]int{}
+	for s := range g.adj {
+		if seen[s] {
+			continue
+		}
+		comp := g.DFS(s)
+		for _, v := range comp {
+			seen[v] = true
+		}
+		slices.Sort(comp)
+		res = append(res, comp)
+	}
+	return res
+}
+
+// TopoSort 用 Kahn 算法返回有向图的一个拓扑序，有环时返回 ErrCycle。
+func (g *Graph) TopoSort() ([]int, error) {
+	n := g.Len()
+	indeg := make([]int, n)
+	for _, es := range g.adj {
+		for _, e := range es {
+			indeg[e.To]++
+		}
+	}
+	order := []int{}
+	for u := 0; u < n; u++ {
+		if indeg[u] == 0 {
+			order = append(order, u)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, e := range g.adj[order[i]] {
+			if indeg[e.To]--; indeg[e.To] == 0 {
+				order = append(order, e.To)
+			}
+		}
+	}
+	if len(order) < n {
+		return nil, ErrCycle
+	}
+	return order, nil
+}
+
+// FindCycle 返回有向图中的一个环（首尾顶点相同），无环时返回 nil。
+// 三色标记：白色未访问，灰色在当前 DFS 栈上，黑色已完成；遇到灰色顶点即找到环。
+func (g *Graph) FindCycle() []int {
+	const (
+		white = iota
+		gray
+		black
+	)
+	n := g.Len()
+	color := make([]int, n)
+	parent := make([]int, n)
+
+	var cycle []int
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		color[u] = gray
+		for _, e := range g.adj[u] {
+			v := e.To
+			if color[v] == gray {
+				cycle = []int{v}
+				for x := u; x != v; x = parent[x] {
+					cycle = append(cycle, x)
+				}
+				cycle = append(cycle, v)
+				slices.Reverse(cycle)
+				return true
+			}
+			if color[v] == white {
+				parent[v] = u
+				if dfs(v) {
+					return true
+				}
+			}
+		}
+		color[u] = black
+		return false
+	}
+	for u := 0; u < n; u++ {
+		if color[u] == white && dfs(u) {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// SCC 用 Tarjan 算法返回有向图的强连通分量，按逆拓扑序排列。
+func (g *Graph) SCC() [][]int {
+	n := g.Len()
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range index {
+		index[i] = -1
+	}
+	stack := []int{}
+	counter := 0
+	res := [][]int{}
+
+	var visit func(u int)
+	visit = func(u int) {
+		index[u], low[u] = counter, counter
+		counter++
+		stack = append(stack, u)
+		onStack[u] = true
+		for _, e := range g.adj[u] {
+			v := e.To
+			if index[v] == -1 {
+				visit(v)
+				low[u] = min(low[u], low[v])
+			} else if onStack[v] {
+				low[u] = min(low[u], index[v])
+			}
+		}
+		if low[u] == index[u] {
+			comp := []int{}
+			for {
+				v := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[v] = false
+				comp = append(comp, v)
+				if v == u {
+					break
+				}
+			}
+			slices.Sort(comp)
+			res = append(res, comp)
+		}
+	}
+	for u := 0; u < n; u++ {
+		if index[u] == -1 {
+			visit(u)
+		}
+	}
+	return res
+}
@@ -17,7 +17,6 @@ func groupAnagrams(strs []string) [][]string {
 			cnt[b-'a']++
 		}
 		mp[cnt] = append(mp[cnt], str)
-		fmt.Println(mp)
 	}
 	ans := make([][]string, 0, len(mp))
 	for _, v := range mp {
@@ -0,0 +1,103 @@
+/**
+ * 区间问题，对应面试经典 150 题的「区间」部分。
+ * 区间使用 LeetCode 的 []int{start, end} 表示，均为闭区间。
+ */
+package interval
+
+import (
+	"slices"
+	"strconv"
+)
+
+func byStart(a, b []int) int {
+	return a[0] - b[0]
+}
+
+/**
+ * 56. 合并区间
+ * https://leetcode.cn/problems/merge-intervals/
+ * 会对 intervals 原地排序。
+ */
+func Merge(intervals [][]int) [][]int {
+	slices.SortFunc(intervals, byStart)
+
+	res := [][]int{}
+	for _, in := range intervals {
+		if n := len(res); n > 0 && in[0] <= res[n-1][1] {
+			res[n-1][1] = max(res[n-1][1], in[1])
+		} else {
+			res = append(res, []int{in[0], in[1]})
+		}
+	}
+	return res
+}
+
+/**
+ * 57. 插入区间
+ * https://leetcode.cn/problems/insert-interval/
+ * intervals 已按起点排序且互不重叠。
+ */
+func Insert(intervals [][]int, newInterval []int) [][]int {
+	left, right := newInterval[0], newInterval[1]
+	res := [][]int{}
+	placed := false
+	for _, in := range intervals {
+		switch {
+		case in[1] < left:
+			res = append(res, []int{in[0], in[1]})
+		case in[0] > right:
+			if !placed {
+				res = append(res, []int{left, right})
+				placed = true
+			}
+			res = append(res, []int{in[0], in[1]})
+		default:
+			left, right = min(left, in[0]), max(right, in[1])
+		}
+	}
+	if !placed {
+		res = append(res, []int{left, right})
+	}
+	return res
+}
+
+/**
+ * 228. 汇总区间
+ * https://leetcode.cn/problems/summary-ranges/
+ */
+func SummaryRanges(nums []int) []string {
+	res := []string{}
+	for i, n := 0, len(nums); i < n; {
+		start := i
+		for i++; i < n && nums[i] == nums[i-1]+1; i++ {
+		}
+		s := strconv.Itoa(nums[start])
+		if start < i-1 {
+			s += "->" + strconv.Itoa(nums[i-1])
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+/**
+ * 452. 用最少数量的箭引爆气球
+ * https://leetcode.cn/problems/minimum-number-of-arrows-to-burst-balloons/
+ * 按右端点排序，每支箭射在当前气球的右端点。会对 points 原地排序。
+ */
+func FindMinArrowShots(points [][]int) int {
+	if len(points) == 0 {
+		return 0
+	}
+	slices.SortFunc(points, func(a, b []int) int { return a[1] - b[1] })
+
+	res := 1
+	arrow := points[0][1]
+	for _, p := range points[1:] {
+		if p[0] > arrow {
+			res++
+			arrow = p[1]
+		}
+	}
+	return res
+}
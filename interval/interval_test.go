@@ -0,0 +1,156 @@
+package interval
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func randIntervals(r *rand.Rand, n, limit int) [][]int {
+	res := make([][]int, n)
+	for i := range res {
+		a, b := r.Intn(limit), r.Intn(limit)
+		res[i] = []int{min(a, b), max(a, b)}
+	}
+	return res
+}
+
+// covered 返回区间覆盖的点，坐标乘 2 以区分 [1,2][3,4] 这样相邻但不重叠的区间。
+func covered(intervals [][]int) map[int]bool {
+	pts := map[int]bool{}
+	for _, in := range intervals {
+		for x := 2 * in[0]; x <= 2*in[1]; x++ {
+			pts[x] = true
+		}
+	}
+	return pts
+}
+
+func clone(intervals [][]int) [][]int {
+	res := make([][]int, len(intervals))
+	for i, in := range intervals {
+		res[i] = slices.Clone(in)
+	}
+	return res
+}
+
+// checkMerged 结果按起点升序、两两不重叠，且覆盖的点与 want 相同。
+func checkMerged(t *testing.T, got [][]int, want map[int]bool) {
+	t.Helper()
+	for i := 1; i < len(got); i++ {
+		if got[i][0] <= got[i-1][1] {
+			t.Fatalf("%v: %v and %v overlap or are out of order", got, got[i-1], got[i])
+		}
+	}
+	pts := covered(got)
+	if len(pts) != len(want) {
+		t.Fatalf("%v covers %d points, want %d", got, len(pts), len(want))
+	}
+	for x := range want {
+		if !pts[x] {
+			t.Fatalf("%v does not cover %v", got, float64(x)/2)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 2000 {
+		in := randIntervals(r, r.Intn(8), 20)
+		checkMerged(t, Merge(clone(in)), covered(in))
+	}
+}
+
+func TestInsert(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 2000 {
+		in := Merge(randIntervals(r, r.Intn(8), 20))
+		add := randIntervals(r, 1, 20)[0]
+		want := covered(append(clone(in), add))
+		checkMerged(t, Insert(clone(in), slices.Clone(add)), want)
+	}
+}
+
+func TestSummaryRanges(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 2000 {
+		nums := []int{}
+		for x := -5; x < 15; x++ {
+			if r.Intn(2) == 0 {
+				nums = append(nums, x)
+			}
+		}
+		got := SummaryRanges(nums)
+		// 展开后应当还原 nums，且相邻两段之间至少空出一个数
+		var expanded []int
+		prevEnd := 0
+		for i, s := range got {
+			lo, hi, ok := strings.Cut(s, "->")
+			a, _ := strconv.Atoi(lo)
+			b := a
+			if ok {
+				b, _ = strconv.Atoi(hi)
+				if b <= a {
+					t.Fatalf("SummaryRanges(%v): bad range %q", nums, s)
+				}
+			}
+			if i > 0 && a <= prevEnd+1 {
+				t.Fatalf("SummaryRanges(%v) = %v: %q should be merged with the previous range", nums, got, s)
+			}
+			for x := a; x <= b; x++ {
+				expanded = append(expanded, x)
+			}
+			prevEnd = b
+		}
+		if !slices.Equal(expanded, nums) {
+			t.Fatalf("SummaryRanges(%v) = %v, expands to %v", nums, got, expanded)
+		}
+	}
+}
+
+// minArrowsBrute 枚举右端点的子集，总存在一个最优解只射在右端点上。
+func minArrowsBrute(points [][]int) int {
+	n := len(points)
+	best := n
+	for mask := 0; mask < 1<<n; mask++ {
+		ok := true
+		for _, p := range points {
+			hit := false
+			for i := range n {
+				if mask>>i&1 == 1 && p[0] <= points[i][1] && points[i][1] <= p[1] {
+					hit = true
+					break
+				}
+			}
+			if !hit {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			best = min(best, popcount(mask))
+		}
+	}
+	return best
+}
+
+func popcount(x int) int {
+	n := 0
+	for ; x > 0; x &= x - 1 {
+		n++
+	}
+	return n
+}
+
+func TestFindMinArrowShots(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for range 2000 {
+		points := randIntervals(r, r.Intn(8), 15)
+		want := minArrowsBrute(points)
+		if got := FindMinArrowShots(clone(points)); got != want {
+			t.Fatalf("FindMinArrowShots(%v) = %d, want %d", points, got, want)
+		}
+	}
+}
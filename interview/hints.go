@@ -0,0 +1,42 @@
+package interview
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadHints 读取 dir/<slug>.md 中的提示，各阶段之间用单独一行的 --- 分隔，由浅入深排列。
+// 文件不存在时返回空。
+func LoadHints(dir, slug string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, slug+".md"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var hints []string
+	var cur []string
+	flush := func() {
+		if s := strings.TrimSpace(strings.Join(cur, "\n")); s != "" {
+			hints = append(hints, s)
+		}
+		cur = cur[:0]
+	}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if strings.TrimSpace(sc.Text()) == "---" {
+			flush()
+			continue
+		}
+		cur = append(cur, sc.Text())
+	}
+	flush()
+	return hints, sc.Err()
+}
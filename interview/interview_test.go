@@ -0,0 +1,92 @@
+package interview
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"leetcode-go/compare"
+	_ "leetcode-go/problems"
+	"leetcode-go/registry"
+)
+
+func TestJudgeable(t *testing.T) {
+	for _, id := range []int{146, 303, 304, 380} {
+		p, ok := registry.Lookup(id)
+		if !ok {
+			t.Fatalf("%d is not registered", id)
+		}
+		if !isDesign(p) || Judgeable(p) {
+			t.Errorf("%d. %s: isDesign = %v, Judgeable = %v", id, p.Title, isDesign(p), Judgeable(p))
+		}
+	}
+	// 返回带方法的指针（链表、树）的不是设计题
+	for _, id := range []int{1, 206, 226} {
+		if p, _ := registry.Lookup(id); isDesign(p) {
+			t.Errorf("%d. %s is reported as a design problem", id, p.Title)
+		}
+	}
+	for _, id := range []int{1, 70, 88} {
+		if p, _ := registry.Lookup(id); !Judgeable(p) {
+			t.Errorf("%d. %s is not judgeable", id, p.Title)
+		}
+	}
+	noCases := &registry.Problem{ID: 99999, Slug: "interview-test", Solution: func(n int) int { return n }}
+	if Judgeable(noCases) {
+		t.Error("a problem without examples or a generator is judgeable")
+	}
+}
+
+func TestPick(t *testing.T) {
+	all := registry.All()
+	r := rand.New(rand.NewSource(1))
+	for range 200 {
+		p, err := Pick(all, Filter{}, nil, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !Judgeable(p) {
+			t.Fatalf("Pick returned %d. %s, which cannot be judged", p.ID, p.Title)
+		}
+		if _, err := Stub(p); err != nil {
+			t.Fatalf("Stub(%d): %v", p.ID, err)
+		}
+	}
+
+	f := Filter{Difficulty: "easy", Topic: "字符串"}
+	exclude := map[int]bool{}
+	for {
+		p, err := Pick(all, f, exclude, r)
+		if errors.Is(err, ErrNoProblem) {
+			break
+		}
+		if err != nil || p.Difficulty != registry.Easy || !f.match(p) || exclude[p.ID] {
+			t.Fatalf("Pick(%+v) = %v, %v", f, p, err)
+		}
+		exclude[p.ID] = true
+	}
+	if len(exclude) == 0 {
+		t.Fatal("no easy string problem was picked")
+	}
+	// 只有设计题符合条件时抽不到题
+	if p, err := Pick(all, Filter{Topic: "设计"}, nil, r); !errors.Is(err, ErrNoProblem) {
+		t.Errorf("Pick(设计) = %d. %s, %v", p.ID, p.Title, err)
+	}
+}
+
+func TestAccepted(t *testing.T) {
+	for _, tc := range []struct {
+		r    Result
+		want bool
+	}{
+		{Result{Verdict: &compare.Verdict{Passed: 3}}, true},
+		{Result{Verdict: &compare.Verdict{Passed: 2, Failures: []compare.Failure{{}}}}, false},
+		{Result{Verdict: &compare.Verdict{}}, false},
+		{Result{Verdict: &compare.Verdict{Skipped: 4}}, false},
+		{Result{Output: "compile error"}, false},
+	} {
+		if got := tc.r.Accepted(); got != tc.want {
+			t.Errorf("%+v: Accepted() = %v, want %v", tc.r, got, tc.want)
+		}
+	}
+}
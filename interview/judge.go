@@ -0,0 +1,135 @@
+package interview
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"leetcode-go/compare"
+	"leetcode-go/registry"
+)
+
+// Result 一次提交的评测结果。没有通过编译或超时时 Verdict 为 nil，Output 是相应的输出。
+type Result struct {
+	Verdict *compare.Verdict `json:",omitempty"`
+	Output  string           `json:",omitempty"`
+}
+
+// Accepted 编译运行成功，且至少通过了一个用例、没有失败的用例（见 compare.Verdict.OK）。
+func (r *Result) Accepted() bool {
+	return r.Verdict != nil && r.Verdict.OK()
+}
+
+// JudgeOptions 评测用的随机用例参数，与 lc compare 相同。
+type JudgeOptions struct {
+	Cases   int
+	MaxN    int
+	Seed    int64
+	Timeout time.Duration // 运行时限，不含编译
+}
+
+// Judge 评测候选人的文件：把它和生成的评测程序放进模块根目录 root 下的临时目录，
+// 编译运行后用 compare 包以题目的 Solution 为准对拍示例和随机用例。
+// 临时目录以 _ 开头，不会被 ./... 匹配到。
+func Judge(root, file string, p *registry.Problem, opts JudgeOptions) (*Result, error) {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), file, src, parser.SkipObjectResolution)
+	if err != nil {
+		return &Result{Output: err.Error()}, nil
+	}
+	if f.Name.Name != "main" {
+		return &Result{Output: "submission must be in package main"}, nil
+	}
+
+	dir, err := os.MkdirTemp(root, "_interview-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	err = judgeTmpl.Execute(&buf, map[string]any{
+		"ID": p.ID, "Func": FuncName(p), "HasMain": hasMain(f),
+		"Cases": opts.Cases, "MaxN": opts.MaxN, "Seed": opts.Seed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "submission.go"), src, 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "judge.go"), buf.Bytes(), 0o644); err != nil {
+		return nil, err
+	}
+
+	bin := filepath.Join(dir, "judge")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = dir
+	if out, err := build.CombinedOutput(); err != nil {
+		return &Result{Output: string(out)}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, bin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err = cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &Result{Output: "time limit exceeded (" + opts.Timeout.String() + ")"}, nil
+	}
+	if err != nil {
+		return &Result{Output: stderr.String()}, nil
+	}
+
+	var v compare.Verdict
+	if err := json.Unmarshal(stdout.Bytes(), &v); err != nil {
+		return &Result{Output: stdout.String() + stderr.String()}, nil
+	}
+	return &Result{Verdict: &v}, nil
+}
+
+func hasMain(f *ast.File) bool {
+	for _, d := range f.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+var judgeTmpl = template.Must(template.New("judge").Parse(`package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"leetcode-go/compare"
+	_ "leetcode-go/problems"
+	"leetcode-go/registry"
+)
+
+// 在 init 中评测后退出，提交的文件里即使有 main 也不会运行
+func init() {
+	p, _ := registry.Lookup({{.ID}})
+	q := *p
+	q.Approaches = []registry.Approach{ {Name: "submission", Func: {{.Func}}} }
+	cases := append(compare.Examples(&q), compare.Random(&q, {{.Cases}}, {{.MaxN}}, {{.Seed}})...)
+	json.NewEncoder(os.Stdout).Encode(compare.Verify(&q, cases)[0])
+	os.Exit(0)
+}
+{{if not .HasMain}}
+func main() {}
+{{end}}`))
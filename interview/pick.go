@@ -0,0 +1,81 @@
+/**
+ * 模拟面试：从 registry 中按难度和标签抽题，隐藏题解，计时，
+ * 候选人提交的文件用 compare 包对拍，提示分阶段给出，全程记录到面试记录中供事后复盘。
+ */
+package interview
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"leetcode-go/compare"
+	"leetcode-go/registry"
+)
+
+// Filter 抽题条件，零值表示不限。
+type Filter struct {
+	Difficulty registry.Difficulty
+	Topic      string // 与题目的某个标签相同即可，不区分大小写
+}
+
+func (f Filter) match(p *registry.Problem) bool {
+	if f.Difficulty != "" && !strings.EqualFold(string(f.Difficulty), string(p.Difficulty)) {
+		return false
+	}
+	if f.Topic == "" {
+		return true
+	}
+	for _, t := range p.Topics {
+		if strings.EqualFold(t, f.Topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrNoProblem 没有符合条件的题目。
+var ErrNoProblem = errors.New("interview: no problem matches")
+
+// Pick 从 problems 中随机抽一道符合条件的题目，exclude 中的题号不会被抽到（例如做过的题）。
+// 无法评测的题目（见 Judgeable）不参与抽题。
+func Pick(problems []*registry.Problem, f Filter, exclude map[int]bool, r *rand.Rand) (*registry.Problem, error) {
+	var cands []*registry.Problem
+	for _, p := range problems {
+		if f.match(p) && !exclude[p.ID] && Judgeable(p) {
+			cands = append(cands, p)
+		}
+	}
+	if len(cands) == 0 {
+		return nil, ErrNoProblem
+	}
+	return cands[r.Intn(len(cands))], nil
+}
+
+// Judgeable 判断题目能否在模拟面试中评测：要有用例（题目目录中的示例或随机生成器），
+// 并且不是设计题。设计题登记的是构造函数，候选人要实现的是一组方法，Stub 生成不了能作答的模板。
+func Judgeable(p *registry.Problem) bool {
+	if isDesign(p) {
+		return false
+	}
+	return p.Gen != nil || len(compare.Examples(p)) > 0
+}
+
+// isDesign 判断 Solution 是否为设计题的构造函数，如 146. LRU 缓存 的 Constructor(capacity int) LRUCache：
+// 按 LeetCode 的写法返回一个结构体，操作是它指针上的方法。
+func isDesign(p *registry.Problem) bool {
+	t := reflect.TypeOf(p.Solution)
+	if t.NumOut() != 1 {
+		return false
+	}
+	out := t.Out(0)
+	return out.Kind() == reflect.Struct && reflect.PointerTo(out).NumMethod() > 0
+}
+
+// Limit 不同难度的默认限时（分钟）。
+var Limit = map[registry.Difficulty]int{
+	registry.Easy:   15,
+	registry.Medium: 30,
+	registry.Hard:   45,
+}
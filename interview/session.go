@@ -0,0 +1,203 @@
+package interview
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"leetcode-go/registry"
+)
+
+// Event 面试记录中的一条事件。
+type Event struct {
+	Kind    string    `json:"kind"` // start、hint、submit、end
+	At      time.Time `json:"at"`
+	Elapsed string    `json:"elapsed"`
+	Hint    int       `json:"hint,omitempty"` // 第几条提示，从 1 开始
+	File    string    `json:"file,omitempty"`
+	Result  *Result   `json:"result,omitempty"`
+	Note    string    `json:"note,omitempty"`
+}
+
+// Transcript 一次面试的完整记录，以 JSON 写入磁盘。
+type Transcript struct {
+	ID         int                 `json:"id"`
+	Slug       string              `json:"slug"`
+	Title      string              `json:"title"`
+	Difficulty registry.Difficulty `json:"difficulty"`
+	Limit      string              `json:"limit"`
+	Started    time.Time           `json:"started"`
+	Ended      time.Time           `json:"ended"`
+	Attempts   int                 `json:"attempts"`
+	HintsUsed  int                 `json:"hintsUsed"`
+	Accepted   bool                `json:"accepted"`
+	Overtime   bool                `json:"overtime"`
+	Events     []Event             `json:"events"`
+}
+
+// Session 一次面试。Judge 评测提交的文件，Now 为空时使用 time.Now。
+type Session struct {
+	Problem    *registry.Problem
+	Hints      []string
+	Limit      time.Duration
+	File       string // 默认提交的文件，即生成的作答模板
+	Transcript string // 面试记录的路径
+	Judge      func(file string) (*Result, error)
+	Now        func() time.Time
+
+	mu    sync.Mutex // 保护 out，计时器在另一个 goroutine 中提示超时
+	out   io.Writer
+	start time.Time
+	log   Transcript
+}
+
+func (s *Session) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now()
+}
+
+func (s *Session) elapsed() time.Duration {
+	return s.now().Sub(s.start).Round(time.Second)
+}
+
+func (s *Session) printf(format string, args ...any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fmt.Fprintf(s.out, format, args...)
+}
+
+const help = `commands:
+  submit [file]  judge the file (default: the generated stub)
+  hint           reveal the next hint
+  time           show elapsed and remaining time
+  quit           end the session
+`
+
+// Run 开始计时，从 in 读取命令直到通过、quit 或输入结束，每条事件之后都会重写面试记录。
+func (s *Session) Run(in io.Reader, out io.Writer) error {
+	s.out = out
+	s.start = s.now()
+	p := s.Problem
+	s.log = Transcript{
+		ID: p.ID, Slug: p.Slug, Title: p.Title, Difficulty: p.Difficulty,
+		Limit: s.Limit.String(), Started: s.start,
+	}
+
+	s.printf("%d. %s [%s]\n%s\n\n", p.ID, p.Title, p.Difficulty, p.URL())
+	s.printf("implement %s in %s, time limit %s, %d hints available\n%s", FuncName(p), s.File, s.Limit, len(s.Hints), help)
+	if err := s.record(Event{Kind: "start", File: s.File}); err != nil {
+		return err
+	}
+	timer := time.AfterFunc(s.Limit, func() {
+		s.printf("\ntime is up (%s)\n> ", s.Limit)
+	})
+	defer timer.Stop()
+
+	sc := bufio.NewScanner(in)
+	for {
+		s.printf("> ")
+		if !sc.Scan() {
+			break
+		}
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "submit":
+			file := s.File
+			if len(fields) > 1 {
+				file = fields[1]
+			}
+			done, err := s.submit(file)
+			if err != nil {
+				return err
+			}
+			if done {
+				return s.end("accepted")
+			}
+		case "hint":
+			if err := s.hint(); err != nil {
+				return err
+			}
+		case "time":
+			d := s.elapsed()
+			if d > s.Limit {
+				s.printf("elapsed %s, %s over the limit\n", d, d-s.Limit)
+			} else {
+				s.printf("elapsed %s, %s left\n", d, s.Limit-d)
+			}
+		case "quit":
+			return s.end("gave up")
+		default:
+			s.printf("%s", help)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return s.end("input closed")
+}
+
+func (s *Session) submit(file string) (bool, error) {
+	s.printf("judging %s ...\n", file)
+	res, err := s.Judge(file)
+	if err != nil {
+		return false, err
+	}
+	s.log.Attempts++
+	switch {
+	case res.Verdict == nil:
+		s.printf("%s\n", strings.TrimSpace(res.Output))
+	case res.Accepted():
+		s.printf("accepted: %d cases in %s\n", res.Verdict.Passed, s.elapsed())
+		s.log.Accepted = true
+	default:
+		s.printf("wrong answer: %d passed, %d failed\n", res.Verdict.Passed, len(res.Verdict.Failures))
+		for _, f := range res.Verdict.Failures {
+			s.printf("  %s: args %s got %s want %s\n", f.Case, f.Args, f.Got, f.Want)
+		}
+	}
+	return res.Accepted(), s.record(Event{Kind: "submit", File: file, Result: res})
+}
+
+func (s *Session) hint() error {
+	if s.log.HintsUsed == len(s.Hints) {
+		s.printf("no more hints\n")
+		return nil
+	}
+	s.log.HintsUsed++
+	s.printf("hint %d/%d:\n%s\n", s.log.HintsUsed, len(s.Hints), s.Hints[s.log.HintsUsed-1])
+	return s.record(Event{Kind: "hint", Hint: s.log.HintsUsed})
+}
+
+func (s *Session) end(note string) error {
+	s.log.Ended = s.now()
+	s.log.Overtime = s.elapsed() > s.Limit
+	if err := s.record(Event{Kind: "end", Note: note}); err != nil {
+		return err
+	}
+	s.printf("%s after %s, %d attempts, %d hints; transcript written to %s\n",
+		note, s.elapsed(), s.log.Attempts, s.log.HintsUsed, s.Transcript)
+	return nil
+}
+
+// record 追加一条事件并重写面试记录，中途退出也不会丢失之前的记录。
+func (s *Session) record(e Event) error {
+	e.At = s.now()
+	e.Elapsed = s.elapsed().String()
+	s.log.Events = append(s.log.Events, e)
+
+	data, err := json.MarshalIndent(s.log, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Transcript, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,118 @@
+package interview
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"reflect"
+	"slices"
+	"strings"
+
+	"leetcode-go/catalog"
+	"leetcode-go/registry"
+)
+
+// FuncName 候选人需要实现的函数名：优先用题目目录中的名字，否则由 slug 转成驼峰，如 climbing-stairs -> climbingStairs。
+func FuncName(p *registry.Problem) string {
+	if cp, err := catalog.Lookup(p.Slug); err == nil {
+		return cp.Func
+	}
+	parts := strings.Split(p.Slug, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// Stub 生成候选人作答的 package main 文件：头部注释、函数签名和返回零值的函数体，不含任何题解。
+func Stub(p *registry.Problem) ([]byte, error) {
+	t := reflect.TypeOf(p.Solution)
+	imports := map[string]bool{}
+
+	names := make([]string, t.NumIn())
+	if cp, err := catalog.Lookup(p.Slug); err == nil && len(cp.Params) == t.NumIn() {
+		for i, pa := range cp.Params {
+			names[i] = pa.Name
+		}
+	} else {
+		for i := range names {
+			names[i] = fmt.Sprintf("arg%d", i)
+		}
+	}
+	params := make([]string, t.NumIn())
+	for i := range params {
+		params[i] = names[i] + " " + typeString(t.In(i), imports)
+	}
+	var results, zeros []string
+	for i := 0; i < t.NumOut(); i++ {
+		results = append(results, typeString(t.Out(i), imports))
+		zeros = append(zeros, zeroValue(t.Out(i)))
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("package main\n\n")
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		buf.WriteString("import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&buf, "\t%q\n", path)
+		}
+		buf.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&buf, "/**\n * %d. %s\n * %s\n */\n", p.ID, p.Title, p.URL())
+	fmt.Fprintf(&buf, "func %s(%s)", FuncName(p), strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		buf.WriteString(" " + results[0])
+	default:
+		buf.WriteString(" (" + strings.Join(results, ", ") + ")")
+	}
+	buf.WriteString(" {\n")
+	if len(zeros) > 0 {
+		buf.WriteString("\treturn " + strings.Join(zeros, ", ") + "\n")
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
+
+// typeString 返回类型在 package main 中的写法，用到的包记入 imports。
+func typeString(t reflect.Type, imports map[string]bool) string {
+	if t.Name() != "" {
+		if t.PkgPath() != "" {
+			imports[t.PkgPath()] = true
+		}
+		return t.String()
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[]" + typeString(t.Elem(), imports)
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeString(t.Elem(), imports))
+	case reflect.Pointer:
+		return "*" + typeString(t.Elem(), imports)
+	case reflect.Map:
+		return "map[" + typeString(t.Key(), imports) + "]" + typeString(t.Elem(), imports)
+	}
+	return t.String()
+}
+
+func zeroValue(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return `""`
+	case reflect.Bool:
+		return "false"
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Func, reflect.Interface, reflect.Chan:
+		return "nil"
+	case reflect.Struct, reflect.Array:
+		return t.String() + "{}"
+	}
+	return "0"
+}
@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"cmp"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Diagnostic 一条检查结果。
+type Diagnostic struct {
+	Analyzer string
+	Pos      token.Position
+	Message  string
+}
+
+func (d Diagnostic) String() string {
+	return d.Pos.String() + ": " + d.Message + " (" + d.Analyzer + ")"
+}
+
+// Run 检查 patterns 指定的目录，"dir/..." 表示递归检查 dir 下的所有目录。
+//
+// 本仓库根目录下每个文件都是一个可以单独 go run 的 main 包，整个目录无法作为一个包通过类型检查，
+// 所以这里不用 go/packages，而是自己驱动分析：多个文件都有 main 函数时逐个文件做类型检查，
+// 类型错误只会让类型信息不完整，不会中止检查。dupdecl 仍然针对整个目录运行。
+func Run(patterns []string, analyzers []*analysis.Analyzer) ([]Diagnostic, error) {
+	if err := analysis.Validate(analyzers); err != nil {
+		return nil, err
+	}
+	dirs, err := expand(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	res := []Diagnostic{}
+	for _, dir := range dirs {
+		groups, err := parseDir(fset, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, files := range groups {
+			for _, unit := range units(files) {
+				pkg, info := typeCheck(fset, imp, unit)
+				for _, a := range analyzers {
+					if a != DupDecl {
+						res = append(res, runAnalyzer(a, fset, unit, pkg, info)...)
+					}
+				}
+			}
+			if slices.Contains(analyzers, DupDecl) {
+				res = append(res, runAnalyzer(DupDecl, fset, files, nil, nil)...)
+			}
+		}
+	}
+
+	slices.SortFunc(res, func(a, b Diagnostic) int {
+		return cmp.Or(
+			cmp.Compare(a.Pos.Filename, b.Pos.Filename),
+			cmp.Compare(a.Pos.Line, b.Pos.Line),
+			cmp.Compare(a.Pos.Column, b.Pos.Column),
+			cmp.Compare(a.Analyzer, b.Analyzer),
+		)
+	})
+	return res, nil
+}
+
+func expand(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	dirs := []string{}
+	for _, p := range patterns {
+		root, recursive := strings.CutSuffix(p, "...")
+		if !recursive {
+			dirs = append(dirs, filepath.Clean(p))
+			continue
+		}
+		root = filepath.Clean(cmp.Or(root, "."))
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor") {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// parseDir 解析目录下的非测试 Go 文件，按包名分组。
+func parseDir(fset *token.FileSet, dir string) (map[string][]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	groups := map[string][]*ast.File{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		groups[f.Name.Name] = append(groups[f.Name.Name], f)
+	}
+	return groups, nil
+}
+
+// units 把一个包拆成类型检查单元：多个文件各自声明 main 时每个文件单独一个单元。
+func units(files []*ast.File) [][]*ast.File {
+	mains := 0
+	for _, f := range files {
+		if hasMain(f) {
+			mains++
+		}
+	}
+	if mains <= 1 {
+		return [][]*ast.File{files}
+	}
+	res := make([][]*ast.File, len(files))
+	for i, f := range files {
+		res[i] = []*ast.File{f}
+	}
+	return res
+}
+
+func hasMain(f *ast.File) bool {
+	for _, decl := range f.Decls {
+		if fd, ok := decl.(*ast.FuncDecl); ok && isMain(fd) && fd.Name.Name == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+func typeCheck(fset *token.FileSet, imp types.Importer, files []*ast.File) (*types.Package, *types.Info) {
+	conf := types.Config{
+		Importer: imp,
+		Error:    func(error) {}, // 类型错误不影响检查
+	}
+	info := &types.Info{
+		Types:      map[ast.Expr]types.TypeAndValue{},
+		Defs:       map[*ast.Ident]types.Object{},
+		Uses:       map[*ast.Ident]types.Object{},
+		Selections: map[*ast.SelectorExpr]*types.Selection{},
+		Scopes:     map[ast.Node]*types.Scope{},
+	}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+	return pkg, info
+}
+
+func runAnalyzer(a *analysis.Analyzer, fset *token.FileSet, files []*ast.File, pkg *types.Package, info *types.Info) []Diagnostic {
+	res := []Diagnostic{}
+	pass := &analysis.Pass{
+		Analyzer:   a,
+		Fset:       fset,
+		Files:      files,
+		Pkg:        pkg,
+		TypesInfo:  info,
+		TypesSizes: types.SizesFor("gc", "amd64"),
+		ResultOf:   map[*analysis.Analyzer]any{},
+		ReadFile:   os.ReadFile,
+		Report: func(d analysis.Diagnostic) {
+			res = append(res, Diagnostic{a.Name, fset.Position(d.Pos), d.Message})
+		},
+	}
+	if _, err := a.Run(pass); err != nil {
+		res = append(res, Diagnostic{a.Name, fset.Position(files[0].Package), err.Error()})
+	}
+	return res
+}
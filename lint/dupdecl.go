@@ -0,0 +1,57 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var DupDecl = &analysis.Analyzer{
+	Name:             "dupdecl",
+	Doc:              "report top-level identifiers declared in more than one file of the same package",
+	Run:              runDupDecl,
+	RunDespiteErrors: true,
+}
+
+// runDupDecl 只看语法，不依赖类型检查：重复声明本身就会让类型检查失败。
+// main 和 init 每个文件各有一个是本仓库的惯例（每个文件单独 go run），不算重复。
+func runDupDecl(pass *analysis.Pass) (any, error) {
+	first := map[string]token.Pos{}
+	check := func(id *ast.Ident) {
+		switch id.Name {
+		case "_", "main", "init":
+			return
+		}
+		if prev, ok := first[id.Name]; ok {
+			if pass.Fset.File(prev) != pass.Fset.File(id.Pos()) {
+				pass.Reportf(id.Pos(), "%s is also declared at %s", id.Name, pass.Fset.Position(prev))
+			}
+			return
+		}
+		first[id.Name] = id.Pos()
+	}
+
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				if d.Recv == nil {
+					check(d.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					switch s := spec.(type) {
+					case *ast.ValueSpec:
+						for _, name := range s.Names {
+							check(name)
+						}
+					case *ast.TypeSpec:
+						check(s.Name)
+					}
+				}
+			}
+		}
+	}
+	return nil, nil
+}
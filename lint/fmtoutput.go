@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var FmtOutput = &analysis.Analyzer{
+	Name:             "fmtoutput",
+	Doc:              "report fmt.Print* and print/println calls inside solution functions",
+	Run:              runFmtOutput,
+	RunDespiteErrors: true,
+}
+
+var printFuncs = map[string]bool{"Print": true, "Printf": true, "Println": true}
+
+func runFmtOutput(pass *analysis.Pass) (any, error) {
+	for _, f := range pass.Files {
+		if !isSolutionFile(pass, f) {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil || isMain(fd) {
+				continue
+			}
+			ast.Inspect(fd.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				if pkg, name := pkgFunc(pass.TypesInfo, call); pkg == "fmt" && printFuncs[name] {
+					pass.Reportf(call.Pos(), "fmt.%s in %s: print from main instead of the solution", name, fd.Name.Name)
+				} else if isBuiltin(pass.TypesInfo, call, "print") || isBuiltin(pass.TypesInfo, call, "println") {
+					pass.Reportf(call.Pos(), "builtin output in %s: print from main instead of the solution", fd.Name.Name)
+				}
+				return true
+			})
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package lint
+
+import (
+	"regexp"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var Header = &analysis.Analyzer{
+	Name:             "header",
+	Doc:              "report solution files without a header comment giving the problem number and LeetCode URL",
+	Run:              runHeader,
+	RunDespiteErrors: true,
+}
+
+var (
+	problemURL    = regexp.MustCompile(`https?://leetcode\.(cn|com)/problems/[\w-]+`)
+	problemNumber = regexp.MustCompile(`(^|\s)\d+\.\s`)
+	anyURL        = regexp.MustCompile(`https?://`)
+)
+
+// runHeader 只检查 package main 的题解文件，库包中的题目写在函数注释里，不做要求。
+func runHeader(pass *analysis.Pass) (any, error) {
+	for _, f := range pass.Files {
+		if f.Name.Name != "main" || !isSolutionFile(pass, f) {
+			continue
+		}
+		hasURL, hasNumber := false, false
+		for _, cg := range f.Comments {
+			if text := cg.Text(); problemURL.MatchString(text) {
+				hasURL = true
+				hasNumber = hasNumber || problemNumber.MatchString(text)
+			}
+		}
+		switch {
+		case hasURL && !hasNumber:
+			pass.Reportf(f.Package, "header comment has no problem number, e.g. \"135. 分发糖果\"")
+		case !hasURL:
+			pass.Reportf(f.Package, "missing header comment with problem number and https://leetcode.cn/problems/ URL")
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,89 @@
+/**
+ * 题解质量检查，基于 golang.org/x/tools/go/analysis，通过 `lc vet` 运行：
+ *
+ *	fmtoutput     解题函数中的 fmt 输出（调试打印应在 main 中）
+ *	shadowbuiltin 遮蔽内置标识符，如 len := len(nums)
+ *	header        题解文件缺少带题号和链接的头部注释
+ *	mutation      修改了调用方传入的切片或 map，但注释中没有说明
+ *	dupdecl       同一个包的不同文件中重复声明的顶层标识符
+ */
+package lint
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Analyzers 所有检查项，按名称排序。
+var Analyzers = []*analysis.Analyzer{
+	DupDecl,
+	FmtOutput,
+	Header,
+	Mutation,
+	ShadowBuiltin,
+}
+
+// isMain 判断函数是否为 main 或 init，这两个函数不算解题函数。
+func isMain(fd *ast.FuncDecl) bool {
+	return fd.Recv == nil && (fd.Name.Name == "main" || fd.Name.Name == "init")
+}
+
+// isSolutionFile 判断 pass 中的文件是否需要按题解的要求检查。
+// 非 main 包都按库代码检查；main 包中，cmd 目录下的命令和头部注释引用了
+// 非 LeetCode 网址的示例（如设计模式）都不是题解。
+func isSolutionFile(pass *analysis.Pass, f *ast.File) bool {
+	if f.Name.Name != "main" {
+		return true
+	}
+	name := filepath.ToSlash(pass.Fset.File(f.Pos()).Name())
+	if strings.Contains(name, "/cmd/") || strings.HasPrefix(name, "cmd/") {
+		return false
+	}
+	for _, cg := range f.Comments {
+		if text := cg.Text(); anyURL.MatchString(text) && !problemURL.MatchString(text) {
+			return false
+		}
+	}
+	return true
+}
+
+// pkgFunc 返回调用表达式调用的包级函数的包路径和函数名，不是包级函数时返回空串。
+// 没有类型信息时按语法猜测，例如 fmt.Println。
+func pkgFunc(info *types.Info, call *ast.CallExpr) (string, string) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+	if info != nil {
+		if pn, ok := info.Uses[x].(*types.PkgName); ok {
+			return pn.Imported().Path(), sel.Sel.Name
+		}
+		if info.Uses[x] != nil {
+			return "", ""
+		}
+	}
+	return x.Name, sel.Sel.Name
+}
+
+// isBuiltin 判断调用的是否为内置函数 name。
+func isBuiltin(info *types.Info, call *ast.CallExpr, name string) bool {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || id.Name != name {
+		return false
+	}
+	if info != nil {
+		if obj := info.Uses[id]; obj != nil {
+			_, ok := obj.(*types.Builtin)
+			return ok
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package lint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzers(t *testing.T) {
+	for _, tc := range []struct {
+		analyzer *analysis.Analyzer
+		patterns []string
+	}{
+		{DupDecl, []string{"dupdecl"}},
+		{FmtOutput, []string{"fmtoutput"}},
+		{Header, []string{"header/..."}},
+		{Mutation, []string{"mutation"}},
+		{ShadowBuiltin, []string{"shadowbuiltin"}},
+	} {
+		t.Run(tc.analyzer.Name, func(t *testing.T) {
+			analysistest.Run(t, analysistest.TestData(), tc.analyzer, tc.patterns...)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"regexp"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var Mutation = &analysis.Analyzer{
+	Name:             "mutation",
+	Doc:              "report functions that modify a slice or map parameter without documenting it",
+	Run:              runMutation,
+	RunDespiteErrors: true,
+}
+
+// mutationDoc 注释中出现这些词即视为已说明会修改入参。
+var mutationDoc = regexp.MustCompile(`(?i)原地|修改|改成|排序|in[- ]place|mutat|modif`)
+
+// mutatingCalls 会修改第一个参数的标准库函数。
+var mutatingCalls = map[string]map[string]bool{
+	"sort":   {"Ints": true, "Strings": true, "Float64s": true, "Slice": true, "SliceStable": true, "Sort": true, "Stable": true},
+	"slices": {"Sort": true, "SortFunc": true, "SortStableFunc": true, "Reverse": true},
+}
+
+func runMutation(pass *analysis.Pass) (any, error) {
+	if pass.TypesInfo == nil {
+		return nil, nil
+	}
+	for _, f := range pass.Files {
+		if !isSolutionFile(pass, f) {
+			continue
+		}
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Body == nil || isMain(fd) {
+				continue
+			}
+			// 库包只检查导出的函数，内部辅助函数修改入参是实现细节
+			if f.Name.Name != "main" && !fd.Name.IsExported() {
+				continue
+			}
+			if fd.Doc != nil && mutationDoc.MatchString(fd.Doc.Text()) {
+				continue
+			}
+			params := mutableParams(pass.TypesInfo, fd)
+			if len(params) == 0 {
+				continue
+			}
+			if pos, name, ok := findMutation(pass.TypesInfo, fd.Body, params); ok {
+				pass.Reportf(pos, "%s modifies its parameter %s; say so in its doc comment", fd.Name.Name, name)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// mutableParams 返回切片和 map 类型的参数。
+func mutableParams(info *types.Info, fd *ast.FuncDecl) map[types.Object]bool {
+	res := map[types.Object]bool{}
+	for _, field := range fd.Type.Params.List {
+		for _, name := range field.Names {
+			obj := info.Defs[name]
+			if obj == nil {
+				continue
+			}
+			switch obj.Type().Underlying().(type) {
+			case *types.Slice, *types.Map:
+				res[obj] = true
+			}
+		}
+	}
+	return res
+}
+
+// baseParam 剥掉下标和切片表达式，返回最底层引用的参数，如 nums[i][j] 中的 nums。
+func baseParam(info *types.Info, e ast.Expr, params map[types.Object]bool) (types.Object, bool) {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.IndexExpr:
+			e = x.X
+		case *ast.SliceExpr:
+			e = x.X
+		case *ast.Ident:
+			obj := info.Uses[x]
+			return obj, params[obj]
+		default:
+			return nil, false
+		}
+	}
+}
+
+// findMutation 返回函数体中第一处修改参数的位置。
+// 参数被重新赋值（如 nums = nums[1:]）之后的写入仍按修改入参处理，这是一个保守的检查。
+func findMutation(info *types.Info, body *ast.BlockStmt, params map[types.Object]bool) (token.Pos, string, bool) {
+	var pos token.Pos
+	var name string
+	written := func(e ast.Expr) bool {
+		if _, isIdent := ast.Unparen(e).(*ast.Ident); isIdent {
+			return false
+		}
+		if obj, ok := baseParam(info, e, params); ok {
+			pos, name = e.Pos(), obj.Name()
+			return true
+		}
+		return false
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if pos.IsValid() {
+			return false
+		}
+		switch s := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range s.Lhs {
+				if written(lhs) {
+					return false
+				}
+			}
+		case *ast.IncDecStmt:
+			written(s.X)
+		case *ast.CallExpr:
+			if len(s.Args) == 0 {
+				return true
+			}
+			pkg, fn := pkgFunc(info, s)
+			if mutatingCalls[pkg][fn] || isBuiltin(info, s, "copy") || isBuiltin(info, s, "clear") || isBuiltin(info, s, "delete") {
+				if obj, ok := baseParam(info, s.Args[0], params); ok {
+					pos, name = s.Pos(), obj.Name()
+				}
+			}
+		}
+		return true
+	})
+	return pos, name, pos.IsValid()
+}
@@ -0,0 +1,34 @@
+package lint
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var ShadowBuiltin = &analysis.Analyzer{
+	Name:             "shadowbuiltin",
+	Doc:              "report declarations that shadow predeclared identifiers such as len, max or string",
+	Run:              runShadowBuiltin,
+	RunDespiteErrors: true,
+}
+
+func runShadowBuiltin(pass *analysis.Pass) (any, error) {
+	if pass.TypesInfo == nil {
+		return nil, nil
+	}
+	for id, obj := range pass.TypesInfo.Defs {
+		if obj == nil || types.Universe.Lookup(id.Name) == nil {
+			continue
+		}
+		// 字段和方法通过选择器访问，不会遮蔽内置标识符
+		if v, ok := obj.(*types.Var); ok && v.IsField() {
+			continue
+		}
+		if f, ok := obj.(*types.Func); ok && f.Type().(*types.Signature).Recv() != nil {
+			continue
+		}
+		pass.Reportf(id.Pos(), "%s shadows the predeclared identifier %s", id.Name, id.Name)
+	}
+	return nil, nil
+}
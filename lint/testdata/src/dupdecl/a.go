@@ -0,0 +1,11 @@
+package dupdecl
+
+func helper() int { return 1 }
+
+var limit = 10
+
+type pair struct{ a, b int }
+
+func (pair) sum() int { return 0 }
+
+func main() {}
@@ -0,0 +1,15 @@
+package dupdecl
+
+func helper() int { return 2 } // want `helper is also declared at .*a\.go:3:6`
+
+const limit = 20 // want `limit is also declared at .*a\.go:5:5`
+
+// 不同类型的同名方法不算重复，每个文件各有一个 main 也不算
+type other struct{}
+
+func (other) sum() int { return 0 }
+
+func main() {}
+
+var _ = 1
+var _ = 2
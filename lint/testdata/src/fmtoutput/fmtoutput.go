@@ -0,0 +1,30 @@
+/**
+ * 1. 两数之和
+ * https://leetcode.cn/problems/two-sum/
+ */
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func twoSum(nums []int, target int) []int {
+	fmt.Println(nums) // want `fmt.Println in twoSum: print from main instead of the solution`
+	for i := range nums {
+		fmt.Printf("%d\n", i) // want `fmt.Printf in twoSum`
+		println(i)            // want `builtin output in twoSum`
+	}
+	return nil
+}
+
+// format 格式化输出不算打印。
+func format(nums []int) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, nums)
+	return fmt.Sprint(nums) + b.String()
+}
+
+func main() {
+	fmt.Println(twoSum([]int{2, 7}, 9), format(nil))
+}
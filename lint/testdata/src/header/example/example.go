@@ -0,0 +1,7 @@
+/**
+ * 单例模式，引用了其他网址的示例不是题解
+ * https://refactoringguru.cn/design-patterns/singleton
+ */
+package main
+
+func main() {}
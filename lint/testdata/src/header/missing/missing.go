@@ -0,0 +1,4 @@
+// 没有题目链接
+package main // want `missing header comment with problem number`
+
+func main() {}
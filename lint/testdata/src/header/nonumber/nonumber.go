@@ -0,0 +1,7 @@
+/**
+ * 分发糖果
+ * https://leetcode.cn/problems/candy/
+ */
+package main // want `header comment has no problem number`
+
+func main() {}
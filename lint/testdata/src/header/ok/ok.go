@@ -0,0 +1,7 @@
+/**
+ * 135. 分发糖果
+ * https://leetcode.cn/problems/candy/
+ */
+package main
+
+func main() {}
@@ -0,0 +1,51 @@
+/**
+ * 75. 颜色分类
+ * https://leetcode.cn/problems/sort-colors/
+ */
+package main
+
+import "sort"
+
+func sortColors(nums []int) {
+	nums[0] = 0 // want `sortColors modifies its parameter nums; say so in its doc comment`
+}
+
+// sortColors2 原地排序。
+func sortColors2(nums []int) {
+	sort.Ints(nums)
+}
+
+func sortGrid(grid [][]int) {
+	grid[1][2]++ // want `sortGrid modifies its parameter grid`
+}
+
+func count(m map[string]int, words []string) {
+	delete(m, "") // want `count modifies its parameter m`
+	for _, w := range words {
+		m[w]++
+	}
+}
+
+func reversed(nums []int) []int {
+	res := make([]int, len(nums))
+	copy(res, nums)
+	nums = nums[1:]
+	for i := range res {
+		res[i] = -res[i]
+	}
+	return res
+}
+
+func sorted(nums []int) []int {
+	sort.Ints(nums) // want `sorted modifies its parameter nums`
+	return nums
+}
+
+func main() {
+	sortColors(nil)
+	sortColors2(nil)
+	sortGrid(nil)
+	count(nil, nil)
+	reversed(nil)
+	sorted(nil)
+}
@@ -0,0 +1,23 @@
+package shadowbuiltin
+
+type node struct {
+	len  int // 字段不算
+	next *node
+}
+
+func (n *node) cap() int { return n.len } // 方法不算
+
+func walk(n *node, max int) int { // want `max shadows the predeclared identifier max`
+	len := 0 // want `len shadows the predeclared identifier len`
+	for ; n != nil && len < max; n = n.next {
+		len++
+	}
+	return len
+}
+
+func convert(s []byte) string {
+	string := string(s) // want `string shadows the predeclared identifier string`
+	return string
+}
+
+type error struct{} // want `error shadows the predeclared identifier error`
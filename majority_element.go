@@ -15,9 +15,10 @@ func main() {
 	fmt.Println(majorityElement(nums))
 }
 
+// majorityElement 会对 nums 原地排序，排序后中间位置一定是多数元素
 func majorityElement(nums []int) int {
 
-	len := len(nums)
+	n := len(nums)
 	sort.Ints(nums)
-	return nums[len/2]
+	return nums[n/2]
 }
@@ -0,0 +1,143 @@
+package multiset
+
+// Bytes 固定字母表（全部 256 个字节）的多重集合，用数组计数，零值可用。
+type Bytes struct {
+	counts [256]int
+	size   int
+}
+
+// FromBytes 统计字符串中每个字节出现的次数。
+func FromBytes(s string) *Bytes {
+	b := &Bytes{}
+	for i := 0; i < len(s); i++ {
+		b.counts[s[i]]++
+	}
+	b.size = len(s)
+	return b
+}
+
+func (b *Bytes) Add(c byte) {
+	b.counts[c]++
+	b.size++
+}
+
+func (b *Bytes) Remove(c byte) bool {
+	if b.counts[c] == 0 {
+		return false
+	}
+	b.counts[c]--
+	b.size--
+	return true
+}
+
+func (b *Bytes) Count(c byte) int {
+	return b.counts[c]
+}
+
+func (b *Bytes) Len() int {
+	return b.size
+}
+
+func (b *Bytes) IsSubMultiset(other *Bytes) bool {
+	if b.size > other.size {
+		return false
+	}
+	for c, n := range b.counts {
+		if other.counts[c] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Bytes) Equal(other *Bytes) bool {
+	return b.counts == other.counts
+}
+
+// Runes 字母表为 [lo, hi] 区间的 rune 多重集合，区间内用切片计数，区间外退化为 map。
+type Runes struct {
+	lo, hi rune
+	counts []int
+	extra  map[rune]int
+	size   int
+}
+
+// NewRunes 创建字母表为 [lo, hi] 的多重集合，例如小写字母为 NewRunes('a', 'z')。
+func NewRunes(lo, hi rune) *Runes {
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	return &Runes{lo: lo, hi: hi, counts: make([]int, hi-lo+1)}
+}
+
+// FromRunes 在字母表 [lo, hi] 上统计字符串中每个字符出现的次数。
+func FromRunes(s string, lo, hi rune) *Runes {
+	r := NewRunes(lo, hi)
+	for _, c := range s {
+		r.Add(c)
+	}
+	return r
+}
+
+func (r *Runes) Add(c rune) {
+	if c >= r.lo && c <= r.hi {
+		r.counts[c-r.lo]++
+	} else {
+		if r.extra == nil {
+			r.extra = map[rune]int{}
+		}
+		r.extra[c]++
+	}
+	r.size++
+}
+
+func (r *Runes) Remove(c rune) bool {
+	if c >= r.lo && c <= r.hi {
+		if r.counts[c-r.lo] == 0 {
+			return false
+		}
+		r.counts[c-r.lo]--
+	} else {
+		if r.extra[c] == 0 {
+			return false
+		}
+		if r.extra[c]--; r.extra[c] == 0 {
+			delete(r.extra, c)
+		}
+	}
+	r.size--
+	return true
+}
+
+func (r *Runes) Count(c rune) int {
+	if c >= r.lo && c <= r.hi {
+		return r.counts[c-r.lo]
+	}
+	return r.extra[c]
+}
+
+func (r *Runes) Len() int {
+	return r.size
+}
+
+// IsSubMultiset 判断 r 是否为 other 的子多重集合，两者字母表可以不同。
+func (r *Runes) IsSubMultiset(other *Runes) bool {
+	if r.size > other.size {
+		return false
+	}
+	for i, n := range r.counts {
+		if n > 0 && other.Count(r.lo+rune(i)) < n {
+			return false
+		}
+	}
+	for c, n := range r.extra {
+		if other.Count(c) < n {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runes) Equal(other *Runes) bool {
+	return r.size == other.size && r.IsSubMultiset(other)
+}
@@ -0,0 +1,142 @@
+/**
+ * 多重集合：允许元素重复出现的集合，记录每个元素出现的次数。
+ * 383. 赎金信、242. 有效的字母异位词、567. 字符串的排列 都是在比较两个多重集合。
+ */
+package multiset
+
+import (
+	"iter"
+	"maps"
+)
+
+// Multiset 基于 map 的泛型多重集合，零值不可用，需通过 New 创建。
+type Multiset[T comparable] struct {
+	counts map[T]int
+	size   int
+}
+
+func New[T comparable]() *Multiset[T] {
+	return &Multiset[T]{counts: map[T]int{}}
+}
+
+// Of 用给定元素创建多重集合。
+func Of[T comparable](items ...T) *Multiset[T] {
+	m := New[T]()
+	for _, v := range items {
+		m.Add(v)
+	}
+	return m
+}
+
+func (m *Multiset[T]) Add(v T) {
+	m.AddN(v, 1)
+}
+
+// AddN 增加 n 个 v，n <= 0 时不做任何事。
+func (m *Multiset[T]) AddN(v T, n int) {
+	if n <= 0 {
+		return
+	}
+	m.counts[v] += n
+	m.size += n
+}
+
+// Remove 删除一个 v，集合中没有 v 时返回 false。
+func (m *Multiset[T]) Remove(v T) bool {
+	return m.RemoveN(v, 1) == 1
+}
+
+// RemoveN 最多删除 n 个 v，返回实际删除的个数。
+func (m *Multiset[T]) RemoveN(v T, n int) int {
+	c := m.counts[v]
+	if n <= 0 || c == 0 {
+		return 0
+	}
+	n = min(n, c)
+	if c == n {
+		delete(m.counts, v)
+	} else {
+		m.counts[v] = c - n
+	}
+	m.size -= n
+	return n
+}
+
+func (m *Multiset[T]) Count(v T) int {
+	return m.counts[v]
+}
+
+// Len 返回元素总数（重复元素分别计数）。
+func (m *Multiset[T]) Len() int {
+	return m.size
+}
+
+// Distinct 返回不同元素的个数。
+func (m *Multiset[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// All 遍历每个不同的元素及其出现次数，顺序不确定。
+func (m *Multiset[T]) All() iter.Seq2[T, int] {
+	return maps.All(m.counts)
+}
+
+func (m *Multiset[T]) Clone() *Multiset[T] {
+	return &Multiset[T]{counts: maps.Clone(m.counts), size: m.size}
+}
+
+// IsSubMultiset 判断 m 中每个元素的次数都不超过 other 中的次数。
+func (m *Multiset[T]) IsSubMultiset(other *Multiset[T]) bool {
+	if m.size > other.size {
+		return false
+	}
+	for v, c := range m.counts {
+		if other.counts[v] < c {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 判断两个多重集合完全相同。
+func (m *Multiset[T]) Equal(other *Multiset[T]) bool {
+	return m.size == other.size && m.IsSubMultiset(other)
+}
+
+// Union 返回并集，每个元素取两边次数的较大值。
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	res := m.Clone()
+	for v, c := range other.counts {
+		if c > res.counts[v] {
+			res.AddN(v, c-res.counts[v])
+		}
+	}
+	return res
+}
+
+// Intersection 返回交集，每个元素取两边次数的较小值。
+func (m *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	res := New[T]()
+	for v, c := range m.counts {
+		res.AddN(v, min(c, other.counts[v]))
+	}
+	return res
+}
+
+// Difference 返回差集，每个元素的次数为 m 中次数减去 other 中次数，不足时为 0。
+func (m *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	res := New[T]()
+	for v, c := range m.counts {
+		res.AddN(v, c-other.counts[v])
+	}
+	return res
+}
+
+// Sum 返回和，每个元素的次数相加。
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	res := m.Clone()
+	for v, c := range other.counts {
+		res.AddN(v, c)
+	}
+	return res
+}
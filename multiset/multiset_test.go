@@ -0,0 +1,208 @@
+package multiset
+
+import (
+	"errors"
+	"io"
+	"maps"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// counts 用 map 计数，作为各种多重集合的参照。
+func counts(s string) map[rune]int {
+	m := map[rune]int{}
+	for _, c := range s {
+		m[c]++
+	}
+	return m
+}
+
+func subset(a, b map[rune]int) bool {
+	for c, n := range a {
+		if b[c] < n {
+			return false
+		}
+	}
+	return true
+}
+
+func randString(r *rand.Rand, alphabet string, n int) string {
+	letters := []rune(alphabet)
+	var b strings.Builder
+	for range r.Intn(n + 1) {
+		b.WriteRune(letters[r.Intn(len(letters))])
+	}
+	return b.String()
+}
+
+// TestMultiset 随机增删，每一步与 map 计数比较。
+func TestMultiset(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int]()
+	want := map[int]int{}
+	size := 0
+	for range 5000 {
+		v, n := r.Intn(10), r.Intn(4)-1
+		if r.Intn(2) == 0 {
+			m.AddN(v, n)
+			if n > 0 {
+				want[v] += n
+				size += n
+			}
+		} else {
+			got := m.RemoveN(v, n)
+			removed := min(max(n, 0), want[v])
+			if got != removed {
+				t.Fatalf("RemoveN(%d, %d) = %d, want %d", v, n, got, removed)
+			}
+			if want[v] -= removed; want[v] == 0 {
+				delete(want, v)
+			}
+			size -= removed
+		}
+		if m.Len() != size || m.Distinct() != len(want) || m.Count(v) != want[v] {
+			t.Fatalf("Len = %d, Distinct = %d, Count(%d) = %d, want %d, %d, %d",
+				m.Len(), m.Distinct(), v, m.Count(v), size, len(want), want[v])
+		}
+	}
+	if got := maps.Collect(m.All()); !maps.Equal(got, want) {
+		t.Fatalf("All() = %v, want %v", got, want)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := Of(1, 1, 1, 2, 3), Of(1, 2, 2, 4)
+	for _, tc := range []struct {
+		name string
+		got  *Multiset[int]
+		want *Multiset[int]
+	}{
+		{"union", a.Union(b), Of(1, 1, 1, 2, 2, 3, 4)},
+		{"intersection", a.Intersection(b), Of(1, 2)},
+		{"difference", a.Difference(b), Of(1, 1, 3)},
+		{"sum", a.Sum(b), Of(1, 1, 1, 1, 2, 2, 2, 3, 4)},
+	} {
+		if !tc.got.Equal(tc.want) {
+			t.Errorf("%s = %v, want %v", tc.name, maps.Collect(tc.got.All()), maps.Collect(tc.want.All()))
+		}
+	}
+	// 运算不修改操作数
+	if !a.Equal(Of(1, 1, 1, 2, 3)) || !b.Equal(Of(1, 2, 2, 4)) {
+		t.Fatal("operands were modified")
+	}
+	if !Of(1, 2).IsSubMultiset(a) || Of(1, 1, 1, 1).IsSubMultiset(a) || a.Equal(Of(1, 1, 2, 3, 3)) {
+		t.Fatal("IsSubMultiset or Equal is wrong")
+	}
+	c := a.Clone()
+	c.Remove(1)
+	if a.Count(1) != 3 {
+		t.Fatal("Clone shares counts with the original")
+	}
+}
+
+// TestAlphabets Bytes 和 Runes 的判断与 map 计数一致，Runes 字母表外的字符也要计入。
+func TestAlphabets(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 2000 {
+		s, u := randString(r, "abcz{é", 8), randString(r, "abcz{é", 8)
+		cs, cu := counts(s), counts(u)
+		wantSub, wantEq := subset(cs, cu), maps.Equal(cs, cu)
+
+		rs, ru := FromRunes(s, 'a', 'z'), FromRunes(u, 'b', 'c')
+		if rs.IsSubMultiset(ru) != wantSub || rs.Equal(ru) != wantEq {
+			t.Fatalf("Runes(%q, %q): IsSubMultiset = %v, Equal = %v", s, u, rs.IsSubMultiset(ru), rs.Equal(ru))
+		}
+		if rs.Count('é') != cs['é'] || rs.Len() != len([]rune(s)) {
+			t.Fatalf("Runes(%q): Count('é') = %d, Len = %d", s, rs.Count('é'), rs.Len())
+		}
+		bs, bu := FromBytes(s), FromBytes(u)
+		if bs.IsSubMultiset(bu) != wantSub || bs.Equal(bu) != wantEq {
+			t.Fatalf("Bytes(%q, %q): IsSubMultiset = %v, Equal = %v", s, u, bs.IsSubMultiset(bu), bs.Equal(bu))
+		}
+		for _, c := range s {
+			if !rs.Remove(c) {
+				t.Fatalf("Runes(%q).Remove(%q) = false", s, c)
+			}
+		}
+		if rs.Len() != 0 || rs.Remove('a') || rs.Remove('é') {
+			t.Fatalf("Runes(%q) not empty after removing every rune", s)
+		}
+	}
+	b := &Bytes{}
+	b.Add('x')
+	if !b.Remove('x') || b.Remove('x') || b.Len() != 0 {
+		t.Fatal("Bytes Remove on an empty count succeeded")
+	}
+}
+
+func TestProblems(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 3000 {
+		s, u := randString(r, "abc", 6), randString(r, "abc", 10)
+		if got, want := CanConstruct(s, u), subset(counts(s), counts(u)); got != want {
+			t.Fatalf("CanConstruct(%q, %q) = %v, want %v", s, u, got, want)
+		}
+		sorted := func(s string) string {
+			b := []byte(s)
+			slices.Sort(b)
+			return string(b)
+		}
+		if got, want := IsAnagram(s, u), sorted(s) == sorted(u); got != want {
+			t.Fatalf("IsAnagram(%q, %q) = %v, want %v", s, u, got, want)
+		}
+		want := false
+		for i := 0; i+len(s) <= len(u); i++ {
+			want = want || sorted(u[i:i+len(s)]) == sorted(s)
+		}
+		if got := CheckInclusion(s, u); got != want {
+			t.Fatalf("CheckInclusion(%q, %q) = %v, want %v", s, u, got, want)
+		}
+	}
+	if !IsAnagramRunes("午饭", "饭午") || IsAnagramRunes("午饭", "午午") {
+		t.Fatal("IsAnagramRunes is wrong")
+	}
+}
+
+// countingReader 记录读了多少字节，每次最多读一个字节。
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p[:min(len(p), 1)])
+	c.n += n
+	return n, err
+}
+
+func TestCanConstructFromReader(t *testing.T) {
+	for _, tc := range []struct {
+		note, magazine string
+		want           bool
+	}{
+		{"", "", true},
+		{"aa", "ab", false},
+		{"aa", "aab", true},
+		{"赎金", "金子和赎回", true},
+		{"赎赎", "赎金", false},
+	} {
+		got, err := CanConstructFromReader(tc.note, strings.NewReader(tc.magazine))
+		if got != tc.want || err != nil {
+			t.Errorf("CanConstructFromReader(%q, %q) = %v, %v, want %v", tc.note, tc.magazine, got, err, tc.want)
+		}
+	}
+
+	// 凑齐后不再读取剩余内容（bufio 最多多读一个 16 字节的缓冲区）
+	r := &countingReader{r: strings.NewReader("ab" + strings.Repeat("x", 1000))}
+	if ok, err := CanConstructFromReader("ba", r); !ok || err != nil || r.n > 16 {
+		t.Fatalf("CanConstructFromReader = %v, %v after reading %d bytes", ok, err, r.n)
+	}
+
+	boom := errors.New("boom")
+	if ok, err := CanConstructFromReader("z", iotest.ErrReader(boom)); ok || !errors.Is(err, boom) {
+		t.Fatalf("CanConstructFromReader on a failing reader = %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,108 @@
+package multiset
+
+import (
+	"bufio"
+	"io"
+)
+
+/**
+ * 383. 赎金信
+ * https://leetcode.cn/problems/ransom-note/
+ */
+func CanConstruct(ransomNote, magazine string) bool {
+	if len(ransomNote) > len(magazine) {
+		return false
+	}
+	return FromBytes(ransomNote).IsSubMultiset(FromBytes(magazine))
+}
+
+/**
+ * 242. 有效的字母异位词
+ * https://leetcode.cn/problems/valid-anagram/
+ */
+func IsAnagram(s, t string) bool {
+	if len(s) != len(t) {
+		return false
+	}
+	return FromBytes(s).Equal(FromBytes(t))
+}
+
+// IsAnagramRunes 按 Unicode 字符比较的字母异位词判断。
+func IsAnagramRunes(s, t string) bool {
+	return FromRunes(s, 'a', 'z').Equal(FromRunes(t, 'a', 'z'))
+}
+
+/**
+ * 567. 字符串的排列
+ * https://leetcode.cn/problems/permutation-in-string/
+ * 固定长度的滑动窗口，diff 记录窗口与 s1 计数不同的字节个数。
+ */
+func CheckInclusion(s1, s2 string) bool {
+	n, m := len(s1), len(s2)
+	if n > m {
+		return false
+	}
+
+	var cnt [256]int
+	for i := 0; i < n; i++ {
+		cnt[s1[i]]--
+		cnt[s2[i]]++
+	}
+	diff := 0
+	for _, c := range cnt {
+		if c != 0 {
+			diff++
+		}
+	}
+
+	update := func(c byte, delta int) {
+		if cnt[c] == 0 {
+			diff++
+		}
+		cnt[c] += delta
+		if cnt[c] == 0 {
+			diff--
+		}
+	}
+	for i := n; diff != 0 && i < m; i++ {
+		update(s2[i], 1)
+		update(s2[i-n], -1)
+	}
+	return diff == 0
+}
+
+// CanConstructFromReader 流式版本的赎金信：magazine 从 r 中逐个字符读取，
+// 一旦 note 所需的字符都已凑齐就立即返回 true，不再读取剩余内容。
+// r 不是 io.RuneReader 时会用 bufio 包装，可能多读一个缓冲区的数据。
+func CanConstructFromReader(note string, r io.Reader) (bool, error) {
+	need := map[rune]int{}
+	remaining := 0
+	for _, c := range note {
+		need[c]++
+		remaining++
+	}
+	if remaining == 0 {
+		return true, nil
+	}
+
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReaderSize(r, 16)
+	}
+	for {
+		c, _, err := rr.ReadRune()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if need[c] > 0 {
+			need[c]--
+			remaining--
+			if remaining == 0 {
+				return true, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package notes
+
+import (
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// headingWeight 标题中的词按出现这么多次计算。
+const headingWeight = 3
+
+type posting struct {
+	section int
+	tf      int
+}
+
+// Index 小节的倒排索引，按 BM25 排序。
+type Index struct {
+	Sections []*Section
+	postings map[string][]posting
+	lengths  []int
+	avgLen   float64
+}
+
+// NewIndex 为 secs 建立索引。
+func NewIndex(secs []*Section) *Index {
+	idx := &Index{Sections: secs, postings: map[string][]posting{}, lengths: make([]int, len(secs))}
+	total := 0
+	for i, s := range secs {
+		tf := map[string]int{}
+		for _, t := range Tokenize(strings.Join(s.Path, " ")) {
+			tf[t] += headingWeight
+		}
+		for _, t := range Tokenize(s.Text) {
+			tf[t]++
+		}
+		for t, n := range tf {
+			idx.postings[t] = append(idx.postings[t], posting{i, n})
+			idx.lengths[i] += n
+		}
+		total += idx.lengths[i]
+	}
+	if len(secs) > 0 {
+		idx.avgLen = float64(total) / float64(len(secs))
+	}
+	return idx
+}
+
+// Load 读取 root 下所有 Markdown 文件（跳过以 . 或 _ 开头的目录和 skip 中的目录）并建立索引。
+func Load(root string, skip ...string) (*Index, error) {
+	var secs []*Section
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_") || slices.Contains(skip, rel)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		s, err := Parse(rel, f)
+		if err != nil {
+			return err
+		}
+		secs = append(secs, s...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewIndex(secs), nil
+}
+
+// Hit 一条搜索结果。
+type Hit struct {
+	*Section
+	Score   float64
+	Snippet string
+}
+
+// Search 返回与 query 最相关的至多 limit 个小节，不包含任何查询词的小节不会出现。
+func (idx *Index) Search(query string, limit int) []Hit {
+	const k1, b = 1.2, 0.75
+	terms := Tokenize(query)
+	slices.Sort(terms)
+	terms = slices.Compact(terms)
+
+	scores := map[int]float64{}
+	n := float64(len(idx.Sections))
+	for _, t := range terms {
+		ps := idx.postings[t]
+		if len(ps) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-float64(len(ps))+0.5)/(float64(len(ps))+0.5))
+		for _, p := range ps {
+			tf := float64(p.tf)
+			norm := 1 - b + b*float64(idx.lengths[p.section])/idx.avgLen
+			scores[p.section] += idf * tf * (k1 + 1) / (tf + k1*norm)
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for i, score := range scores {
+		hits = append(hits, Hit{Section: idx.Sections[i], Score: score})
+	}
+	slices.SortFunc(hits, func(a, b Hit) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Ref(), b.Ref())
+	})
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	for i := range hits {
+		hits[i].Snippet = snippet(hits[i].Text, terms)
+	}
+	return hits
+}
+
+// Lookup 按 file#anchor 查找小节，anchor 也可以直接写标题文字；只有 file 时返回文件的第一个小节。
+func (idx *Index) Lookup(ref string) (*Section, bool) {
+	file, anchor, _ := strings.Cut(ref, "#")
+	for _, s := range idx.Sections {
+		if s.File != file {
+			continue
+		}
+		if anchor == "" || s.Anchor == anchor || s.Anchor == Anchor(anchor) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+const snippetRunes = 60
+
+// snippet 截取正文中第一个查询词附近的一行文字。
+// 逐个 rune 转小写，小写文本与原文的 rune 一一对应，查到的位置直接用于原文；
+// 按字节算的位置不能通用，例如 'İ' 转小写后少一个字节，'Ⱥ' 多一个字节。
+func snippet(text string, terms []string) string {
+	r := []rune(strings.Join(strings.Fields(text), " "))
+	lower := make([]rune, len(r))
+	for i, c := range r {
+		lower[i] = unicode.ToLower(c)
+	}
+	at := 0
+	for _, t := range terms {
+		if i := strings.Index(string(lower), t); i >= 0 {
+			at = utf8.RuneCountInString(string(lower)[:i])
+			break
+		}
+	}
+	start := max(0, at-snippetRunes/3)
+	end := min(len(r), start+snippetRunes)
+	s := string(r[start:end])
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(r) {
+		s += "…"
+	}
+	return s
+}
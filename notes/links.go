@@ -0,0 +1,114 @@
+package notes
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Link 源文件注释中声明的相关笔记，一行一条或用逗号分隔多条：
+//
+//	notes: 笔记/redis.md#缓存淘汰策略, 面试准备/算法.md
+//
+// 锚点可以写 GitHub 锚点，也可以直接写标题文字。
+type Link struct {
+	File  string   // 源文件，相对模块根目录
+	Line  int      // 声明所在行
+	Ref   string   // file#anchor
+	Slugs []string // 同一段注释中链接到的题目，为空表示整个文件
+}
+
+// Source 一个声明了笔记或链接到题目的 Go 源文件。
+type Source struct {
+	File  string
+	Slugs []string // 注释中链接到的所有 LeetCode 题目
+	Links []Link
+}
+
+var (
+	notesLine  = regexp.MustCompile(`^[\s*/]*notes:\s*(.+?)\s*(\*/)?$`)
+	problemURL = regexp.MustCompile(`leetcode\.(?:cn|com)/problems/([a-z0-9-]+)`)
+)
+
+// ScanSources 解析 root 下所有 Go 文件（跳过以 . 或 _ 开头的目录）的注释，有语法错误的文件跳过。
+func ScanSources(root string) ([]*Source, error) {
+	var srcs []*Source
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), "_")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		src := &Source{File: filepath.ToSlash(rel)}
+		for _, g := range f.Comments {
+			var slugs []string
+			for _, c := range g.List {
+				for _, m := range problemURL.FindAllStringSubmatch(c.Text, -1) {
+					if !slices.Contains(slugs, m[1]) {
+						slugs = append(slugs, m[1])
+					}
+				}
+			}
+			for _, s := range slugs {
+				if !slices.Contains(src.Slugs, s) {
+					src.Slugs = append(src.Slugs, s)
+				}
+			}
+
+			for _, c := range g.List {
+				line := fset.Position(c.Pos()).Line
+				for i, text := range strings.Split(c.Text, "\n") {
+					m := notesLine.FindStringSubmatch(text)
+					if m == nil {
+						continue
+					}
+					for _, ref := range strings.Split(m[1], ",") {
+						if ref = strings.TrimSpace(ref); ref != "" {
+							src.Links = append(src.Links, Link{src.File, line + i, ref, slugs})
+						}
+					}
+				}
+			}
+		}
+		if len(src.Slugs) > 0 || len(src.Links) > 0 {
+			srcs = append(srcs, src)
+		}
+		return nil
+	})
+	return srcs, err
+}
+
+// LinksFor 返回与题目 slug 相关的笔记声明：链接到该题的文件中，与题目链接在同一段注释里
+// 或者针对整个文件的声明。
+func LinksFor(srcs []*Source, slug string) []Link {
+	var links []Link
+	for _, src := range srcs {
+		if !slices.Contains(src.Slugs, slug) {
+			continue
+		}
+		for _, l := range src.Links {
+			if len(l.Slugs) == 0 || slices.Contains(l.Slugs, slug) {
+				links = append(links, l)
+			}
+		}
+	}
+	return links
+}
@@ -0,0 +1,129 @@
+/**
+ * 笔记索引：把 笔记/、面试准备/ 等目录下的 Markdown 按标题切成小节，建立中英文全文索引，
+ * 并把题解、设计模式文件注释中声明的笔记锚点与小节关联起来，见 lc notes。
+ */
+package notes
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Section Markdown 中一个标题下的内容，不含子标题下的内容。
+type Section struct {
+	File    string   // 相对模块根目录的路径，使用 /
+	Heading string   // 去掉 # 和强调符号后的标题，第一个标题之前的内容为空
+	Level   int      // 标题级别，第一个标题之前的内容为 0
+	Anchor  string   // 与 GitHub 相同的锚点
+	Line    int      // 标题所在行，从 1 开始
+	Path    []string // 从最外层到本节的标题
+	Text    string
+}
+
+// Ref 返回 file#anchor 形式的引用。
+func (s *Section) Ref() string {
+	if s.Anchor == "" {
+		return s.File
+	}
+	return s.File + "#" + s.Anchor
+}
+
+// Parse 把 Markdown 按 ATX 标题（# 开头）切分，代码块中的 # 不算标题。
+func Parse(file string, r io.Reader) ([]*Section, error) {
+	var (
+		secs    []*Section
+		stack   []*Section // 当前标题路径
+		anchors = map[string]int{}
+		body    []string
+		fence   string
+	)
+	cur := &Section{File: file, Line: 1}
+	flush := func() {
+		cur.Text = strings.TrimSpace(strings.Join(body, "\n"))
+		if cur.Level > 0 || cur.Text != "" {
+			secs = append(secs, cur)
+		}
+		body = body[:0]
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for line := 1; sc.Scan(); line++ {
+		text := sc.Text()
+		trimmed := strings.TrimSpace(text)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			body = append(body, text)
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			body = append(body, text)
+			continue
+		}
+
+		level, heading := headingOf(text)
+		if level == 0 {
+			body = append(body, text)
+			continue
+		}
+		flush()
+		for len(stack) > 0 && stack[len(stack)-1].Level >= level {
+			stack = stack[:len(stack)-1]
+		}
+		cur = &Section{File: file, Heading: heading, Level: level, Line: line}
+		cur.Anchor = Anchor(heading)
+		if n := anchors[cur.Anchor]; n > 0 {
+			anchors[cur.Anchor]++
+			cur.Anchor += "-" + strconv.Itoa(n)
+		} else {
+			anchors[cur.Anchor] = 1
+		}
+		for _, s := range stack {
+			cur.Path = append(cur.Path, s.Heading)
+		}
+		cur.Path = append(cur.Path, heading)
+		stack = append(stack, cur)
+	}
+	flush()
+	return secs, sc.Err()
+}
+
+// headingOf 识别 ATX 标题，返回级别和去掉强调符号的标题文字，不是标题时级别为 0。
+func headingOf(line string) (int, string) {
+	if len(line) > 3 && strings.HasPrefix(line, "    ") {
+		return 0, "" // 缩进代码块
+	}
+	s := strings.TrimLeft(line, " ")
+	level := 0
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(s) && s[level] != ' ' && s[level] != '\t') {
+		return 0, ""
+	}
+	h := strings.TrimSpace(s[level:])
+	h = strings.TrimSpace(strings.TrimRight(h, "#"))
+	h = strings.NewReplacer("**", "", "__", "", "`", "").Replace(h)
+	return level, h
+}
+
+// Anchor 按 GitHub 的规则生成锚点：转小写，去掉字母、数字、空格、- 和 _ 以外的字符，空格换成 -。
+// 中文字符保留，例如 "Redis 为什么快" 得到 "redis-为什么快"。
+func Anchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			sb.WriteByte('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
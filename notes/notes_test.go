@@ -0,0 +1,225 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const doc = `前言
+
+# Redis
+
+## 缓存淘汰策略
+
+LRU 和 LFU。
+
+` + "```" + `
+# 代码块里的不是标题
+` + "```" + `
+
+### **Redis** 为什么快？
+
+单线程、IO 多路复用。
+
+## 缓存淘汰策略
+
+重复的标题。
+
+    # 缩进代码块
+#不是标题
+`
+
+func TestParse(t *testing.T) {
+	secs, err := Parse("笔记/redis.md", strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range secs {
+		got = append(got, s.Ref()+" "+strings.Join(s.Path, "/"))
+	}
+	want := []string{
+		"笔记/redis.md ",
+		"笔记/redis.md#redis Redis",
+		"笔记/redis.md#缓存淘汰策略 Redis/缓存淘汰策略",
+		"笔记/redis.md#redis-为什么快 Redis/缓存淘汰策略/Redis 为什么快？",
+		"笔记/redis.md#缓存淘汰策略-1 Redis/缓存淘汰策略",
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Parse sections:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if s := secs[2]; s.Level != 2 || s.Line != 5 || !strings.Contains(s.Text, "# 代码块里的不是标题") {
+		t.Errorf("section %+v", s)
+	}
+	if s := secs[4]; !strings.Contains(s.Text, "# 缩进代码块") || !strings.Contains(s.Text, "#不是标题") {
+		t.Errorf("last section text %q", s.Text)
+	}
+}
+
+func TestAnchor(t *testing.T) {
+	for heading, want := range map[string]string{
+		"Redis 为什么快": "redis-为什么快",
+		"如何保证多个 goroutine 对共享变量的并发安全？": "如何保证多个-goroutine-对共享变量的并发安全",
+		"Go 1.23: range-over-func":     "go-123-range-over-func",
+		"snake_case & C++":             "snake_case--c",
+	} {
+		if got := Anchor(heading); got != want {
+			t.Errorf("Anchor(%q) = %q, want %q", heading, got, want)
+		}
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("缓存淘汰 LRU-K，用 Go1.23 写")
+	want := []string{"缓存", "存淘", "淘汰", "lru", "k", "用", "go1", "23", "写"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("Tokenize = %q, want %q", got, want)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	secs := []*Section{
+		{File: "a.md", Anchor: "lru", Path: []string{"LRU"}, Text: "最近最少使用"},
+		{File: "b.md", Anchor: "redis", Path: []string{"Redis"}, Text: "淘汰策略有 LRU、LFU 等，淘汰时"},
+		{File: "c.md", Anchor: "tcp", Path: []string{"TCP"}, Text: "三次握手"},
+		{File: "d.md", Anchor: "long", Path: []string{"长文"}, Text: "LRU " + strings.Repeat("无关的内容 ", 50)},
+	}
+	idx := NewIndex(secs)
+	var got []string
+	for _, h := range idx.Search("lru 淘汰", 0) {
+		got = append(got, h.Ref())
+	}
+	// b 同时包含两个词；标题中的词加权，a 排在长文 d 之前
+	if want := []string{"b.md#redis", "a.md#lru", "d.md#long"}; !slices.Equal(got, want) {
+		t.Fatalf("Search = %v, want %v", got, want)
+	}
+	if hits := idx.Search("lru", 1); len(hits) != 1 || hits[0].Ref() != "a.md#lru" {
+		t.Fatalf("Search limit 1 = %v", hits)
+	}
+	if hits := idx.Search("udp", 0); len(hits) != 0 {
+		t.Fatalf("Search for a missing term = %v", hits)
+	}
+	if s, ok := idx.Lookup("b.md#Redis"); !ok || s != secs[1] {
+		t.Errorf("Lookup by heading text = %v, %v", s, ok)
+	}
+	if s, ok := idx.Lookup("c.md"); !ok || s != secs[2] {
+		t.Errorf("Lookup by file = %v, %v", s, ok)
+	}
+	if _, ok := idx.Lookup("c.md#udp"); ok {
+		t.Error("Lookup of a missing anchor succeeded")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	long := strings.Repeat("前面的内容，", 20) + "关键词在这里" + strings.Repeat("，后面的内容", 20)
+	s := snippet(long, []string{"关键"})
+	if !strings.HasPrefix(s, "…") || !strings.HasSuffix(s, "…") || !strings.Contains(s, "关键词在这里") {
+		t.Errorf("snippet = %q", s)
+	}
+	if s := snippet("短文本", []string{"不存在"}); s != "短文本" {
+		t.Errorf("snippet without a match = %q", s)
+	}
+	// 转小写会改变字节长度的字符在查询词之前，截取的仍是原文中查询词附近的内容
+	text := strings.Repeat("İȺK", 30) + " Target 之后"
+	s = snippet(text, []string{"target"})
+	if body := strings.Trim(s, "…"); !strings.Contains(body, "Target") || !strings.Contains(text, body) {
+		t.Errorf("snippet after İ and Ⱥ = %q", s)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScanSources(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "two_sum.go"), `package main
+
+/**
+ * 1. 两数之和
+ * https://leetcode.cn/problems/two-sum/
+ * notes: 面试准备/算法.md#算法, 笔记/hash.md
+ */
+func main() {}
+
+// 相关的另一道题 https://leetcode.com/problems/3sum/
+// notes: 笔记/双指针.md
+func threeSum() {}
+`)
+	writeFile(t, filepath.Join(root, "cache", "cache.go"), `/**
+ * 缓存
+ * notes: 笔记/redis.md#缓存淘汰策略
+ */
+package cache
+`)
+	writeFile(t, filepath.Join(root, "broken.go"), "package main\n// notes: x.md\nfunc {")
+	writeFile(t, filepath.Join(root, "_tmp", "skip.go"), "package x\n// notes: x.md\n")
+	writeFile(t, filepath.Join(root, "plain.go"), "package plain\n")
+
+	srcs, err := ScanSources(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(srcs) != 2 || srcs[0].File != "cache/cache.go" || srcs[1].File != "two_sum.go" {
+		t.Fatalf("ScanSources found %d sources: %+v", len(srcs), srcs)
+	}
+	ts := srcs[1]
+	if !slices.Equal(ts.Slugs, []string{"two-sum", "3sum"}) {
+		t.Errorf("slugs = %v", ts.Slugs)
+	}
+	want := []Link{
+		{"two_sum.go", 6, "面试准备/算法.md#算法", []string{"two-sum"}},
+		{"two_sum.go", 6, "笔记/hash.md", []string{"two-sum"}},
+		{"two_sum.go", 11, "笔记/双指针.md", []string{"3sum"}},
+	}
+	if !slices.EqualFunc(ts.Links, want, func(a, b Link) bool {
+		return a.File == b.File && a.Line == b.Line && a.Ref == b.Ref && slices.Equal(a.Slugs, b.Slugs)
+	}) {
+		t.Errorf("links = %+v, want %+v", ts.Links, want)
+	}
+	if l := srcs[0].Links; len(l) != 1 || l[0].Ref != "笔记/redis.md#缓存淘汰策略" || l[0].Slugs != nil {
+		t.Errorf("cache links = %+v", l)
+	}
+
+	refs := func(links []Link) []string {
+		var r []string
+		for _, l := range links {
+			r = append(r, l.Ref)
+		}
+		return r
+	}
+	if got := refs(LinksFor(srcs, "3sum")); !slices.Equal(got, []string{"笔记/双指针.md"}) {
+		t.Errorf("LinksFor(3sum) = %v", got)
+	}
+	if got := refs(LinksFor(srcs, "two-sum")); len(got) != 2 {
+		t.Errorf("LinksFor(two-sum) = %v", got)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "笔记", "redis.md"), "# Redis\n\n## 缓存淘汰策略\n\nLRU\n")
+	writeFile(t, filepath.Join(root, "README.MD"), "# 说明\n")
+	writeFile(t, filepath.Join(root, "vendor", "x.md"), "# 跳过\n")
+	writeFile(t, filepath.Join(root, ".git", "x.md"), "# 跳过\n")
+	idx, err := Load(root, "vendor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range idx.Sections {
+		got = append(got, s.Ref())
+	}
+	if want := []string{"README.MD#说明", "笔记/redis.md#redis", "笔记/redis.md#缓存淘汰策略"}; !slices.Equal(got, want) {
+		t.Fatalf("Load sections = %v, want %v", got, want)
+	}
+}
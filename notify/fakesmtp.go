@@ -0,0 +1,200 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// FakeMail FakeSMTPServer 收到的一封邮件，Data 是 DATA 阶段的原始内容（已去掉点转义，换行为 LF）。
+type FakeMail struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Parse 解析邮件头和正文，正文未解码。
+func (m FakeMail) Parse() (*mail.Message, error) {
+	return mail.ReadMessage(bytes.NewReader(m.Data))
+}
+
+// FakeSMTPServer 监听 127.0.0.1 随机端口的 SMTP 服务器，只实现发信需要的命令
+// （EHLO/HELO、AUTH PLAIN、MAIL、RCPT、DATA、RSET、NOOP、QUIT），邮件保存在内存里。
+type FakeSMTPServer struct {
+	Addr string
+
+	username, password string
+	ln                 net.Listener
+	wg                 sync.WaitGroup
+
+	mu     sync.Mutex
+	mails  []FakeMail
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+// NewFakeSMTPServer 启动服务器。username 不为空时要求 AUTH PLAIN 认证后才能发信。
+func NewFakeSMTPServer(username, password string) (*FakeSMTPServer, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &FakeSMTPServer{
+		Addr:     ln.Addr().String(),
+		username: username,
+		password: password,
+		ln:       ln,
+		conns:    map[net.Conn]struct{}{},
+	}
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+// Mails 到目前为止收到的邮件。
+func (s *FakeSMTPServer) Mails() []FakeMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]FakeMail(nil), s.mails...)
+}
+
+// Close 停止监听并断开所有连接。
+func (s *FakeSMTPServer) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	s.closed = true
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *FakeSMTPServer) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		// Close 之前刚接受的连接可能在 Close 断开所有连接之后才登记，这里直接关掉
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.serve(conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+func (s *FakeSMTPServer) serve(conn net.Conn) {
+	tp := textproto.NewConn(conn)
+	defer tp.Close()
+	tp.PrintfLine("220 localhost fake ESMTP ready")
+	authed := s.username == ""
+	// 退信地址 MAIL FROM:<> 的 From 为空，用 haveMail 记录是否已经开始一封邮件
+	var m FakeMail
+	haveMail := false
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			tp.PrintfLine("250 localhost")
+		case "EHLO":
+			tp.PrintfLine("250-localhost")
+			if s.username != "" {
+				tp.PrintfLine("250-AUTH PLAIN")
+			}
+			tp.PrintfLine("250 8BITMIME")
+		case "AUTH":
+			mech, resp, _ := strings.Cut(arg, " ")
+			if !strings.EqualFold(mech, "PLAIN") {
+				tp.PrintfLine("504 5.5.4 unrecognized authentication type")
+				continue
+			}
+			if s.checkPlain(resp) {
+				authed = true
+				tp.PrintfLine("235 2.7.0 authentication successful")
+			} else {
+				tp.PrintfLine("535 5.7.8 authentication credentials invalid")
+			}
+		case "MAIL":
+			if !authed {
+				tp.PrintfLine("530 5.7.0 authentication required")
+				continue
+			}
+			m, haveMail = FakeMail{From: pathArg(arg)}, true
+			tp.PrintfLine("250 2.1.0 ok")
+		case "RCPT":
+			if !haveMail {
+				tp.PrintfLine("503 5.5.1 need MAIL first")
+				continue
+			}
+			m.To = append(m.To, pathArg(arg))
+			tp.PrintfLine("250 2.1.5 ok")
+		case "DATA":
+			if len(m.To) == 0 {
+				tp.PrintfLine("503 5.5.1 need RCPT first")
+				continue
+			}
+			tp.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			m.Data = data
+			s.mu.Lock()
+			s.mails = append(s.mails, m)
+			s.mu.Unlock()
+			m, haveMail = FakeMail{}, false
+			tp.PrintfLine("250 2.0.0 queued")
+		case "RSET":
+			m, haveMail = FakeMail{}, false
+			tp.PrintfLine("250 2.0.0 ok")
+		case "NOOP":
+			tp.PrintfLine("250 2.0.0 ok")
+		case "QUIT":
+			tp.PrintfLine("221 2.0.0 bye")
+			return
+		default:
+			tp.PrintfLine("502 5.5.2 command not implemented")
+		}
+	}
+}
+
+// checkPlain 检查 AUTH PLAIN 的初始响应：base64("authzid\x00user\x00pass")。
+func (s *FakeSMTPServer) checkPlain(resp string) bool {
+	b, err := base64.StdEncoding.DecodeString(resp)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(string(b), "\x00")
+	return len(parts) == 3 && parts[1] == s.username && parts[2] == s.password
+}
+
+// pathArg 从 "FROM:<a@b> BODY=8BITMIME" 中取出 a@b。
+func pathArg(arg string) string {
+	_, path, _ := strings.Cut(arg, ":")
+	path, _, _ = strings.Cut(strings.TrimSpace(path), " ")
+	return strings.Trim(path, "<>")
+}
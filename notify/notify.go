@@ -0,0 +1,60 @@
+/**
+ * 通知：统一的 Channel 接口，SMTP 邮件、带 HMAC 签名和退避重试的 HTTP Webhook、
+ * 同时发往多个渠道的 Fanout，以及按语言区域渲染的 text/template 消息模版。
+ * FakeSMTPServer 是进程内的 SMTP 服务器，用于演示和测试，不需要真实的邮件服务。
+ */
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Message 一条通知。短信等没有标题的渠道忽略 Subject。
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Channel 发送通知的渠道，实现必须可以被多个 goroutine 同时使用。
+type Channel interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// ChannelFunc 把普通函数当作 Channel。
+type ChannelFunc func(ctx context.Context, msg Message) error
+
+func (f ChannelFunc) Send(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Fanout 把同一条消息并发发给所有渠道，全部成功才算成功，失败的渠道按下标合并成一个错误。
+type Fanout []Channel
+
+func (f Fanout) Send(ctx context.Context, msg Message) error {
+	errs := make([]error, len(f))
+	var wg sync.WaitGroup
+	for i, ch := range f {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ch.Send(ctx, msg); err != nil {
+				errs[i] = fmt.Errorf("notify: channel %d: %w", i, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// newID 随机的 128 位十六进制标识，用于 Message-ID 和 Webhook ID。
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,37 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFanout(t *testing.T) {
+	var sent atomic.Int64
+	ok := ChannelFunc(func(ctx context.Context, msg Message) error {
+		sent.Add(1)
+		return nil
+	})
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+	fail := func(err error) Channel {
+		return ChannelFunc(func(ctx context.Context, msg Message) error { return err })
+	}
+
+	if err := (Fanout{ok, ok}).Send(context.Background(), Message{}); err != nil || sent.Load() != 2 {
+		t.Fatalf("Send = %v with %d channels sent, want nil and 2", err, sent.Load())
+	}
+	if err := (Fanout{}).Send(context.Background(), Message{}); err != nil {
+		t.Fatalf("empty Fanout = %v", err)
+	}
+
+	// 一个渠道失败不影响其他渠道，所有错误按下标合并
+	sent.Store(0)
+	err := Fanout{fail(errA), ok, fail(errB)}.Send(context.Background(), Message{})
+	if !errors.Is(err, errA) || !errors.Is(err, errB) || sent.Load() != 1 {
+		t.Fatalf("Send = %v with %d channels sent, want both errors and 1", err, sent.Load())
+	}
+	if want := "notify: channel 0: a failed\nnotify: channel 2: b failed"; err.Error() != want {
+		t.Fatalf("error = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type SMTPOptions struct {
+	Addr string // host:port
+	From string // 发件人，可以带显示名，例如 "Acme <no-reply@acme.test>"
+	Auth smtp.Auth
+	// TLS 不为 nil 时要求服务器支持 STARTTLS 并升级连接，不支持时返回 ErrNoStartTLS，不会退回明文。
+	// smtp.PlainAuth 在非加密连接上只允许连接 localhost。
+	TLS      *tls.Config
+	Hostname string           // EHLO 使用的主机名，默认 localhost
+	Timeout  time.Duration    // 一次发送（连接到 QUIT）的超时，默认 10s
+	Now      func() time.Time // 用于 Date 头，默认 time.Now
+}
+
+// ErrNoStartTLS 设置了 SMTPOptions.TLS，但服务器没有提供 STARTTLS。
+var ErrNoStartTLS = errors.New("notify: smtp server does not support STARTTLS")
+
+// SMTP 每条消息建立一次连接，发一封纯文本邮件。
+type SMTP struct {
+	opts SMTPOptions
+	from *mail.Address
+}
+
+func NewSMTP(opts SMTPOptions) (*SMTP, error) {
+	from, err := mail.ParseAddress(opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("notify: smtp from: %w", err)
+	}
+	if opts.Hostname == "" {
+		opts.Hostname = "localhost"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &SMTP{opts: opts, from: from}, nil
+}
+
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("notify: smtp to: %w", err)
+	}
+	data, err := s.compose(to, msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.opts.Addr)
+	if err != nil {
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	// net/smtp 不支持 context，用连接的截止时间和取消时关闭连接来代替
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	host, _, _ := net.SplitHostPort(s.opts.Addr)
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	defer c.Close()
+	if err := s.deliver(c, to.Address, data); err != nil {
+		if ctx.Err() != nil {
+			err = errors.Join(ctx.Err(), err)
+		}
+		return fmt.Errorf("notify: smtp: %w", err)
+	}
+	return nil
+}
+
+func (s *SMTP) deliver(c *smtp.Client, to string, data []byte) error {
+	if err := c.Hello(s.opts.Hostname); err != nil {
+		return err
+	}
+	if s.opts.TLS != nil {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return ErrNoStartTLS
+		}
+		if err := c.StartTLS(s.opts.TLS); err != nil {
+			return err
+		}
+	}
+	if s.opts.Auth != nil {
+		if err := c.Auth(s.opts.Auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// compose 生成 RFC 5322 邮件：标题按 RFC 2047 编码，正文用 UTF-8 quoted-printable，
+// 换行统一成 CRLF。标题中的换行会被拒绝，以防注入额外的邮件头。
+func (s *SMTP) compose(to *mail.Address, msg Message) ([]byte, error) {
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("notify: smtp subject contains a line break")
+	}
+	var b bytes.Buffer
+	header := func(k, v string) { fmt.Fprintf(&b, "%s: %s\r\n", k, v) }
+	header("From", s.from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", s.opts.Now().Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", newID(), s.opts.Hostname))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n")
+	qp := quotedprintable.NewWriter(&b)
+	qp.Write([]byte(body))
+	qp.Close()
+	return b.Bytes(), nil
+}
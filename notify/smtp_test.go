@@ -0,0 +1,230 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestSMTP(t *testing.T, password string) (*SMTP, *FakeSMTPServer) {
+	server, err := NewFakeSMTPServer("user", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { server.Close() })
+	s, err := NewSMTP(SMTPOptions{
+		Addr: server.Addr,
+		From: "Acme <no-reply@acme.test>",
+		Auth: smtp.PlainAuth("", "user", password, "127.0.0.1"),
+		Now:  func() time.Time { return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, server
+}
+
+func TestSMTPSend(t *testing.T) {
+	s, server := newTestSMTP(t, "secret")
+	// 以点开头的行需要点转义，长行需要 quoted-printable 软换行
+	body := "您好，\n.\n.hidden\n" + strings.Repeat("x", 200) + "\n"
+	msg := Message{To: "Alice <alice@example.com>", Subject: "登录验证码 123456", Body: body}
+	if err := s.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	mails := server.Mails()
+	if len(mails) != 1 {
+		t.Fatalf("%d mails, want 1", len(mails))
+	}
+	m := mails[0]
+	if m.From != "no-reply@acme.test" || !slices.Equal(m.To, []string{"alice@example.com"}) {
+		t.Fatalf("envelope from %q to %v", m.From, m.To)
+	}
+	parsed, err := m.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec mime.WordDecoder
+	if subject, err := dec.DecodeHeader(parsed.Header.Get("Subject")); err != nil || subject != msg.Subject {
+		t.Fatalf("Subject = %q, %v", subject, err)
+	}
+	if date := parsed.Header.Get("Date"); date != "Thu, 01 Jan 2026 00:00:00 +0000" {
+		t.Fatalf("Date = %q", date)
+	}
+	got, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// FakeMail.Data 的换行已经是 LF
+	if string(got) != body {
+		t.Fatalf("body = %q, want %q", got, body)
+	}
+}
+
+func TestSMTPRejects(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		password string
+		msg      Message
+	}{
+		{"header injection CRLF", "secret", Message{To: "a@example.com", Subject: "hi\r\nBcc: victim@example.com", Body: "x"}},
+		{"header injection LF", "secret", Message{To: "a@example.com", Subject: "hi\nBcc: victim@example.com", Body: "x"}},
+		{"bad recipient", "secret", Message{To: "not an address", Body: "x"}},
+		{"wrong password", "wrong", Message{To: "a@example.com", Body: "x"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, server := newTestSMTP(t, tc.password)
+			if err := s.Send(context.Background(), tc.msg); err == nil {
+				t.Fatal("Send succeeded")
+			}
+			if n := len(server.Mails()); n != 0 {
+				t.Fatalf("%d mails delivered", n)
+			}
+		})
+	}
+}
+
+// TestSMTPRequiresStartTLS 要求 TLS 时，服务器不支持 STARTTLS 就不发送，而不是退回明文。
+func TestSMTPRequiresStartTLS(t *testing.T) {
+	s, server := newTestSMTP(t, "secret")
+	s.opts.TLS = &tls.Config{ServerName: "127.0.0.1"}
+	if err := s.Send(context.Background(), Message{To: "a@example.com", Body: "x"}); !errors.Is(err, ErrNoStartTLS) {
+		t.Fatalf("Send = %v, want ErrNoStartTLS", err)
+	}
+	if n := len(server.Mails()); n != 0 {
+		t.Fatalf("%d mails delivered in plaintext", n)
+	}
+}
+
+// dialFake 直接用 SMTP 命令和 FakeSMTPServer 对话。
+func dialFake(t *testing.T, server *FakeSMTPServer) *textproto.Conn {
+	c, err := textproto.Dial("tcp", server.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	if _, _, err := c.ReadResponse(220); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// cmd 发送一条命令，检查响应码。
+func cmd(t *testing.T, c *textproto.Conn, code int, format string, args ...any) {
+	t.Helper()
+	id, err := c.Cmd(format, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.StartResponse(id)
+	defer c.EndResponse(id)
+	if _, msg, err := c.ReadResponse(code); err != nil {
+		t.Fatalf("%s: %v %s", format, err, msg)
+	}
+}
+
+func TestFakeSMTPServer(t *testing.T) {
+	server, err := NewFakeSMTPServer("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	c := dialFake(t, server)
+	cmd(t, c, 250, "HELO test")
+	cmd(t, c, 503, "RCPT TO:<a@example.com>")
+	cmd(t, c, 503, "DATA")
+	// 退信使用空的发件人
+	cmd(t, c, 250, "MAIL FROM:<>")
+	cmd(t, c, 250, "RCPT TO:<a@example.com>")
+	cmd(t, c, 250, "RCPT TO:<b@example.com>")
+	cmd(t, c, 354, "DATA")
+	w := c.DotWriter()
+	io.WriteString(w, "Subject: bounce\r\n\r\n.leading dot\r\n")
+	w.Close()
+	if _, _, err := c.ReadResponse(250); err != nil {
+		t.Fatal(err)
+	}
+	// 一封邮件发完后需要重新 MAIL，RSET 同样清空当前邮件
+	cmd(t, c, 503, "RCPT TO:<a@example.com>")
+	cmd(t, c, 250, "MAIL FROM:<x@example.com>")
+	cmd(t, c, 250, "RSET")
+	cmd(t, c, 503, "RCPT TO:<a@example.com>")
+	cmd(t, c, 502, "VRFY a@example.com")
+	cmd(t, c, 221, "QUIT")
+
+	mails := server.Mails()
+	if len(mails) != 1 {
+		t.Fatalf("%d mails, want 1", len(mails))
+	}
+	m := mails[0]
+	if m.From != "" || !slices.Equal(m.To, []string{"a@example.com", "b@example.com"}) {
+		t.Fatalf("envelope from %q to %v", m.From, m.To)
+	}
+	if string(m.Data) != "Subject: bounce\n\n.leading dot\n" {
+		t.Fatalf("data = %q", m.Data)
+	}
+}
+
+func TestFakeSMTPServerAuth(t *testing.T) {
+	_, server := newTestSMTP(t, "secret")
+	c := dialFake(t, server)
+	cmd(t, c, 250, "EHLO test")
+	cmd(t, c, 530, "MAIL FROM:<a@example.com>")
+	cmd(t, c, 535, "AUTH PLAIN AHVzZXIAd3Jvbmc=") // \x00user\x00wrong
+	cmd(t, c, 504, "AUTH LOGIN")
+	cmd(t, c, 235, "AUTH PLAIN AHVzZXIAc2VjcmV0") // \x00user\x00secret
+	cmd(t, c, 250, "MAIL FROM:<a@example.com>")
+}
+
+// TestFakeSMTPServerClose Close 断开所有连接并等待它们退出，包括与 Close 同时建立的连接。
+func TestFakeSMTPServerClose(t *testing.T) {
+	server, err := NewFakeSMTPServer("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	idle := dialFake(t, server)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				c, err := textproto.Dial("tcp", server.Addr)
+				if err != nil {
+					return
+				}
+				// 服务器关闭后连接要么被拒绝，要么很快被断开，不会一直挂着
+				c.ReadResponse(220)
+				c.ReadLine()
+				c.Close()
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		server.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Close did not return")
+	}
+	if _, err := idle.ReadLine(); err == nil {
+		t.Fatal("idle connection still open after Close")
+	}
+	wg.Wait()
+}
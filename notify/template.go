@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Templates 按语言区域保存消息模版。查找时从最具体的区域逐级退回，
+// 例如 zh-Hant-TW → zh-Hant → zh → 默认区域，区域名不区分大小写，"_" 等同于 "-"。
+type Templates struct {
+	mu       sync.RWMutex
+	fallback string
+	sets     map[string]*template.Template
+}
+
+func NewTemplates(fallback string) *Templates {
+	return &Templates{fallback: normalizeLocale(fallback), sets: map[string]*template.Template{}}
+}
+
+// Parse 为 locale 添加模版，text 中用 {{define "name"}} 定义一个或多个命名模版，
+// 同名模版覆盖之前的定义。模版引用不存在的字段时渲染报错，而不是输出 "<no value>"。
+func (t *Templates) Parse(locale, text string) error {
+	locale = normalizeLocale(locale)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	set, ok := t.sets[locale]
+	if !ok {
+		set = template.New(locale).Option("missingkey=error")
+	}
+	if _, err := set.Parse(text); err != nil {
+		return fmt.Errorf("notify: parse %s templates: %w", locale, err)
+	}
+	t.sets[locale] = set
+	return nil
+}
+
+// MustParse 同 Parse，出错时 panic，用于初始化内置模版。
+func (t *Templates) MustParse(locale, text string) *Templates {
+	if err := t.Parse(locale, text); err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Render 用 locale 下名为 name 的模版渲染 data，找不到时按区域逐级退回。
+func (t *Templates) Render(locale, name string, data any) (string, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for _, l := range t.candidates(locale) {
+		set, ok := t.sets[l]
+		if !ok {
+			continue
+		}
+		if tmpl := set.Lookup(name); tmpl != nil {
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				return "", fmt.Errorf("notify: render %s/%s: %w", l, name, err)
+			}
+			return b.String(), nil
+		}
+	}
+	return "", fmt.Errorf("notify: no template %q for locale %q", name, locale)
+}
+
+func (t *Templates) candidates(locale string) []string {
+	var out []string
+	for l := normalizeLocale(locale); l != ""; {
+		out = append(out, l)
+		i := strings.LastIndexByte(l, '-')
+		if i < 0 {
+			break
+		}
+		l = l[:i]
+	}
+	return append(out, t.fallback)
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
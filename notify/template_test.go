@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplates(t *testing.T) {
+	tmpl := NewTemplates("en").
+		MustParse("en", `{{define "greet"}}hello {{.}}{{end}}{{define "bye"}}bye {{.}}{{end}}`).
+		MustParse("zh", `{{define "greet"}}你好 {{.}}{{end}}`).
+		MustParse("zh_Hant", `{{define "greet"}}妳好 {{.}}{{end}}`)
+	for _, tc := range []struct {
+		locale, name, want string
+	}{
+		{"zh-CN", "greet", "你好 Ann"},      // zh-cn 没有，退回 zh
+		{"zh-hant-TW", "greet", "妳好 Ann"}, // 退回 zh-hant，"_" 等同于 "-"
+		{"ZH", "greet", "你好 Ann"},         // 不区分大小写
+		{"zh-CN", "bye", "bye Ann"},       // zh 没有这个模版，继续退回默认区域
+		{"fr-FR", "greet", "hello Ann"},
+		{"", "greet", "hello Ann"},
+	} {
+		if got, err := tmpl.Render(tc.locale, tc.name, "Ann"); got != tc.want || err != nil {
+			t.Errorf("Render(%q, %q) = %q, %v, want %q", tc.locale, tc.name, got, err, tc.want)
+		}
+	}
+
+	if _, err := tmpl.Render("zh-CN", "missing", "Ann"); err == nil || !strings.Contains(err.Error(), `no template "missing"`) {
+		t.Fatalf("Render of a missing template = %v", err)
+	}
+	// 引用不存在的字段时报错，而不是输出 "<no value>"
+	tmpl.MustParse("en", `{{define "field"}}{{.Code}}{{end}}`)
+	if got, err := tmpl.Render("en", "field", map[string]string{}); err == nil {
+		t.Fatalf("Render with a missing key = %q, want an error", got)
+	}
+	// 同名模版覆盖之前的定义
+	tmpl.MustParse("zh", `{{define "greet"}}您好 {{.}}{{end}}`)
+	if got, _ := tmpl.Render("zh-CN", "greet", "Ann"); got != "您好 Ann" {
+		t.Fatalf("Render after redefining = %q", got)
+	}
+	if err := tmpl.Parse("en", `{{define "broken"}}{{.Code{{end}}`); err == nil {
+		t.Fatal("Parse of a broken template succeeded")
+	}
+}
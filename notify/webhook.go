@@ -0,0 +1,188 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 签名相关的请求头。签名是 HMAC-SHA256(secret, timestamp + "." + body) 的十六进制，
+// 带 "sha256=" 前缀。时间戳参与签名，接收方据此拒绝重放的旧请求。
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+var (
+	ErrBadSignature = errors.New("notify: bad webhook signature")
+	ErrStaleWebhook = errors.New("notify: webhook timestamp outside tolerance")
+)
+
+// WebhookPayload Webhook 请求的 JSON 正文。同一条消息重试时 ID 不变，接收方可以用它去重。
+type WebhookPayload struct {
+	ID      string    `json:"id"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// StatusError 接收方返回了非 2xx 状态码。
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("notify: webhook returned %d %s", e.Code, http.StatusText(e.Code))
+}
+
+type WebhookOptions struct {
+	URL    string
+	Secret []byte
+	Client *http.Client // 默认超时 10s 的客户端
+	// MaxAttempts 最多尝试几次，默认 4。连接失败、408、429 和 5xx 会重试，其他 4xx 不重试。
+	MaxAttempts int
+	// Backoff 第一次重试前的等待，之后每次翻倍，不超过 MaxBackoff，实际等待在 [d/2, d) 中随机。
+	// 接收方返回 Retry-After 时以它为准（同样不超过 MaxBackoff）。默认 500ms 和 30s。
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Now        func() time.Time
+}
+
+// Webhook 把消息签名后 POST 到 URL。
+type Webhook struct {
+	opts WebhookOptions
+}
+
+func NewWebhook(opts WebhookOptions) *Webhook {
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 4
+	}
+	if opts.Backoff <= 0 {
+		opts.Backoff = 500 * time.Millisecond
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 30 * time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Webhook{opts: opts}
+}
+
+func (w *Webhook) Send(ctx context.Context, msg Message) error {
+	id := newID()
+	body, err := json.Marshal(WebhookPayload{
+		ID:      id,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		SentAt:  w.opts.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	backoff := w.opts.Backoff
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := w.post(ctx, id, body)
+		if err == nil {
+			return nil
+		}
+		var status *StatusError
+		if errors.As(err, &status) && !retryable(status.Code) || attempt == w.opts.MaxAttempts || ctx.Err() != nil {
+			return fmt.Errorf("notify: webhook failed after %d attempts: %w", attempt, err)
+		}
+
+		wait := backoff/2 + rand.N(backoff-backoff/2)
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		backoff = min(backoff*2, w.opts.MaxBackoff)
+		timer := time.NewTimer(min(wait, w.opts.MaxBackoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("notify: webhook failed after %d attempts: %w", attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+// post 发送一次，每次重新签名以使用当前时间戳。返回接收方要求的 Retry-After。
+func (w *Webhook) post(ctx context.Context, id string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	ts := strconv.FormatInt(w.opts.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, id)
+	req.Header.Set(HeaderWebhookTimestamp, ts)
+	req.Header.Set(HeaderWebhookSignature, "sha256="+hex.EncodeToString(sign(w.opts.Secret, ts, body)))
+
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	// 读完正文才能复用连接，但不为一个出错的接收方读任意多的数据
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+	var retryAfter time.Duration
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		retryAfter = time.Duration(secs) * time.Second
+	}
+	return retryAfter, &StatusError{resp.StatusCode}
+}
+
+func retryable(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= 500
+}
+
+func sign(secret []byte, ts string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// VerifyWebhook 供接收方使用：读取请求正文，检查签名和时间戳（与 now 相差不超过 tolerance），
+// 通过时返回正文。签名用常数时间比较。
+func VerifyWebhook(r *http.Request, secret []byte, tolerance time.Duration, now time.Time) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	ts := r.Header.Get(HeaderWebhookTimestamp)
+	sig, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(HeaderWebhookSignature), "sha256="))
+	if err != nil || !hmac.Equal(sig, sign(secret, ts, body)) {
+		return nil, ErrBadSignature
+	}
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	if d := now.Sub(time.Unix(secs, 0)); d > tolerance || d < -tolerance {
+		return nil, ErrStaleWebhook
+	}
+	return body, nil
+}
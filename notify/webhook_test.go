@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("webhook-secret")
+
+// receiver 校验每个请求的签名，按 statuses 依次返回状态码，用完后返回 200。
+type receiver struct {
+	t        *testing.T
+	statuses []int
+	header   http.Header // 非 2xx 响应附带的头
+
+	mu       sync.Mutex
+	payloads []WebhookPayload
+}
+
+func (h *receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := VerifyWebhook(r, testSecret, time.Minute, time.Now())
+	if err != nil {
+		h.t.Errorf("VerifyWebhook: %v", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var p WebhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		h.t.Errorf("payload: %v", err)
+	}
+	if r.Header.Get(HeaderWebhookID) != p.ID {
+		h.t.Errorf("%s = %q, payload id %q", HeaderWebhookID, r.Header.Get(HeaderWebhookID), p.ID)
+	}
+	h.mu.Lock()
+	h.payloads = append(h.payloads, p)
+	n := len(h.payloads)
+	h.mu.Unlock()
+	if n <= len(h.statuses) {
+		for k, v := range h.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(h.statuses[n-1])
+	}
+}
+
+func (h *receiver) requests() []WebhookPayload {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]WebhookPayload(nil), h.payloads...)
+}
+
+func newTestWebhook(t *testing.T, h http.Handler, opts WebhookOptions) *Webhook {
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	opts.URL = server.URL
+	if opts.Secret == nil {
+		opts.Secret = testSecret
+	}
+	if opts.Backoff == 0 {
+		opts.Backoff = time.Millisecond
+	}
+	return NewWebhook(opts)
+}
+
+func TestWebhookRetry(t *testing.T) {
+	h := &receiver{t: t, statuses: []int{503, 500, 429, 408}}
+	w := newTestWebhook(t, h, WebhookOptions{MaxAttempts: 5})
+	msg := Message{To: "+1 555 0100", Subject: "code", Body: "123456"}
+	if err := w.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	got := h.requests()
+	if len(got) != 5 {
+		t.Fatalf("%d requests, want 5", len(got))
+	}
+	// 重试时 ID 和正文不变，接收方可以去重
+	for _, p := range got {
+		if p.ID != got[0].ID || p.To != msg.To || p.Subject != msg.Subject || p.Body != msg.Body {
+			t.Fatalf("payload %+v differs from the first %+v", p, got[0])
+		}
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		status   int
+		attempts int
+	}{
+		{"server error", http.StatusBadGateway, 3},
+		{"unauthorized", http.StatusUnauthorized, 1},
+		{"bad request", http.StatusBadRequest, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &receiver{t: t, statuses: []int{tc.status, tc.status, tc.status}}
+			w := newTestWebhook(t, h, WebhookOptions{MaxAttempts: 3})
+			err := w.Send(context.Background(), Message{Body: "x"})
+			var status *StatusError
+			if !errors.As(err, &status) || status.Code != tc.status {
+				t.Fatalf("Send = %v, want a StatusError with code %d", err, tc.status)
+			}
+			if n := len(h.requests()); n != tc.attempts {
+				t.Fatalf("%d requests, want %d", n, tc.attempts)
+			}
+		})
+	}
+}
+
+// TestWebhookRetryAfter Retry-After 优先于指数退避，但不超过 MaxBackoff。
+func TestWebhookRetryAfter(t *testing.T) {
+	const maxBackoff = 50 * time.Millisecond
+	h := &receiver{t: t, statuses: []int{http.StatusTooManyRequests}, header: http.Header{"Retry-After": {"3600"}}}
+	w := newTestWebhook(t, h, WebhookOptions{MaxBackoff: maxBackoff})
+	start := time.Now()
+	if err := w.Send(context.Background(), Message{Body: "x"}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < maxBackoff || elapsed > 10*time.Second {
+		t.Fatalf("Send took %v, want about %v", elapsed, maxBackoff)
+	}
+	if n := len(h.requests()); n != 2 {
+		t.Fatalf("%d requests, want 2", n)
+	}
+}
+
+func TestWebhookCancel(t *testing.T) {
+	h := &receiver{t: t, statuses: []int{503, 503, 503}}
+	w := newTestWebhook(t, h, WebhookOptions{Backoff: time.Hour, MaxBackoff: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := w.Send(ctx, Message{Body: "x"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send = %v, want context.DeadlineExceeded", err)
+	}
+	if n := len(h.requests()); n != 1 {
+		t.Fatalf("%d requests, want 1", n)
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"1"}`)
+	// request 构造签名请求，signed 是参与签名的正文，sent 是实际发出的正文
+	request := func(secret []byte, ts time.Time, signed, sent []byte) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(sent))
+		s := strconv.FormatInt(ts.Unix(), 10)
+		r.Header.Set(HeaderWebhookTimestamp, s)
+		r.Header.Set(HeaderWebhookSignature, "sha256="+hex.EncodeToString(sign(secret, s, signed)))
+		return r
+	}
+
+	got, err := VerifyWebhook(request(testSecret, now, body, body), testSecret, time.Minute, now.Add(time.Minute))
+	if err != nil || !bytes.Equal(got, body) {
+		t.Fatalf("VerifyWebhook = %q, %v", got, err)
+	}
+	retimed := request(testSecret, now, body, body)
+	retimed.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(now.Unix()+1, 10))
+	unsigned := request(testSecret, now, body, body)
+	unsigned.Header.Del(HeaderWebhookSignature)
+	for _, tc := range []struct {
+		name string
+		r    *http.Request
+		want error
+	}{
+		{"wrong secret", request([]byte("other"), now, body, body), ErrBadSignature},
+		{"tampered body", request(testSecret, now, body, []byte(`{"id":"2"}`)), ErrBadSignature},
+		{"tampered timestamp", retimed, ErrBadSignature},
+		{"no signature", unsigned, ErrBadSignature},
+		{"stale", request(testSecret, now.Add(-time.Minute-time.Second), body, body), ErrStaleWebhook},
+		{"future", request(testSecret, now.Add(time.Minute+time.Second), body, body), ErrStaleWebhook},
+	} {
+		if _, err := VerifyWebhook(tc.r, testSecret, time.Minute, now); err != tc.want {
+			t.Errorf("%s: VerifyWebhook = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package otp
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// RFC 4226 附录 D 的测试向量。
+func TestHOTP(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range want {
+		if got, err := HOTP(secret, uint64(counter), 6); got != code || err != nil {
+			t.Errorf("HOTP(%d) = %s, %v, want %s", counter, got, err, code)
+		}
+	}
+}
+
+// RFC 6238 附录 B 中 SHA-1 的测试向量。
+func TestTOTP(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	for _, tc := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	} {
+		now := time.Unix(tc.unix, 0)
+		if got, err := TOTP(secret, now, 30*time.Second, 8); got != tc.code || err != nil {
+			t.Errorf("TOTP(%d) = %s, %v, want %s", tc.unix, got, err, tc.code)
+		}
+		if ok, err := ValidateTOTP(secret, tc.code, now.Add(30*time.Second), 30*time.Second, 8, 1); !ok || err != nil {
+			t.Errorf("ValidateTOTP rejected %s one step later: %v", tc.code, err)
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	for _, step := range []time.Duration{0, time.Millisecond, time.Second - 1, -time.Minute} {
+		if _, err := TOTP([]byte("secret"), time.Unix(0, 0), step, 6); !errors.Is(err, ErrStep) {
+			t.Errorf("TOTP with step %v = %v, want ErrStep", step, err)
+		}
+	}
+	for _, digits := range []int{0, -1, 10} {
+		if _, err := Random(digits); !errors.Is(err, ErrDigits) {
+			t.Errorf("Random(%d) = %v, want ErrDigits", digits, err)
+		}
+		if _, err := HOTP([]byte("secret"), 0, digits); !errors.Is(err, ErrDigits) {
+			t.Errorf("HOTP with %d digits = %v, want ErrDigits", digits, err)
+		}
+		if _, err := ValidateTOTP([]byte("secret"), "", time.Unix(0, 0), 30*time.Second, digits, 1); !errors.Is(err, ErrDigits) {
+			t.Errorf("ValidateTOTP with %d digits = %v, want ErrDigits", digits, err)
+		}
+	}
+}
+
+func TestStoreDiscard(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewStore(StoreOptions{ResendInterval: time.Minute, Now: func() time.Time { return now }})
+	if err := s.Save("k", "111111"); err != nil {
+		t.Fatal(err)
+	}
+	var throttle *ThrottleError
+	if err := s.Save("k", "222222"); !errors.As(err, &throttle) || throttle.RetryAfter != time.Minute {
+		t.Fatalf("second Save = %v, want a throttle error with RetryAfter 1m", err)
+	}
+	// 发送失败后撤销，可以立即重发
+	s.Discard("k", "111111")
+	if err := s.Save("k", "222222"); err != nil {
+		t.Fatalf("Save after Discard = %v", err)
+	}
+	// 撤销旧的验证码不影响新保存的
+	s.Discard("k", "111111")
+	if err := s.Verify("k", "222222"); err != nil {
+		t.Fatalf("Verify = %v, Discard removed a newer code", err)
+	}
+}
+
+// TestStoreThrottleAfterBurn 输错次数用完或过期后验证码作废，但在重发间隔内仍然不能重发。
+func TestStoreThrottleAfterBurn(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewStore(StoreOptions{TTL: 30 * time.Second, MaxAttempts: 2, ResendInterval: time.Minute, Now: func() time.Time { return now }})
+	if err := s.Save("k", "111111"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Verify("k", "000000"); err != ErrMismatch {
+		t.Fatalf("first wrong guess = %v, want ErrMismatch", err)
+	}
+	if err := s.Verify("k", "000000"); err != ErrTooManyAttempts {
+		t.Fatalf("second wrong guess = %v, want ErrTooManyAttempts", err)
+	}
+	// 作废后正确的验证码也不再通过
+	if err := s.Verify("k", "111111"); err != ErrTooManyAttempts {
+		t.Fatalf("right code after the attempts ran out = %v, want ErrTooManyAttempts", err)
+	}
+	var throttle *ThrottleError
+	if err := s.Save("k", "222222"); !errors.As(err, &throttle) || throttle.RetryAfter != time.Minute {
+		t.Fatalf("Save right after the attempts ran out = %v, want a throttle error with RetryAfter 1m", err)
+	}
+
+	// TTL 比重发间隔短：过期后 Verify 报告过期，仍要等到重发间隔结束
+	now = now.Add(40 * time.Second)
+	if err := s.Verify("k", "111111"); err != ErrExpired {
+		t.Fatalf("Verify after the TTL = %v, want ErrExpired", err)
+	}
+	if err := s.Save("k", "222222"); !errors.As(err, &throttle) || throttle.RetryAfter != 20*time.Second {
+		t.Fatalf("Save after the TTL = %v, want a throttle error with RetryAfter 20s", err)
+	}
+	now = now.Add(20 * time.Second)
+	if err := s.Save("k", "222222"); err != nil {
+		t.Fatalf("Save after the resend interval = %v", err)
+	}
+	if err := s.Verify("k", "222222"); err != nil {
+		t.Fatalf("Verify the new code = %v", err)
+	}
+	// 验证成功后可以立即重发，条目也已删除
+	if err := s.Save("k", "333333"); err != nil || s.Len() != 1 {
+		t.Fatalf("Save after a successful Verify = %v, Len %d", err, s.Len())
+	}
+}
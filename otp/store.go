@@ -0,0 +1,146 @@
+package otp
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound        = errors.New("otp: no code issued")
+	ErrExpired         = errors.New("otp: code expired")
+	ErrMismatch        = errors.New("otp: wrong code")
+	ErrTooManyAttempts = errors.New("otp: too many attempts")
+	ErrThrottled       = errors.New("otp: resend too soon")
+)
+
+// ThrottleError 重发过于频繁，errors.Is(err, ErrThrottled) 为 true。
+type ThrottleError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottleError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", ErrThrottled, e.RetryAfter)
+}
+
+func (e *ThrottleError) Unwrap() error {
+	return ErrThrottled
+}
+
+type StoreOptions struct {
+	TTL            time.Duration    // 验证码有效期，默认 5 分钟
+	MaxAttempts    int              // 最多输错几次，之后验证码作废，默认 5
+	ResendInterval time.Duration    // 同一个 key 两次发码的最小间隔，默认 1 分钟
+	Now            func() time.Time // 默认 time.Now
+}
+
+// Store 保存已发出的验证码，只存 SHA-256 摘要。验证成功后验证码立即作废。
+type Store struct {
+	mu        sync.Mutex
+	opts      StoreOptions
+	entries   map[string]*entry
+	lastPrune time.Time
+}
+
+type entry struct {
+	digest   [sha256.Size]byte
+	issued   time.Time
+	attempts int
+	// burned 输错次数用完后作废，清空摘要但保留条目，直到 prune 清理前都计入重发限流
+	burned bool
+}
+
+func NewStore(opts StoreOptions) *Store {
+	if opts.TTL <= 0 {
+		opts.TTL = 5 * time.Minute
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+	if opts.ResendInterval <= 0 {
+		opts.ResendInterval = time.Minute
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Store{opts: opts, entries: map[string]*entry{}}
+}
+
+// Save 为 key 保存新的验证码，替换旧的。距上次保存不足 ResendInterval 时返回 *ThrottleError。
+func (s *Store) Save(key, code string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.opts.Now()
+	s.prune(now)
+	if e, ok := s.entries[key]; ok {
+		if wait := e.issued.Add(s.opts.ResendInterval).Sub(now); wait > 0 {
+			return &ThrottleError{wait}
+		}
+	}
+	s.entries[key] = &entry{digest: sha256.Sum256([]byte(code)), issued: now}
+	return nil
+}
+
+// Discard 撤销一次 Save，用于验证码没能发出的情况：删除验证码并解除重发限流。
+// 只有 key 当前保存的仍是 code 时才删除，不会误删之后保存的新验证码。
+func (s *Store) Discard(key, code string) {
+	digest := sha256.Sum256([]byte(code))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.entries[key]; ok && e.digest == digest {
+		delete(s.entries, key)
+	}
+}
+
+// Verify 检查 code，成功或输错次数用完后验证码作废。验证成功后可以立即重发；
+// 过期或输错次数用完的条目保留到重发间隔结束，否则输错几次就能绕过重发限流。
+func (s *Store) Verify(key, code string) error {
+	digest := sha256.Sum256([]byte(code))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok {
+		return ErrNotFound
+	}
+	if !s.opts.Now().Before(e.issued.Add(s.opts.TTL)) {
+		return ErrExpired
+	}
+	if e.burned {
+		return ErrTooManyAttempts
+	}
+	if subtle.ConstantTimeCompare(digest[:], e.digest[:]) == 1 {
+		delete(s.entries, key)
+		return nil
+	}
+	e.attempts++
+	if e.attempts >= s.opts.MaxAttempts {
+		e.digest, e.burned = [sha256.Size]byte{}, true
+		return ErrTooManyAttempts
+	}
+	return ErrMismatch
+}
+
+// Len 当前保存的验证码数，包括已过期或作废但还没清理的。
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// prune 每过一个 TTL 清理一次过期的验证码，摊还下来每次 Save 是 O(1)。
+// 过期后仍在重发间隔内的条目保留，以免过期立刻重发绕过限流。
+func (s *Store) prune(now time.Time) {
+	if now.Sub(s.lastPrune) < s.opts.TTL {
+		return
+	}
+	s.lastPrune = now
+	keep := max(s.opts.TTL, s.opts.ResendInterval)
+	for k, e := range s.entries {
+		if !now.Before(e.issued.Add(keep)) {
+			delete(s.entries, k)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+/**
+ * 前缀和与差分数组。
+ * 前缀和支持 O(1) 区间求和，差分数组支持 O(1) 区间加，两者互为逆运算。
+ */
+package prefixsum
+
+// Prefix 一维前缀和，sum[i] 为 nums[0:i] 的和。
+type Prefix struct {
+	sum []int
+}
+
+func New(nums []int) *Prefix {
+	sum := make([]int, len(nums)+1)
+	for i, v := range nums {
+		sum[i+1] = sum[i] + v
+	}
+	return &Prefix{sum: sum}
+}
+
+func (p *Prefix) Len() int {
+	return len(p.sum) - 1
+}
+
+// Sum 返回闭区间 [left, right] 的和。
+func (p *Prefix) Sum(left, right int) int {
+	return p.sum[right+1] - p.sum[left]
+}
+
+// Prefix2D 二维前缀和，sum[i][j] 为左上角 i 行 j 列子矩阵的和。
+type Prefix2D struct {
+	sum [][]int
+}
+
+func New2D(matrix [][]int) *Prefix2D {
+	m := len(matrix)
+	n := 0
+	if m > 0 {
+		n = len(matrix[0])
+	}
+	sum := make([][]int, m+1)
+	for i := range sum {
+		sum[i] = make([]int, n+1)
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			sum[i+1][j+1] = sum[i][j+1] + sum[i+1][j] - sum[i][j] + matrix[i][j]
+		}
+	}
+	return &Prefix2D{sum: sum}
+}
+
+// SumRegion 返回左上角 (row1, col1) 到右下角 (row2, col2) 子矩阵的和。
+func (p *Prefix2D) SumRegion(row1, col1, row2, col2 int) int {
+	s := p.sum
+	return s[row2+1][col2+1] - s[row1][col2+1] - s[row2+1][col1] + s[row1][col1]
+}
+
+// Diff 差分数组，diff[i] = nums[i] - nums[i-1]。
+type Diff struct {
+	diff []int
+}
+
+// NewDiff 以 nums 为初始值创建差分数组。
+func NewDiff(nums []int) *Diff {
+	diff := make([]int, len(nums)+1)
+	prev := 0
+	for i, v := range nums {
+		diff[i] = v - prev
+		prev = v
+	}
+	return &Diff{diff: diff}
+}
+
+// Add 给闭区间 [left, right] 的每个元素加上 val。
+func (d *Diff) Add(left, right, val int) {
+	d.diff[left] += val
+	d.diff[right+1] -= val
+}
+
+// Result 还原出当前数组。
+func (d *Diff) Result() []int {
+	res := make([]int, len(d.diff)-1)
+	cur := 0
+	for i := range res {
+		cur += d.diff[i]
+		res[i] = cur
+	}
+	return res
+}
+
+// Diff2D 二维差分数组。
+type Diff2D struct {
+	diff [][]int
+}
+
+func NewDiff2D(m, n int) *Diff2D {
+	diff := make([][]int, m+1)
+	for i := range diff {
+		diff[i] = make([]int, n+1)
+	}
+	return &Diff2D{diff: diff}
+}
+
+// Add 给左上角 (row1, col1) 到右下角 (row2, col2) 子矩阵的每个元素加上 val。
+func (d *Diff2D) Add(row1, col1, row2, col2, val int) {
+	d.diff[row1][col1] += val
+	d.diff[row1][col2+1] -= val
+	d.diff[row2+1][col1] -= val
+	d.diff[row2+1][col2+1] += val
+}
+
+func (d *Diff2D) Result() [][]int {
+	m, n := len(d.diff)-1, len(d.diff[0])-1
+	res := make([][]int, m)
+	for i := 0; i < m; i++ {
+		res[i] = make([]int, n)
+		for j := 0; j < n; j++ {
+			res[i][j] = d.diff[i][j]
+			if i > 0 {
+				res[i][j] += res[i-1][j]
+			}
+			if j > 0 {
+				res[i][j] += res[i][j-1]
+			}
+			if i > 0 && j > 0 {
+				res[i][j] -= res[i-1][j-1]
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,226 @@
+package prefixsum
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func randInts(r *rand.Rand, n, lo, hi int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = lo + r.Intn(hi-lo+1)
+	}
+	return res
+}
+
+func randMatrix(r *rand.Rand, m, n int) [][]int {
+	res := make([][]int, m)
+	for i := range res {
+		res[i] = randInts(r, n, -9, 9)
+	}
+	return res
+}
+
+func TestPrefix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 200 {
+		nums := randInts(r, r.Intn(10), -9, 9)
+		p := New(nums)
+		if p.Len() != len(nums) {
+			t.Fatalf("Len() = %d, want %d", p.Len(), len(nums))
+		}
+		for left := range nums {
+			want := 0
+			for right := left; right < len(nums); right++ {
+				want += nums[right]
+				if got := p.Sum(left, right); got != want {
+					t.Fatalf("%v: Sum(%d, %d) = %d, want %d", nums, left, right, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestPrefix2D(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 100 {
+		m, n := 1+r.Intn(5), 1+r.Intn(5)
+		matrix := randMatrix(r, m, n)
+		p := New2D(matrix)
+		for r1 := range m {
+			for c1 := range n {
+				for r2 := r1; r2 < m; r2++ {
+					for c2 := c1; c2 < n; c2++ {
+						want := 0
+						for i := r1; i <= r2; i++ {
+							for j := c1; j <= c2; j++ {
+								want += matrix[i][j]
+							}
+						}
+						if got := p.SumRegion(r1, c1, r2, c2); got != want {
+							t.Fatalf("%v: SumRegion(%d, %d, %d, %d) = %d, want %d", matrix, r1, c1, r2, c2, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 500 {
+		n := 1 + r.Intn(10)
+		want := randInts(r, n, -9, 9)
+		d := NewDiff(want)
+		want = slices.Clone(want)
+		for range r.Intn(6) {
+			left := r.Intn(n)
+			right := left + r.Intn(n-left)
+			val := r.Intn(19) - 9
+			d.Add(left, right, val)
+			for i := left; i <= right; i++ {
+				want[i] += val
+			}
+		}
+		if got := d.Result(); !slices.Equal(got, want) {
+			t.Fatalf("Diff.Result() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDiff2D(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for range 500 {
+		m, n := 1+r.Intn(5), 1+r.Intn(5)
+		d := NewDiff2D(m, n)
+		want := randMatrix(r, m, n)
+		for i := range want {
+			clear(want[i])
+		}
+		for range r.Intn(6) {
+			r1, c1 := r.Intn(m), r.Intn(n)
+			r2, c2 := r1+r.Intn(m-r1), c1+r.Intn(n-c1)
+			val := r.Intn(19) - 9
+			d.Add(r1, c1, r2, c2, val)
+			for i := r1; i <= r2; i++ {
+				for j := c1; j <= c2; j++ {
+					want[i][j] += val
+				}
+			}
+		}
+		got := d.Result()
+		for i := range want {
+			if !slices.Equal(got[i], want[i]) {
+				t.Fatalf("Diff2D.Result() = %v, want %v", got, want)
+			}
+		}
+	}
+}
+
+func TestCorpFlightBookings(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for range 500 {
+		n := 1 + r.Intn(8)
+		want := make([]int, n)
+		var bookings [][]int
+		for range r.Intn(6) {
+			first := 1 + r.Intn(n)
+			last := first + r.Intn(n-first+1)
+			seats := 1 + r.Intn(10)
+			bookings = append(bookings, []int{first, last, seats})
+			for i := first; i <= last; i++ {
+				want[i-1] += seats
+			}
+		}
+		if got := CorpFlightBookings(bookings, n); !slices.Equal(got, want) {
+			t.Fatalf("CorpFlightBookings(%v, %d) = %v, want %v", bookings, n, got, want)
+		}
+	}
+}
+
+func TestProductExceptSelf(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for range 500 {
+		nums := randInts(r, 2+r.Intn(8), -3, 3)
+		got := ProductExceptSelf(nums)
+		for i := range nums {
+			want := 1
+			for j, v := range nums {
+				if j != i {
+					want *= v
+				}
+			}
+			if got[i] != want {
+				t.Fatalf("ProductExceptSelf(%v)[%d] = %d, want %d", nums, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestTrap(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for range 1000 {
+		height := randInts(r, r.Intn(12), 0, 6)
+		want := 0
+		for i, h := range height {
+			left, right := slices.Max(height[:i+1]), slices.Max(height[i:])
+			want += min(left, right) - h
+		}
+		if got := Trap(height); got != want {
+			t.Fatalf("Trap(%v) = %d, want %d", height, got, want)
+		}
+	}
+}
+
+// completes 从 start 出发能否绕一圈。
+func completes(gas, cost []int, start int) bool {
+	tank := 0
+	for k := range gas {
+		i := (start + k) % len(gas)
+		tank += gas[i] - cost[i]
+		if tank < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCanCompleteCircuit(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for range 2000 {
+		n := 1 + r.Intn(7)
+		gas, cost := randInts(r, n, 0, 5), randInts(r, n, 0, 5)
+		possible := false
+		for s := range n {
+			possible = possible || completes(gas, cost, s)
+		}
+		got := CanCompleteCircuit(gas, cost)
+		if got == -1 && possible || got != -1 && !completes(gas, cost, got) {
+			t.Fatalf("CanCompleteCircuit(%v, %v) = %d, possible: %v", gas, cost, got, possible)
+		}
+	}
+}
+
+func TestHIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for range 2000 {
+		citations := randInts(r, 1+r.Intn(8), 0, 10)
+		want := 0
+		for h := 1; h <= len(citations); h++ {
+			papers := 0
+			for _, c := range citations {
+				if c >= h {
+					papers++
+				}
+			}
+			if papers >= h {
+				want = h
+			}
+		}
+		if got := HIndex(citations); got != want {
+			t.Fatalf("HIndex(%v) = %d, want %d", citations, got, want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package prefixsum
+
+/**
+ * 303. 区域和检索 - 数组不可变
+ * https://leetcode.cn/problems/range-sum-query-immutable/
+ */
+type NumArray struct {
+	p *Prefix
+}
+
+func Constructor(nums []int) NumArray {
+	return NumArray{p: New(nums)}
+}
+
+func (na *NumArray) SumRange(left, right int) int {
+	return na.p.Sum(left, right)
+}
+
+/**
+ * 304. 二维区域和检索 - 矩阵不可变
+ * https://leetcode.cn/problems/range-sum-query-2d-immutable/
+ */
+type NumMatrix struct {
+	p *Prefix2D
+}
+
+func ConstructorMatrix(matrix [][]int) NumMatrix {
+	return NumMatrix{p: New2D(matrix)}
+}
+
+func (nm *NumMatrix) SumRegion(row1, col1, row2, col2 int) int {
+	return nm.p.SumRegion(row1, col1, row2, col2)
+}
+
+/**
+ * 1109. 航班预订统计
+ * https://leetcode.cn/problems/corporate-flight-bookings/
+ */
+func CorpFlightBookings(bookings [][]int, n int) []int {
+	d := NewDiff(make([]int, n))
+	for _, b := range bookings {
+		d.Add(b[0]-1, b[1]-1, b[2])
+	}
+	return d.Result()
+}
+
+/**
+ * 238. 除自身以外数组的乘积
+ * https://leetcode.cn/problems/product-of-array-except-self/
+ * 前缀积乘以后缀积，后缀积用一个变量滚动。
+ */
+func ProductExceptSelf(nums []int) []int {
+	n := len(nums)
+	res := make([]int, n)
+	pre := 1
+	for i := 0; i < n; i++ {
+		res[i] = pre
+		pre *= nums[i]
+	}
+	suf := 1
+	for i := n - 1; i >= 0; i-- {
+		res[i] *= suf
+		suf *= nums[i]
+	}
+	return res
+}
+
+/**
+ * 42. 接雨水
+ * https://leetcode.cn/problems/trapping-rain-water/
+ * 每个位置能接的水由前缀最大值和后缀最大值中较小的一个决定。
+ */
+func Trap(height []int) int {
+	n := len(height)
+	sufMax := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		sufMax[i] = max(sufMax[i+1], height[i])
+	}
+
+	res, preMax := 0, 0
+	for i, h := range height {
+		preMax = max(preMax, h)
+		res += min(preMax, sufMax[i]) - h
+	}
+	return res
+}
+
+/**
+ * 134. 加油站
+ * https://leetcode.cn/problems/gas-station/
+ * 净油量前缀和最低点的下一个站就是起点。
+ */
+func CanCompleteCircuit(gas []int, cost []int) int {
+	sum, minSum, start := 0, 0, 0
+	for i := range gas {
+		sum += gas[i] - cost[i]
+		if sum < minSum {
+			minSum = sum
+			start = i + 1
+		}
+	}
+	if sum < 0 {
+		return -1
+	}
+	return start % len(gas)
+}
+
+/**
+ * 274. H 指数
+ * https://leetcode.cn/problems/h-index/
+ * 按引用次数计数，再从大到小累加后缀和。
+ */
+func HIndex(citations []int) int {
+	n := len(citations)
+	cnt := make([]int, n+1)
+	for _, c := range citations {
+		cnt[min(c, n)]++
+	}
+	papers := 0
+	for h := n; h > 0; h-- {
+		papers += cnt[h]
+		if papers >= h {
+			return h
+		}
+	}
+	return 0
+}
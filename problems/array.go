@@ -0,0 +1,185 @@
+package problems
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"leetcode-go/prefixsum"
+	"leetcode-go/registry"
+	"leetcode-go/slicesx"
+)
+
+func removeElement(nums []int, val int) int {
+	return len(slicesx.Remove(nums, val))
+}
+
+func removeDuplicates(nums []int) int {
+	return len(slicesx.DedupSortedAtMostK(nums, 1))
+}
+
+func removeDuplicatesII(nums []int) int {
+	return len(slicesx.DedupSortedAtMostK(nums, 2))
+}
+
+func rotate(nums []int, k int) {
+	slicesx.Rotate(nums, k)
+}
+
+// merge 从后往前双指针，原地把 nums2 合并进 nums1，nums1 尾部留有 n 个空位。
+func merge(nums1 []int, m int, nums2 []int, n int) {
+	i, j := m-1, n-1
+	for k := m + n - 1; j >= 0; k-- {
+		if i >= 0 && nums1[i] > nums2[j] {
+			nums1[k] = nums1[i]
+			i--
+		} else {
+			nums1[k] = nums2[j]
+			j--
+		}
+	}
+}
+
+// maxProfit 一次买卖：记录之前的最低价。
+func maxProfit(prices []int) int {
+	res, low := 0, math.MaxInt
+	for _, p := range prices {
+		low = min(low, p)
+		res = max(res, p-low)
+	}
+	return res
+}
+
+// maxProfitII 不限次数：收下每一段上涨。
+func maxProfitII(prices []int) int {
+	res := 0
+	for i := 1; i < len(prices); i++ {
+		res += max(prices[i]-prices[i-1], 0)
+	}
+	return res
+}
+
+// candy 先从左往右满足左规则，再从右往左用一个变量维护右规则。
+func candy(ratings []int) int {
+	n := len(ratings)
+	left := make([]int, n)
+	for i := range ratings {
+		left[i] = 1
+		if i > 0 && ratings[i] > ratings[i-1] {
+			left[i] = left[i-1] + 1
+		}
+	}
+	res, right := 0, 0
+	for i := n - 1; i >= 0; i-- {
+		if i < n-1 && ratings[i] > ratings[i+1] {
+			right++
+		} else {
+			right = 1
+		}
+		res += max(left[i], right)
+	}
+	return res
+}
+
+// RandomizedSet 数组保存元素，哈希表保存下标；删除时把最后一个元素换到被删的位置。
+type RandomizedSet struct {
+	nums    []int
+	indices map[int]int
+}
+
+func newRandomizedSet() RandomizedSet {
+	return RandomizedSet{indices: map[int]int{}}
+}
+
+func (rs *RandomizedSet) Insert(val int) bool {
+	if _, ok := rs.indices[val]; ok {
+		return false
+	}
+	rs.indices[val] = len(rs.nums)
+	rs.nums = append(rs.nums, val)
+	return true
+}
+
+func (rs *RandomizedSet) Remove(val int) bool {
+	i, ok := rs.indices[val]
+	if !ok {
+		return false
+	}
+	last := len(rs.nums) - 1
+	rs.nums[i] = rs.nums[last]
+	rs.indices[rs.nums[i]] = i
+	rs.nums = rs.nums[:last]
+	delete(rs.indices, val)
+	return true
+}
+
+func (rs *RandomizedSet) GetRandom() int {
+	return rs.nums[rand.IntN(len(rs.nums))]
+}
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 88, Slug: "merge-sorted-array", Title: "合并两个有序数组",
+		Difficulty: registry.Easy, Topics: []string{"数组", "双指针", "排序"},
+		Solution: merge,
+	})
+	registry.Register(registry.Problem{
+		ID: 27, Slug: "remove-element", Title: "移除元素",
+		Difficulty: registry.Easy, Topics: []string{"数组", "双指针"},
+		Solution: removeElement,
+	})
+	registry.Register(registry.Problem{
+		ID: 26, Slug: "remove-duplicates-from-sorted-array", Title: "删除有序数组中的重复项",
+		Difficulty: registry.Easy, Topics: []string{"数组", "双指针"},
+		Solution: removeDuplicates,
+	})
+	registry.Register(registry.Problem{
+		ID: 80, Slug: "remove-duplicates-from-sorted-array-ii", Title: "删除有序数组中的重复项 II",
+		Difficulty: registry.Medium, Topics: []string{"数组", "双指针"},
+		Solution: removeDuplicatesII,
+	})
+	registry.Register(registry.Problem{
+		ID: 189, Slug: "rotate-array", Title: "轮转数组",
+		Difficulty: registry.Medium, Topics: []string{"数组", "数学", "双指针"},
+		Solution: rotate,
+	})
+	registry.Register(registry.Problem{
+		ID: 274, Slug: "h-index", Title: "H 指数",
+		Difficulty: registry.Medium, Topics: []string{"数组", "计数排序"},
+		Solution: prefixsum.HIndex,
+	})
+	registry.Register(registry.Problem{
+		ID: 238, Slug: "product-of-array-except-self", Title: "除自身以外数组的乘积",
+		Difficulty: registry.Medium, Topics: []string{"数组", "前缀和"},
+		Solution: prefixsum.ProductExceptSelf,
+	})
+	registry.Register(registry.Problem{
+		ID: 134, Slug: "gas-station", Title: "加油站",
+		Difficulty: registry.Medium, Topics: []string{"贪心", "数组"},
+		Solution: prefixsum.CanCompleteCircuit,
+	})
+	registry.Register(registry.Problem{
+		ID: 42, Slug: "trapping-rain-water", Title: "接雨水",
+		Difficulty: registry.Hard, Topics: []string{"数组", "双指针", "动态规划"},
+		Solution: prefixsum.Trap,
+	})
+	registry.Register(registry.Problem{
+		ID: 121, Slug: "best-time-to-buy-and-sell-stock", Title: "买卖股票的最佳时机",
+		Difficulty: registry.Easy, Topics: []string{"数组", "动态规划"},
+		Solution: maxProfit,
+	})
+	registry.Register(registry.Problem{
+		ID: 122, Slug: "best-time-to-buy-and-sell-stock-ii", Title: "买卖股票的最佳时机 II",
+		Difficulty: registry.Medium, Topics: []string{"贪心", "数组", "动态规划"},
+		Solution: maxProfitII,
+	})
+	registry.Register(registry.Problem{
+		ID: 135, Slug: "candy", Title: "分发糖果",
+		Difficulty: registry.Hard, Topics: []string{"贪心", "数组"},
+		Solution: candy,
+	})
+	registry.Register(registry.Problem{
+		ID: 380, Slug: "insert-delete-getrandom-o1", Title: "O(1) 时间插入、删除和获取随机元素",
+		Difficulty: registry.Medium, Topics: []string{"设计", "数组", "哈希表", "数学", "随机化"},
+		Solution: newRandomizedSet,
+	})
+}
@@ -0,0 +1,97 @@
+package problems
+
+import (
+	"math/rand"
+
+	"leetcode-go/dp"
+	"leetcode-go/registry"
+)
+
+// climbStairsRecursive 即 climbing_stairs.go 中不带记忆化的递归，指数级。
+func climbStairsRecursive(n int) int {
+	if n < 2 {
+		return 1
+	}
+	if n == 2 {
+		return 2
+	}
+	return climbStairsRecursive(n-1) + climbStairsRecursive(n-2)
+}
+
+// maxSubArrayKadane 以 nums[i] 结尾的最大子数组和要么接在前一个后面，要么从 nums[i] 重新开始。
+func maxSubArrayKadane(nums []int) int {
+	if len(nums) == 0 {
+		return 0
+	}
+	cur, best := nums[0], nums[0]
+	for _, x := range nums[1:] {
+		cur = max(cur+x, x)
+		best = max(best, cur)
+	}
+	return best
+}
+
+func randInts(r *rand.Rand, n, lo, hi int) []int {
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = lo + r.Intn(hi-lo+1)
+	}
+	return nums
+}
+
+func jump(nums []int) int {
+	steps, _ := dp.Jump(nums)
+	return steps
+}
+
+func lengthOfLIS(nums []int) int {
+	n, _ := dp.LengthOfLIS(nums)
+	return n
+}
+
+func minDistance(word1 string, word2 string) int {
+	n, _ := dp.MinDistance(word1, word2)
+	return n
+}
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 70, Slug: "climbing-stairs", Title: "爬楼梯",
+		Difficulty: registry.Easy, Topics: []string{"记忆化搜索", "数学", "动态规划"},
+		Approaches: []registry.Approach{
+			{Name: "dp", Func: dp.ClimbStairs},
+			{Name: "memo", Func: dp.ClimbStairsMemo},
+			{Name: "recursion", Func: climbStairsRecursive, MaxN: 30},
+		},
+		Gen: func(r *rand.Rand, n int) []any { return []any{n} },
+	})
+	registry.Register(registry.Problem{
+		ID: 55, Slug: "jump-game", Title: "跳跃游戏",
+		Difficulty: registry.Medium, Topics: []string{"贪心", "数组", "动态规划"},
+		Solution: dp.CanJump,
+	})
+	registry.Register(registry.Problem{
+		ID: 45, Slug: "jump-game-ii", Title: "跳跃游戏 II",
+		Difficulty: registry.Medium, Topics: []string{"贪心", "数组", "动态规划"},
+		Solution: jump,
+	})
+	registry.Register(registry.Problem{
+		ID: 53, Slug: "maximum-subarray", Title: "最大子数组和",
+		Difficulty: registry.Medium, Topics: []string{"数组", "分治", "动态规划"},
+		Approaches: []registry.Approach{
+			{Name: "kadane", Func: maxSubArrayKadane},
+			{Name: "divide", Func: dp.MaxSubArray},
+		},
+		Gen: func(r *rand.Rand, n int) []any { return []any{randInts(r, n, -100, 100)} },
+	})
+	registry.Register(registry.Problem{
+		ID: 300, Slug: "longest-increasing-subsequence", Title: "最长递增子序列",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找", "动态规划"},
+		Solution: lengthOfLIS,
+	})
+	registry.Register(registry.Problem{
+		ID: 72, Slug: "edit-distance", Title: "编辑距离",
+		Difficulty: registry.Medium, Topics: []string{"字符串", "动态规划"},
+		Solution: minDistance,
+	})
+}
@@ -0,0 +1,44 @@
+package problems
+
+import (
+	"leetcode-go/ds"
+	"leetcode-go/registry"
+)
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 146, Slug: "lru-cache", Title: "LRU 缓存",
+		Difficulty: registry.Medium, Topics: []string{"设计", "哈希表", "链表", "双向链表"},
+		Solution: ds.Constructor,
+	})
+	registry.Register(registry.Problem{
+		ID: 104, Slug: "maximum-depth-of-binary-tree", Title: "二叉树的最大深度",
+		Difficulty: registry.Easy, Topics: []string{"树", "深度优先搜索", "二叉树"},
+		Solution: ds.MaxDepth,
+	})
+	registry.Register(registry.Problem{
+		ID: 226, Slug: "invert-binary-tree", Title: "翻转二叉树",
+		Difficulty: registry.Easy, Topics: []string{"树", "深度优先搜索", "二叉树"},
+		Solution: ds.InvertTree,
+	})
+	registry.Register(registry.Problem{
+		ID: 236, Slug: "lowest-common-ancestor-of-a-binary-tree", Title: "二叉树的最近公共祖先",
+		Difficulty: registry.Medium, Topics: []string{"树", "深度优先搜索", "二叉树"},
+		Solution: ds.LowestCommonAncestor,
+	})
+	registry.Register(registry.Problem{
+		ID: 206, Slug: "reverse-linked-list", Title: "反转链表",
+		Difficulty: registry.Easy, Topics: []string{"递归", "链表"},
+		Solution: ds.ReverseList,
+	})
+	registry.Register(registry.Problem{
+		ID: 23, Slug: "merge-k-sorted-lists", Title: "合并 K 个升序链表",
+		Difficulty: registry.Hard, Topics: []string{"链表", "分治", "堆（优先队列）"},
+		Solution: ds.MergeKLists,
+	})
+	registry.Register(registry.Problem{
+		ID: 547, Slug: "number-of-provinces", Title: "省份数量",
+		Difficulty: registry.Medium, Topics: []string{"深度优先搜索", "并查集", "图"},
+		Solution: ds.FindCircleNum,
+	})
+}
@@ -0,0 +1,39 @@
+package problems
+
+import (
+	"leetcode-go/graph"
+	"leetcode-go/registry"
+)
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 200, Slug: "number-of-islands", Title: "岛屿数量",
+		Difficulty: registry.Medium, Topics: []string{"深度优先搜索", "广度优先搜索", "并查集", "矩阵"},
+		Solution: graph.NumIslands,
+	})
+	registry.Register(registry.Problem{
+		ID: 130, Slug: "surrounded-regions", Title: "被围绕的区域",
+		Difficulty: registry.Medium, Topics: []string{"深度优先搜索", "广度优先搜索", "并查集", "矩阵"},
+		Solution: graph.Solve,
+	})
+	registry.Register(registry.Problem{
+		ID: 207, Slug: "course-schedule", Title: "课程表",
+		Difficulty: registry.Medium, Topics: []string{"图", "拓扑排序"},
+		Solution: graph.CanFinish,
+	})
+	registry.Register(registry.Problem{
+		ID: 210, Slug: "course-schedule-ii", Title: "课程表 II",
+		Difficulty: registry.Medium, Topics: []string{"图", "拓扑排序"},
+		Solution: graph.FindOrder,
+	})
+	registry.Register(registry.Problem{
+		ID: 743, Slug: "network-delay-time", Title: "网络延迟时间",
+		Difficulty: registry.Medium, Topics: []string{"图", "最短路", "堆（优先队列）"},
+		Solution: graph.NetworkDelayTime,
+	})
+	registry.Register(registry.Problem{
+		ID: 1584, Slug: "min-cost-to-connect-all-points", Title: "连接所有点的最小费用",
+		Difficulty: registry.Medium, Topics: []string{"并查集", "图", "最小生成树"},
+		Solution: graph.MinCostConnectPoints,
+	})
+}
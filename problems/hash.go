@@ -0,0 +1,144 @@
+package problems
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+
+	"leetcode-go/registry"
+)
+
+// twoSum 即 two_sum.go 中的写法：一边遍历一边在哈希表中找 target-num。
+func twoSum(nums []int, target int) []int {
+	seen := make(map[int]int)
+	for i, num := range nums {
+		if j, ok := seen[target-num]; ok {
+			return []int{j, i}
+		}
+		seen[num] = i
+	}
+	return nil
+}
+
+// twoSumSort 按值排序下标后双指针，O(n log n)，不需要哈希表。
+func twoSumSort(nums []int, target int) []int {
+	idx := make([]int, len(nums))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(a, b int) bool { return nums[idx[a]] < nums[idx[b]] })
+
+	i, j := 0, len(idx)-1
+	for i < j {
+		sum := nums[idx[i]] + nums[idx[j]]
+		switch {
+		case sum == target:
+			return []int{min(idx[i], idx[j]), max(idx[i], idx[j])}
+		case sum < target:
+			i++
+		default:
+			j--
+		}
+	}
+	return nil
+}
+
+func twoSumBruteForce(nums []int, target int) []int {
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			if nums[i]+nums[j] == target {
+				return []int{i, j}
+			}
+		}
+	}
+	return nil
+}
+
+// checkTwoSum 答案不唯一，只要两个不同下标的和等于 target 即可。
+func checkTwoSum(args []any, got []any) error {
+	nums, target := args[0].([]int), args[1].(int)
+	res, _ := got[0].([]int)
+	if len(res) != 2 {
+		return fmt.Errorf("want 2 indices")
+	}
+	i, j := res[0], res[1]
+	if i == j || i < 0 || j < 0 || i >= len(nums) || j >= len(nums) {
+		return fmt.Errorf("invalid indices")
+	}
+	if nums[i]+nums[j] != target {
+		return fmt.Errorf("nums[%d]+nums[%d] = %d, want %d", i, j, nums[i]+nums[j], target)
+	}
+	return nil
+}
+
+// majorityElement 计数，超过一半时返回。
+func majorityElement(nums []int) int {
+	cnt := map[int]int{}
+	for _, x := range nums {
+		cnt[x]++
+		if cnt[x] > len(nums)/2 {
+			return x
+		}
+	}
+	return 0
+}
+
+// majorityElementSort 即 majority_element.go 中的写法，排序后中间位置一定是多数元素。
+func majorityElementSort(nums []int) int {
+	slices.Sort(nums)
+	return nums[len(nums)/2]
+}
+
+// majorityElementVote Boyer-Moore 投票：不同的元素两两抵消，剩下的就是多数元素。
+func majorityElementVote(nums []int) int {
+	cand, cnt := 0, 0
+	for _, x := range nums {
+		if cnt == 0 {
+			cand = x
+		}
+		if x == cand {
+			cnt++
+		} else {
+			cnt--
+		}
+	}
+	return cand
+}
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 1, Slug: "two-sum", Title: "两数之和",
+		Difficulty: registry.Easy, Topics: []string{"数组", "哈希表"},
+		Approaches: []registry.Approach{
+			{Name: "hash", Func: twoSum},
+			{Name: "sort", Func: twoSumSort},
+			{Name: "brute-force", Func: twoSumBruteForce, MaxN: 10000},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			nums := randInts(r, max(n, 2), -4*n, 4*n)
+			i := r.Intn(len(nums))
+			j := (i + 1 + r.Intn(len(nums)-1)) % len(nums)
+			return []any{nums, nums[i] + nums[j]}
+		},
+		Check: checkTwoSum,
+	})
+	registry.Register(registry.Problem{
+		ID: 169, Slug: "majority-element", Title: "多数元素",
+		Difficulty: registry.Easy, Topics: []string{"数组", "哈希表", "分治", "计数", "排序"},
+		Approaches: []registry.Approach{
+			{Name: "hash", Func: majorityElement},
+			{Name: "sort", Func: majorityElementSort},
+			{Name: "vote", Func: majorityElementVote},
+		},
+		Gen: func(r *rand.Rand, n int) []any {
+			nums := randInts(r, n, 0, n)
+			// 把随机的一半以上的位置换成同一个数
+			m := r.Intn(n + 1)
+			for _, i := range r.Perm(n)[:n/2+1] {
+				nums[i] = m
+			}
+			return []any{nums}
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package problems
+
+import (
+	"leetcode-go/interval"
+	"leetcode-go/registry"
+)
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 228, Slug: "summary-ranges", Title: "汇总区间",
+		Difficulty: registry.Easy, Topics: []string{"数组"},
+		Solution: interval.SummaryRanges,
+	})
+	registry.Register(registry.Problem{
+		ID: 56, Slug: "merge-intervals", Title: "合并区间",
+		Difficulty: registry.Medium, Topics: []string{"数组", "排序"},
+		Solution: interval.Merge,
+	})
+	registry.Register(registry.Problem{
+		ID: 57, Slug: "insert-interval", Title: "插入区间",
+		Difficulty: registry.Medium, Topics: []string{"数组"},
+		Solution: interval.Insert,
+	})
+	registry.Register(registry.Problem{
+		ID: 452, Slug: "minimum-number-of-arrows-to-burst-balloons", Title: "用最少数量的箭引爆气球",
+		Difficulty: registry.Medium, Topics: []string{"贪心", "数组", "排序"},
+		Solution: interval.FindMinArrowShots,
+	})
+}
@@ -0,0 +1,24 @@
+package problems
+
+import (
+	"leetcode-go/prefixsum"
+	"leetcode-go/registry"
+)
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 303, Slug: "range-sum-query-immutable", Title: "区域和检索 - 数组不可变",
+		Difficulty: registry.Easy, Topics: []string{"数组", "前缀和"},
+		Solution: prefixsum.Constructor,
+	})
+	registry.Register(registry.Problem{
+		ID: 304, Slug: "range-sum-query-2d-immutable", Title: "二维区域和检索 - 矩阵不可变",
+		Difficulty: registry.Medium, Topics: []string{"数组", "矩阵", "前缀和"},
+		Solution: prefixsum.ConstructorMatrix,
+	})
+	registry.Register(registry.Problem{
+		ID: 1109, Slug: "corporate-flight-bookings", Title: "航班预订统计",
+		Difficulty: registry.Medium, Topics: []string{"数组", "前缀和"},
+		Solution: prefixsum.CorpFlightBookings,
+	})
+}
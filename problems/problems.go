@@ -0,0 +1,6 @@
+/**
+ * 把各个库包中的解法登记到 registry，使用方空白导入本包即可：
+ *
+ *	import _ "leetcode-go/problems"
+ */
+package problems
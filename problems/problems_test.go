@@ -0,0 +1,258 @@
+package problems
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"leetcode-go/registry"
+)
+
+func TestRegistered(t *testing.T) {
+	for _, id := range []int{88, 121, 122, 135, 380, 12, 13, 14, 58, 151, 6, 68} {
+		if _, ok := registry.Lookup(id); !ok {
+			t.Errorf("problem %d is not registered", id)
+		}
+	}
+}
+
+func TestRoman(t *testing.T) {
+	seen := map[string]bool{}
+	for num := 1; num <= 3999; num++ {
+		s := intToRoman(num)
+		if seen[s] {
+			t.Fatalf("intToRoman(%d) = %q is not unique", num, s)
+		}
+		seen[s] = true
+		if got := romanToInt(s); got != num {
+			t.Fatalf("romanToInt(intToRoman(%d) = %q) = %d", num, s, got)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		m, n := r.Intn(6), r.Intn(6)
+		a, b := make([]int, m, m+n), make([]int, n)
+		for i := range a {
+			a[i] = r.Intn(10)
+		}
+		for i := range b {
+			b[i] = r.Intn(10)
+		}
+		slices.Sort(a)
+		slices.Sort(b)
+		want := slices.Sorted(slices.Values(append(slices.Clone(a), b...)))
+		nums1 := a[:m+n]
+		merge(nums1, m, b, n)
+		if !slices.Equal(nums1, want) {
+			t.Fatalf("merge(%v, %v) = %v, want %v", a, b, nums1, want)
+		}
+	}
+}
+
+func TestMaxProfit(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for range 1000 {
+		prices := make([]int, r.Intn(8))
+		for i := range prices {
+			prices[i] = r.Intn(10)
+		}
+		want := 0
+		for i := range prices {
+			for j := i + 1; j < len(prices); j++ {
+				want = max(want, prices[j]-prices[i])
+			}
+		}
+		if got := maxProfit(prices); got != want {
+			t.Fatalf("maxProfit(%v) = %d, want %d", prices, got, want)
+		}
+		// 不限次数时，每天最多持有一股的动态规划
+		hold, free := -1<<31, 0
+		for _, p := range prices {
+			hold, free = max(hold, free-p), max(free, hold+p)
+		}
+		if got := maxProfitII(prices); got != free {
+			t.Fatalf("maxProfitII(%v) = %d, want %d", prices, got, free)
+		}
+	}
+}
+
+func TestCandy(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 1000 {
+		ratings := make([]int, 1+r.Intn(8))
+		for i := range ratings {
+			ratings[i] = r.Intn(4)
+		}
+		// 反复修正直到满足规则，得到的就是最少的分配
+		want := make([]int, len(ratings))
+		for i := range want {
+			want[i] = 1
+		}
+		for changed := true; changed; {
+			changed = false
+			for i := range ratings {
+				if i > 0 && ratings[i] > ratings[i-1] && want[i] <= want[i-1] {
+					want[i], changed = want[i-1]+1, true
+				}
+				if i+1 < len(ratings) && ratings[i] > ratings[i+1] && want[i] <= want[i+1] {
+					want[i], changed = want[i+1]+1, true
+				}
+			}
+		}
+		sum := 0
+		for _, c := range want {
+			sum += c
+		}
+		if got := candy(ratings); got != sum {
+			t.Fatalf("candy(%v) = %d, want %d", ratings, got, sum)
+		}
+	}
+}
+
+func TestRandomizedSet(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	rs := newRandomizedSet()
+	want := map[int]bool{}
+	for range 5000 {
+		val := r.Intn(20)
+		switch r.Intn(3) {
+		case 0:
+			if got := rs.Insert(val); got != !want[val] {
+				t.Fatalf("Insert(%d) = %v", val, got)
+			}
+			want[val] = true
+		case 1:
+			if got := rs.Remove(val); got != want[val] {
+				t.Fatalf("Remove(%d) = %v", val, got)
+			}
+			delete(want, val)
+		default:
+			if len(want) > 0 {
+				if got := rs.GetRandom(); !want[got] {
+					t.Fatalf("GetRandom() = %d, not in the set", got)
+				}
+			}
+		}
+	}
+}
+
+func TestLongestCommonPrefix(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for range 1000 {
+		strs := make([]string, r.Intn(4))
+		for i := range strs {
+			strs[i] = randString(r, r.Intn(5), "ab")
+		}
+		got := longestCommonPrefix(strs)
+		for _, s := range strs {
+			if !strings.HasPrefix(s, got) {
+				t.Fatalf("longestCommonPrefix(%q) = %q, not a prefix of %q", strs, got, s)
+			}
+		}
+		// 再多一个字符就不是公共前缀了
+		if len(strs) > 0 && len(got) < len(strs[0]) {
+			longer := strs[0][:len(got)+1]
+			if !slices.ContainsFunc(strs, func(s string) bool { return !strings.HasPrefix(s, longer) }) {
+				t.Fatalf("longestCommonPrefix(%q) = %q, %q is longer", strs, got, longer)
+			}
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for range 1000 {
+		s := randString(r, r.Intn(12), "ab  ")
+		words := strings.Fields(s)
+		if len(words) > 0 {
+			if got := lengthOfLastWord(s); got != len(words[len(words)-1]) {
+				t.Fatalf("lengthOfLastWord(%q) = %d", s, got)
+			}
+		}
+		slices.Reverse(words)
+		if got, want := reverseWords(s), strings.Join(words, " "); got != want {
+			t.Fatalf("reverseWords(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for range 1000 {
+		s := randString(r, r.Intn(15), "abcdefghij")
+		numRows := 1 + r.Intn(5)
+		// 模拟按 Z 字形逐个写入每一行
+		rows := make([]strings.Builder, numRows)
+		row, step := 0, 1
+		for _, c := range s {
+			rows[row].WriteRune(c)
+			if numRows > 1 {
+				if row == 0 {
+					step = 1
+				} else if row == numRows-1 {
+					step = -1
+				}
+				row += step
+			}
+		}
+		var want strings.Builder
+		for i := range rows {
+			want.WriteString(rows[i].String())
+		}
+		if got := convert(s, numRows); got != want.String() {
+			t.Fatalf("convert(%q, %d) = %q, want %q", s, numRows, got, want.String())
+		}
+	}
+}
+
+func TestFullJustify(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for range 1000 {
+		maxWidth := 1 + r.Intn(12)
+		words := make([]string, 1+r.Intn(8))
+		for i := range words {
+			words[i] = randString(r, 1+r.Intn(maxWidth), "abc")
+		}
+		lines := fullJustify(words, maxWidth)
+		var all []string
+		for i, line := range lines {
+			if len(line) != maxWidth {
+				t.Fatalf("fullJustify(%q, %d): line %q has width %d", words, maxWidth, line, len(line))
+			}
+			fields := strings.Fields(line)
+			all = append(all, fields...)
+			if i == len(lines)-1 || len(fields) == 1 {
+				if line != strings.Join(fields, " ")+strings.Repeat(" ", maxWidth-len(strings.Join(fields, " "))) {
+					t.Fatalf("fullJustify(%q, %d): line %q should be left-justified", words, maxWidth, line)
+				}
+				continue
+			}
+			// 空格尽量均分，左边的间隔不少于右边
+			gaps := strings.FieldsFunc(line, func(c rune) bool { return c != ' ' })
+			for k := 1; k < len(gaps); k++ {
+				if len(gaps[k]) > len(gaps[k-1]) || len(gaps[0])-len(gaps[k]) > 1 {
+					t.Fatalf("fullJustify(%q, %d): uneven line %q", words, maxWidth, line)
+				}
+			}
+			// 下一行的第一个单词放不进这一行
+			if next := strings.Fields(lines[i+1])[0]; len(strings.Join(fields, " "))+1+len(next) <= maxWidth {
+				t.Fatalf("fullJustify(%q, %d): %q fits on line %q", words, maxWidth, next, line)
+			}
+		}
+		if !slices.Equal(all, words) {
+			t.Fatalf("fullJustify(%q, %d) = %q, words %q", words, maxWidth, lines, all)
+		}
+	}
+}
+
+func randString(r *rand.Rand, n int, alphabet string) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,59 @@
+package problems
+
+import (
+	"leetcode-go/registry"
+	"leetcode-go/search"
+)
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 35, Slug: "search-insert-position", Title: "搜索插入位置",
+		Difficulty: registry.Easy, Topics: []string{"数组", "二分查找"},
+		Solution: search.SearchInsert,
+	})
+	registry.Register(registry.Problem{
+		ID: 74, Slug: "search-a-2d-matrix", Title: "搜索二维矩阵",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找", "矩阵"},
+		Solution: search.SearchMatrix,
+	})
+	registry.Register(registry.Problem{
+		ID: 162, Slug: "find-peak-element", Title: "寻找峰值",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.FindPeakElement,
+	})
+	registry.Register(registry.Problem{
+		ID: 33, Slug: "search-in-rotated-sorted-array", Title: "搜索旋转排序数组",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.SearchRotated,
+	})
+	registry.Register(registry.Problem{
+		ID: 34, Slug: "find-first-and-last-position-of-element-in-sorted-array", Title: "在排序数组中查找元素的第一个和最后一个位置",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.SearchRange,
+	})
+	registry.Register(registry.Problem{
+		ID: 153, Slug: "find-minimum-in-rotated-sorted-array", Title: "寻找旋转排序数组中的最小值",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.FindMin,
+	})
+	registry.Register(registry.Problem{
+		ID: 167, Slug: "two-sum-ii-input-array-is-sorted", Title: "两数之和 II - 输入有序数组",
+		Difficulty: registry.Medium, Topics: []string{"数组", "双指针", "二分查找"},
+		Solution: search.TwoSumSorted,
+	})
+	registry.Register(registry.Problem{
+		ID: 69, Slug: "sqrtx", Title: "x 的平方根",
+		Difficulty: registry.Easy, Topics: []string{"数学", "二分查找"},
+		Solution: search.MySqrt,
+	})
+	registry.Register(registry.Problem{
+		ID: 875, Slug: "koko-eating-bananas", Title: "爱吃香蕉的珂珂",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.MinEatingSpeed,
+	})
+	registry.Register(registry.Problem{
+		ID: 1011, Slug: "capacity-to-ship-packages-within-d-days", Title: "在 D 天内送达包裹的能力",
+		Difficulty: registry.Medium, Topics: []string{"数组", "二分查找"},
+		Solution: search.ShipWithinDays,
+	})
+}
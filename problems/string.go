@@ -0,0 +1,175 @@
+package problems
+
+import (
+	"slices"
+	"strings"
+
+	"leetcode-go/registry"
+	"leetcode-go/strmatch"
+)
+
+func strStr(haystack string, needle string) int {
+	return strmatch.Index(strmatch.NewKMP(needle), haystack)
+}
+
+var romanSymbols = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// intToRoman 从大到小贪心地减去能用的最大符号。
+func intToRoman(num int) string {
+	var b strings.Builder
+	for _, rs := range romanSymbols {
+		for num >= rs.value {
+			num -= rs.value
+			b.WriteString(rs.symbol)
+		}
+	}
+	return b.String()
+}
+
+// romanToInt 小的符号在大的符号左边时做减法。
+func romanToInt(s string) int {
+	values := map[byte]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+	res := 0
+	for i := range s {
+		v := values[s[i]]
+		if i+1 < len(s) && v < values[s[i+1]] {
+			res -= v
+		} else {
+			res += v
+		}
+	}
+	return res
+}
+
+// longestCommonPrefix 纵向比较每一列。
+func longestCommonPrefix(strs []string) string {
+	if len(strs) == 0 {
+		return ""
+	}
+	s0 := strs[0]
+	for j := range len(s0) {
+		for _, s := range strs[1:] {
+			if j == len(s) || s[j] != s0[j] {
+				return s0[:j]
+			}
+		}
+	}
+	return s0
+}
+
+// lengthOfLastWord 从末尾跳过空格后数字母。
+func lengthOfLastWord(s string) int {
+	end := len(s) - 1
+	for end >= 0 && s[end] == ' ' {
+		end--
+	}
+	start := end
+	for start >= 0 && s[start] != ' ' {
+		start--
+	}
+	return end - start
+}
+
+func reverseWords(s string) string {
+	words := strings.Fields(s)
+	slices.Reverse(words)
+	return strings.Join(words, " ")
+}
+
+// convert Z 字形排列：周期为 2*numRows-2，按周期内的位置算出每个字符所在的行。
+func convert(s string, numRows int) string {
+	if numRows == 1 || numRows >= len(s) {
+		return s
+	}
+	rows := make([][]byte, numRows)
+	period := 2*numRows - 2
+	for i := range len(s) {
+		r := i % period
+		r = min(r, period-r)
+		rows[r] = append(rows[r], s[i])
+	}
+	return string(slices.Concat(rows...))
+}
+
+// fullJustify 贪心地往每行放尽量多的单词，多出的空格从左往右均分；最后一行和只有一个单词的行左对齐。
+func fullJustify(words []string, maxWidth int) []string {
+	var res []string
+	for i := 0; i < len(words); {
+		j, width := i+1, len(words[i])
+		for j < len(words) && width+1+len(words[j]) <= maxWidth {
+			width += 1 + len(words[j])
+			j++
+		}
+
+		gaps := j - i - 1
+		if j == len(words) || gaps == 0 {
+			line := strings.Join(words[i:j], " ")
+			res = append(res, line+strings.Repeat(" ", maxWidth-len(line)))
+		} else {
+			spaces := maxWidth - width + gaps // 单词之间的空格总数
+			var b strings.Builder
+			for k := i; k < j-1; k++ {
+				b.WriteString(words[k])
+				n := spaces / gaps
+				if k-i < spaces%gaps {
+					n++
+				}
+				b.WriteString(strings.Repeat(" ", n))
+			}
+			b.WriteString(words[j-1])
+			res = append(res, b.String())
+		}
+		i = j
+	}
+	return res
+}
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: 28, Slug: "find-the-index-of-the-first-occurrence-in-a-string", Title: "找出字符串中第一个匹配项的下标",
+		Difficulty: registry.Easy, Topics: []string{"双指针", "字符串", "字符串匹配"},
+		Solution: strStr,
+	})
+	registry.Register(registry.Problem{
+		ID: 12, Slug: "integer-to-roman", Title: "整数转罗马数字",
+		Difficulty: registry.Medium, Topics: []string{"哈希表", "数学", "字符串"},
+		Solution: intToRoman,
+	})
+	registry.Register(registry.Problem{
+		ID: 13, Slug: "roman-to-integer", Title: "罗马数字转整数",
+		Difficulty: registry.Easy, Topics: []string{"哈希表", "数学", "字符串"},
+		Solution: romanToInt,
+	})
+	registry.Register(registry.Problem{
+		ID: 14, Slug: "longest-common-prefix", Title: "最长公共前缀",
+		Difficulty: registry.Easy, Topics: []string{"字典树", "字符串"},
+		Solution: longestCommonPrefix,
+	})
+	registry.Register(registry.Problem{
+		ID: 58, Slug: "length-of-last-word", Title: "最后一个单词的长度",
+		Difficulty: registry.Easy, Topics: []string{"字符串"},
+		Solution: lengthOfLastWord,
+	})
+	registry.Register(registry.Problem{
+		ID: 151, Slug: "reverse-words-in-a-string", Title: "反转字符串中的单词",
+		Difficulty: registry.Medium, Topics: []string{"双指针", "字符串"},
+		Solution: reverseWords,
+	})
+	registry.Register(registry.Problem{
+		ID: 6, Slug: "zigzag-conversion", Title: "Z 字形变换",
+		Difficulty: registry.Medium, Topics: []string{"字符串"},
+		Solution: convert,
+	})
+	registry.Register(registry.Problem{
+		ID: 68, Slug: "text-justification", Title: "文本左右对齐",
+		Difficulty: registry.Hard, Topics: []string{"数组", "字符串", "模拟"},
+		Solution: fullJustify,
+	})
+}
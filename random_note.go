@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"leetcode-go/multiset"
+)
 
 /**
  * 383. Ransom Note 赎金信
@@ -11,6 +15,7 @@ func main() {
 	ransomoNote := "a"
 	magazine := "b"
 	fmt.Println(canConstruct(ransomoNote, magazine))
+	fmt.Println(canConstruct2(ransomoNote, magazine))
 }
 
 func canConstruct(ransomNote string, magazine string) bool {
@@ -34,3 +39,8 @@ func canConstruct(ransomNote string, magazine string) bool {
 
 	return true
 }
+
+// canConstruct2 用多重集合判断：赎金信的字母计数是杂志字母计数的子集
+func canConstruct2(ransomNote string, magazine string) bool {
+	return multiset.CanConstruct(ransomNote, magazine)
+}
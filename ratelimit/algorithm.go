@@ -0,0 +1,275 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Algorithm 限流算法，为每个键创建独立的状态。
+type Algorithm interface {
+	Limit() int
+	newState(now time.Time) state
+}
+
+type state interface {
+	allow(now time.Time) Decision
+}
+
+// Decision 一次限流判断的结果。
+type Decision struct {
+	Allowed   bool
+	Limit     int           // 窗口内（或桶）允许的请求数
+	Remaining int           // 当前还能放行的请求数
+	Delay     time.Duration // 放行但需要排队等待的时间，只有漏桶会大于 0
+	// RetryAfter 被拒绝时，至少等待多久再重试才可能被放行，用于 429 的 Retry-After 头。
+	RetryAfter time.Duration
+}
+
+// epsilon 吸收浮点误差，避免等待了 RetryAfter 之后仍差一点点而被拒绝。
+const epsilon = 1e-9
+
+func ceilDuration(ns float64) time.Duration {
+	return time.Duration(math.Ceil(ns - epsilon))
+}
+
+func perSecond(rate float64) time.Duration {
+	return time.Duration(float64(time.Second) / rate)
+}
+
+// TokenBucket 令牌桶：以每秒 rate 个的速度放入令牌，最多攒 burst 个，每个请求取走一个。
+// 允许突发，长期平均速率不超过 rate。
+func TokenBucket(rate float64, burst int) Algorithm {
+	if rate <= 0 || burst <= 0 {
+		panic("ratelimit: token bucket rate and burst must be positive")
+	}
+	return tokenBucket{rate, burst}
+}
+
+type tokenBucket struct {
+	rate  float64
+	burst int
+}
+
+func (a tokenBucket) Limit() int { return a.burst }
+
+func (a tokenBucket) newState(now time.Time) state {
+	return &tokenState{alg: a, tokens: float64(a.burst), last: now}
+}
+
+type tokenState struct {
+	alg    tokenBucket
+	tokens float64
+	last   time.Time
+}
+
+func (s *tokenState) allow(now time.Time) Decision {
+	if elapsed := now.Sub(s.last); elapsed > 0 {
+		s.tokens = math.Min(float64(s.alg.burst), s.tokens+elapsed.Seconds()*s.alg.rate)
+		s.last = now
+	}
+	d := Decision{Limit: s.alg.burst}
+	if s.tokens >= 1-epsilon {
+		s.tokens = math.Max(s.tokens-1, 0)
+		d.Allowed = true
+	} else {
+		d.RetryAfter = ceilDuration((1 - s.tokens) / s.alg.rate * float64(time.Second))
+	}
+	d.Remaining = int(s.tokens + epsilon)
+	return d
+}
+
+// LeakyBucket 漏桶（队列形式）：请求进入容量为 capacity 的队列，以每秒 rate 个的恒定速度流出。
+// 与令牌桶不同，它不放行突发，而是通过 Decision.Delay 让请求排队，把流量整形成匀速；队列满时拒绝。
+func LeakyBucket(rate float64, capacity int) Algorithm {
+	if rate <= 0 || capacity <= 0 {
+		panic("ratelimit: leaky bucket rate and capacity must be positive")
+	}
+	// 流出间隔以纳秒计，rate 超过每秒 1e9 个时间隔为 0，漏桶就失去了意义
+	interval := perSecond(rate)
+	if interval <= 0 {
+		panic("ratelimit: leaky bucket rate must not exceed 1e9 per second")
+	}
+	return leakyBucket{interval, capacity}
+}
+
+type leakyBucket struct {
+	interval time.Duration
+	capacity int
+}
+
+func (a leakyBucket) Limit() int { return a.capacity }
+
+func (a leakyBucket) newState(now time.Time) state {
+	return &leakyState{alg: a, next: now}
+}
+
+type leakyState struct {
+	alg  leakyBucket
+	next time.Time // 下一个请求最早可以流出的时间
+}
+
+func (s *leakyState) allow(now time.Time) Decision {
+	at := s.next
+	if at.Before(now) {
+		at = now
+	}
+	wait := at.Sub(now)
+	queued := int(wait / s.alg.interval) // 排在前面还没流出的请求数
+	d := Decision{Limit: s.alg.capacity}
+	if queued >= s.alg.capacity {
+		// 等到排队时间降到 capacity 个间隔以下
+		d.RetryAfter = wait - time.Duration(s.alg.capacity)*s.alg.interval + 1
+		return d
+	}
+	s.next = at.Add(s.alg.interval)
+	d.Allowed = true
+	d.Delay = wait
+	d.Remaining = s.alg.capacity - queued - 1
+	return d
+}
+
+// FixedWindow 固定窗口：按 window 把时间切成固定的段，每段最多 limit 个请求。
+// 实现最简单，但在窗口交界处可能放行 2*limit 个请求。
+func FixedWindow(limit int, window time.Duration) Algorithm {
+	checkWindow(limit, window)
+	return fixedWindow{limit, window}
+}
+
+func checkWindow(limit int, window time.Duration) {
+	if limit <= 0 || window <= 0 {
+		panic("ratelimit: window limit and size must be positive")
+	}
+}
+
+type fixedWindow struct {
+	limit  int
+	window time.Duration
+}
+
+func (a fixedWindow) Limit() int { return a.limit }
+
+func (a fixedWindow) newState(now time.Time) state {
+	return &fixedState{alg: a, start: now.Truncate(a.window)}
+}
+
+type fixedState struct {
+	alg   fixedWindow
+	start time.Time
+	count int
+}
+
+func (s *fixedState) allow(now time.Time) Decision {
+	if start := now.Truncate(s.alg.window); start.After(s.start) {
+		s.start, s.count = start, 0
+	}
+	d := Decision{Limit: s.alg.limit}
+	if s.count < s.alg.limit {
+		s.count++
+		d.Allowed = true
+	} else {
+		d.RetryAfter = s.start.Add(s.alg.window).Sub(now)
+	}
+	d.Remaining = s.alg.limit - s.count
+	return d
+}
+
+// SlidingLog 滑动窗口日志：记录每个放行请求的时间，任意长度为 window 的区间内最多 limit 个。
+// 精确，但每个键要保存最多 limit 个时间戳。
+func SlidingLog(limit int, window time.Duration) Algorithm {
+	checkWindow(limit, window)
+	return slidingLog{limit, window}
+}
+
+type slidingLog struct {
+	limit  int
+	window time.Duration
+}
+
+func (a slidingLog) Limit() int { return a.limit }
+
+func (a slidingLog) newState(time.Time) state {
+	return &logState{alg: a}
+}
+
+type logState struct {
+	alg slidingLog
+	log []time.Time // 按时间递增
+}
+
+func (s *logState) allow(now time.Time) Decision {
+	cutoff := now.Add(-s.alg.window)
+	i := 0
+	for i < len(s.log) && !s.log[i].After(cutoff) {
+		i++
+	}
+	s.log = s.log[i:]
+	d := Decision{Limit: s.alg.limit}
+	if len(s.log) < s.alg.limit {
+		s.log = append(s.log, now)
+		d.Allowed = true
+	} else {
+		d.RetryAfter = s.log[0].Sub(cutoff)
+	}
+	d.Remaining = s.alg.limit - len(s.log)
+	return d
+}
+
+// SlidingWindow 滑动窗口计数：只保存当前和上一个固定窗口的计数，
+// 用上一个窗口按重叠比例加权来估计滑动窗口内的请求数，内存 O(1)。
+func SlidingWindow(limit int, window time.Duration) Algorithm {
+	checkWindow(limit, window)
+	return slidingWindow{limit, window}
+}
+
+type slidingWindow struct {
+	limit  int
+	window time.Duration
+}
+
+func (a slidingWindow) Limit() int { return a.limit }
+
+func (a slidingWindow) newState(now time.Time) state {
+	return &slidingState{alg: a, start: now.Truncate(a.window)}
+}
+
+type slidingState struct {
+	alg         slidingWindow
+	start       time.Time
+	prev, count int
+}
+
+func (s *slidingState) allow(now time.Time) Decision {
+	w := s.alg.window
+	if start := now.Truncate(w); start.After(s.start) {
+		if start.Sub(s.start) == w {
+			s.prev = s.count
+		} else {
+			s.prev = 0
+		}
+		s.start, s.count = start, 0
+	}
+	elapsed := now.Sub(s.start)
+	weight := 1 - float64(elapsed)/float64(w)
+	used := int(math.Ceil(float64(s.prev)*weight-epsilon)) + s.count
+
+	d := Decision{Limit: s.alg.limit}
+	if used < s.alg.limit {
+		s.count++
+		d.Allowed = true
+		d.Remaining = s.alg.limit - used - 1
+		return d
+	}
+	// 被拒绝：求最早的时刻 t，使上一个窗口的加权计数降到 limit-1-当前计数 以下。
+	if room := s.alg.limit - 1 - s.count; room >= 0 && s.prev > 0 {
+		at := ceilDuration(float64(w) * (1 - float64(room)/float64(s.prev)))
+		d.RetryAfter = at - elapsed
+	} else {
+		// 当前窗口已满，要等到下一个窗口，并让本窗口的计数按比例衰减。
+		at := ceilDuration(float64(w) * (1 - float64(s.alg.limit-1)/float64(s.count)))
+		d.RetryAfter = w - elapsed + at
+	}
+	if d.RetryAfter <= 0 {
+		d.RetryAfter = time.Millisecond
+	}
+	return d
+}
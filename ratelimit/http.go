@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeyFunc 从请求中取出限流的键，返回空串表示不限流。
+type KeyFunc func(r *http.Request) string
+
+// ByURL 按请求路径限流。
+func ByURL(r *http.Request) string {
+	return r.URL.Path
+}
+
+// ByClientIP 按客户端 IP 限流。trustForwarded 为 true 时取 X-Forwarded-For 的第一个地址，
+// 只应在前面还有可信代理时打开，否则客户端可以伪造这个头绕过限流。
+func ByClientIP(trustForwarded bool) KeyFunc {
+	return func(r *http.Request) string {
+		if trustForwarded {
+			if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+				first, _, _ := strings.Cut(xff, ",")
+				return strings.TrimSpace(first)
+			}
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			return r.RemoteAddr
+		}
+		return host
+	}
+}
+
+// ByHeader 按请求头的值限流，例如 API key；没有这个头的请求不限流。
+func ByHeader(name string) KeyFunc {
+	return func(r *http.Request) string {
+		return r.Header.Get(name)
+	}
+}
+
+// Middleware 用 l 对 key 取出的键限流：放行时写 X-RateLimit-Limit 和 X-RateLimit-Remaining，
+// 漏桶要求排队时先等待 Decision.Delay；拒绝时返回 429 并带上 Retry-After（秒，向上取整）。
+func Middleware(l *Limiter, key KeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			k := key(r)
+			if k == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			d := l.Allow(k)
+			h := w.Header()
+			h.Set("X-RateLimit-Limit", strconv.Itoa(d.Limit))
+			h.Set("X-RateLimit-Remaining", strconv.Itoa(d.Remaining))
+			if !d.Allowed {
+				h.Set("Retry-After", strconv.Itoa(RetryAfterSeconds(d.RetryAfter)))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			if d.Delay > 0 {
+				select {
+				case <-l.clock.After(d.Delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RetryAfterSeconds 把等待时间换成 Retry-After 头的秒数，不足一秒按一秒算。
+func RetryAfterSeconds(d time.Duration) int {
+	s := int((d + time.Second - 1) / time.Second)
+	return max(s, 1)
+}
@@ -0,0 +1,61 @@
+/**
+ * 限流：令牌桶、漏桶、固定窗口、滑动窗口日志、滑动窗口计数五种算法，
+ * 按任意键（URL、客户端 IP、请求头）分别计数，被拒绝时给出 Retry-After。
+ * 每个键的状态放在容量有限的 LRU 中，键再多内存也是有界的。
+ */
+package ratelimit
+
+import (
+	"sync"
+
+	"leetcode-go/cache"
+	"leetcode-go/ds"
+)
+
+// DefaultMaxKeys 未指定 Options.MaxKeys 时最多跟踪的键数。
+const DefaultMaxKeys = 10000
+
+type Options struct {
+	Clock cache.Clock // 默认 cache.SystemClock，测试时换成 cache.FakeClock
+	// MaxKeys 最多同时跟踪多少个键，超出时丢弃最久没有请求的键的状态。
+	// 被丢弃的键下次请求时从满额度重新开始，所以它应当明显大于活跃键的数量。
+	MaxKeys int
+}
+
+// Limiter 按键限流，可以被多个 goroutine 同时使用。
+type Limiter struct {
+	mu    sync.Mutex
+	alg   Algorithm
+	clock cache.Clock
+	keys  *ds.LRU[string, state]
+}
+
+func New(alg Algorithm, opts Options) *Limiter {
+	if opts.Clock == nil {
+		opts.Clock = cache.SystemClock
+	}
+	if opts.MaxKeys <= 0 {
+		opts.MaxKeys = DefaultMaxKeys
+	}
+	return &Limiter{alg: alg, clock: opts.Clock, keys: ds.NewLRU[string, state](opts.MaxKeys)}
+}
+
+// Allow 为 key 记一次请求并返回是否放行。被拒绝的请求不消耗额度。
+func (l *Limiter) Allow(key string) Decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	s, ok := l.keys.Get(key)
+	if !ok {
+		s = l.alg.newState(now)
+		l.keys.Put(key, s)
+	}
+	return s.allow(now)
+}
+
+// Keys 当前跟踪的键数。
+func (l *Limiter) Keys() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.keys.Len()
+}
@@ -0,0 +1,180 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"leetcode-go/cache"
+)
+
+var start = time.Date(2026, 1, 1, 0, 0, 0, 123456789, time.UTC)
+
+var algorithms = []struct {
+	name string
+	alg  Algorithm
+}{
+	{"token-bucket", TokenBucket(3, 5)},
+	{"token-bucket-slow", TokenBucket(0.7, 2)},
+	{"leaky-bucket", LeakyBucket(4, 3)},
+	{"fixed-window", FixedWindow(4, time.Second)},
+	{"sliding-log", SlidingLog(4, time.Second)},
+	{"sliding-window", SlidingWindow(4, time.Second)},
+	{"sliding-window-1", SlidingWindow(1, 700*time.Millisecond)},
+}
+
+// replay 用新的限流器按 offsets（相对 start）重放请求，返回最后一次的结果。
+func replay(alg Algorithm, offsets []time.Duration) Decision {
+	clock := cache.NewFakeClock(start)
+	l := New(alg, Options{Clock: clock})
+	var d Decision
+	for _, off := range offsets {
+		clock.Advance(start.Add(off).Sub(clock.Now()))
+		d = l.Allow("k")
+	}
+	return d
+}
+
+// slack 令牌桶和滑动窗口计数用浮点数计算，RetryAfter 允许比最短等待时间多出这么一点。
+const slack = time.Microsecond
+
+// TestRetryAfter 被拒绝时，恰好等待 RetryAfter 后重试一定放行，少等 slack 仍然被拒绝。
+func TestRetryAfter(t *testing.T) {
+	for _, tc := range algorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			var offsets []time.Duration
+			var now time.Duration
+			denied := 0
+			for range 300 {
+				// 大多是密集的请求，偶尔空闲一段时间
+				gap := time.Duration(r.Int63n(int64(100 * time.Millisecond)))
+				if r.Intn(10) == 0 {
+					gap = time.Duration(r.Int63n(int64(3 * time.Second)))
+				}
+				now += gap
+				offsets = append(offsets, now)
+				d := replay(tc.alg, offsets)
+				if d.Limit != tc.alg.Limit() || d.Remaining < 0 || d.Remaining >= d.Limit && d.Allowed {
+					t.Fatalf("at %v: %+v", now, d)
+				}
+				if d.Allowed {
+					if d.RetryAfter != 0 {
+						t.Fatalf("at %v: allowed with RetryAfter %v", now, d.RetryAfter)
+					}
+					continue
+				}
+				denied++
+				if d.RetryAfter <= 0 {
+					t.Fatalf("at %v: denied with RetryAfter %v", now, d.RetryAfter)
+				}
+				if got := replay(tc.alg, append(offsets, now+d.RetryAfter)); !got.Allowed {
+					t.Fatalf("at %v: denied, RetryAfter %v, still denied after waiting: %+v", now, d.RetryAfter, got)
+				}
+				if got := replay(tc.alg, append(offsets, now+d.RetryAfter-slack)); d.RetryAfter > slack && got.Allowed {
+					t.Fatalf("at %v: RetryAfter %v is not the shortest wait", now, d.RetryAfter)
+				}
+			}
+			if denied == 0 {
+				t.Fatal("no request was denied")
+			}
+		})
+	}
+}
+
+// TestDeniedDoesNotConsume 被拒绝的请求不消耗额度：中间插入任意多次被拒绝的请求，之后的结果不变。
+func TestDeniedDoesNotConsume(t *testing.T) {
+	for _, tc := range algorithms {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := cache.NewFakeClock(start)
+			l := New(tc.alg, Options{Clock: clock})
+			var d Decision
+			for d = l.Allow("k"); d.Allowed; d = l.Allow("k") {
+			}
+			for range 10 {
+				l.Allow("k")
+			}
+			clock.Advance(d.RetryAfter)
+			if got := l.Allow("k"); !got.Allowed {
+				t.Fatalf("denied after RetryAfter %v: %+v", d.RetryAfter, got)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	clock := cache.NewFakeClock(start)
+	l := New(FixedWindow(2, time.Minute), Options{Clock: clock})
+	h := Middleware(l, ByHeader("X-Key"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	do := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/", nil)
+		if key != "" {
+			req.Header.Set("X-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i, want := range []int{200, 200, 429} {
+		rec := do("a")
+		if rec.Code != want {
+			t.Fatalf("request %d = %d, want %d", i, rec.Code, want)
+		}
+		if rec.Header().Get("X-RateLimit-Limit") != "2" {
+			t.Fatalf("X-RateLimit-Limit = %q", rec.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+	// start 在一分钟内已经过去了 0.123s，Retry-After 向上取整
+	if got := do("a").Header().Get("Retry-After"); got != "60" {
+		t.Fatalf("Retry-After = %q, want 60", got)
+	}
+	if rec := do("b"); rec.Code != 200 {
+		t.Fatalf("another key = %d, want 200", rec.Code)
+	}
+	for range 5 {
+		if rec := do(""); rec.Code != 200 {
+			t.Fatalf("request without a key = %d, want 200", rec.Code)
+		}
+	}
+	clock.Advance(time.Minute)
+	if rec := do("a"); rec.Code != 200 {
+		t.Fatalf("after the window = %d, want 200", rec.Code)
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	for d, want := range map[time.Duration]int{0: 1, time.Nanosecond: 1, time.Second: 1, time.Second + 1: 2, 90 * time.Second: 90} {
+		if got := RetryAfterSeconds(d); got != want {
+			t.Errorf("RetryAfterSeconds(%v) = %d, want %d", d, got, want)
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	for name, f := range map[string]func(){
+		"token-bucket zero rate":  func() { TokenBucket(0, 1) },
+		"leaky-bucket zero cap":   func() { LeakyBucket(1, 0) },
+		"leaky-bucket too fast":   func() { LeakyBucket(2e9, 10) },
+		"fixed-window zero limit": func() { FixedWindow(0, time.Second) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+	// 间隔恰好 1ns 的速率仍然可用
+	l := New(LeakyBucket(1e9, 2), Options{Clock: cache.NewFakeClock(start)})
+	if d := l.Allow("k"); !d.Allowed {
+		t.Fatalf("LeakyBucket(1e9, 2) denied the first request: %+v", d)
+	}
+}
@@ -0,0 +1,113 @@
+/**
+ * 题目注册表：按 LeetCode 题号登记题目信息和对应的库函数实现。
+ * 各题的登记放在 problems 包中，使用方通过空白导入 problems 来加载。
+ */
+package registry
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+type Difficulty string
+
+const (
+	Easy   Difficulty = "Easy"
+	Medium Difficulty = "Medium"
+	Hard   Difficulty = "Hard"
+)
+
+type Problem struct {
+	ID         int
+	Slug       string // leetcode.cn/problems/ 后面的部分
+	Title      string
+	Difficulty Difficulty
+	Topics     []string
+	Solution   any // LeetCode 签名的解题函数，lc compare 以它的结果作为标准答案
+
+	// 以下字段供 lc compare 使用，均可省略
+	Approaches []Approach // 同一道题的多种解法，省略时只有 Solution 一种
+	Gen        Generator  // 随机生成参数，省略时只能用题目目录中的示例对拍
+	Check      Checker    // 答案不唯一时判断结果是否正确，省略时与 Solution 的结果比较
+}
+
+// Approach 一种命名的解法，Func 与 Solution 的签名相同。
+type Approach struct {
+	Name string
+	Func any
+	MaxN int // 输入规模超过 MaxN 时跳过，用于指数级的解法，0 表示不限
+}
+
+// Generator 生成一组规模为 n 的随机参数，顺序与解题函数的参数一致。
+type Generator func(r *rand.Rand, n int) []any
+
+// Checker 判断 got（解题函数的返回值）是否是参数 args 的正确答案，不正确时返回原因。
+type Checker func(args []any, got []any) error
+
+// URL 返回题目在 leetcode.cn 上的地址。
+func (p *Problem) URL() string {
+	return "https://leetcode.cn/problems/" + p.Slug + "/"
+}
+
+var (
+	byID   = map[int]*Problem{}
+	bySlug = map[string]*Problem{}
+)
+
+// Solutions 返回这道题的所有解法。
+func (p *Problem) Solutions() []Approach {
+	if len(p.Approaches) > 0 {
+		return p.Approaches
+	}
+	return []Approach{{Name: "solution", Func: p.Solution}}
+}
+
+// Register 登记一道题目，题号或 slug 重复时 panic，应在 init 中调用。
+// 只给出 Approaches 时以第一种解法作为 Solution。
+func Register(p Problem) {
+	if p.ID <= 0 || p.Slug == "" {
+		panic(fmt.Sprintf("registry: invalid problem %d %q", p.ID, p.Slug))
+	}
+	if p.Solution == nil && len(p.Approaches) > 0 {
+		p.Solution = p.Approaches[0].Func
+	}
+	if p.Solution == nil {
+		panic(fmt.Sprintf("registry: problem %d has no solution", p.ID))
+	}
+	seen := map[string]bool{}
+	for _, a := range p.Approaches {
+		if a.Name == "" || seen[a.Name] || a.Func == nil {
+			panic(fmt.Sprintf("registry: invalid approach %q for problem %d", a.Name, p.ID))
+		}
+		seen[a.Name] = true
+	}
+	if _, ok := byID[p.ID]; ok {
+		panic(fmt.Sprintf("registry: duplicate problem %d", p.ID))
+	}
+	if _, ok := bySlug[p.Slug]; ok {
+		panic(fmt.Sprintf("registry: duplicate slug %q", p.Slug))
+	}
+	byID[p.ID] = &p
+	bySlug[p.Slug] = &p
+}
+
+func Lookup(id int) (*Problem, bool) {
+	p, ok := byID[id]
+	return p, ok
+}
+
+func LookupSlug(slug string) (*Problem, bool) {
+	p, ok := bySlug[slug]
+	return p, ok
+}
+
+// All 按题号升序返回所有已登记的题目。
+func All() []*Problem {
+	res := make([]*Problem, 0, len(byID))
+	for _, p := range byID {
+		res = append(res, p)
+	}
+	slices.SortFunc(res, func(a, b *Problem) int { return a.ID - b.ID })
+	return res
+}
@@ -0,0 +1,270 @@
+/**
+ * 根据 catalog 中的题目生成题解模板：题解文件（含头部注释和 registry 登记）、
+ * 以示例为表格用例的测试文件和基准测试桩，生成的代码放在 problems 包中。
+ */
+package scaffold
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"leetcode-go/catalog"
+	"leetcode-go/registry"
+)
+
+// ErrDuplicate 题目已经有题解。
+var ErrDuplicate = errors.New("scaffold: problem already solved")
+
+// File 一个待生成的文件。
+type File struct {
+	Path    string
+	Content []byte
+}
+
+// FileName 由 slug 得到文件名，与仓库根目录的命名方式一致，如 two-sum -> two_sum.go。
+func FileName(p *catalog.Problem) string {
+	return strings.ReplaceAll(p.Slug, "-", "_") + ".go"
+}
+
+// Render 生成题解和测试两个文件，dir 为 problems 包所在目录。
+func Render(p *catalog.Problem, dir string) ([]File, error) {
+	data, err := newTemplateData(p)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Join(dir, FileName(p))
+	files := []File{
+		{Path: name},
+		{Path: strings.TrimSuffix(name, ".go") + "_test.go"},
+	}
+	for i, tmpl := range []*template.Template{solutionTmpl, testTmpl} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("scaffold: generated code for %d does not parse: %w", p.ID, err)
+		}
+		files[i].Content = src
+	}
+	return files, nil
+}
+
+// CheckDuplicate 检查题目是否已经解过：已在 registry 中登记，
+// 或者 root 下某个 .go 文件的注释里引用了该题的链接，或者有同名文件（早期题解没有写链接）。
+func CheckDuplicate(p *catalog.Problem, root string) error {
+	if r, ok := registry.Lookup(p.ID); ok {
+		return fmt.Errorf("%w: %d. %s is registered as %s", ErrDuplicate, p.ID, p.Title, r.Slug)
+	}
+	if _, ok := registry.LookupSlug(p.Slug); ok {
+		return fmt.Errorf("%w: %s is registered", ErrDuplicate, p.Slug)
+	}
+	url := regexp.MustCompile(`leetcode\.(cn|com)/problems/` + regexp.QuoteMeta(p.Slug) + `(/|\s|$)`)
+	var found string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		if d.Name() == FileName(p) {
+			found = path
+			return fs.SkipAll
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if url.Match(src) {
+			found = path
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if found != "" {
+		return fmt.Errorf("%w: %d. %s is solved in %s", ErrDuplicate, p.ID, p.Title, found)
+	}
+	return nil
+}
+
+// Write 写出文件，任何一个文件已存在时都不写。
+func Write(files []File) error {
+	for _, f := range files {
+		if _, err := os.Stat(f.Path); err == nil {
+			return fmt.Errorf("%w: %s already exists", ErrDuplicate, f.Path)
+		}
+	}
+	for _, f := range files {
+		if err := os.WriteFile(f.Path, f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testCase struct {
+	Args []string
+	Want string
+}
+
+type templateData struct {
+	*catalog.Problem
+	Signature  string
+	Exported   string // 测试函数名后缀
+	Difficulty string // registry 中的常量名
+	Zero       string
+	WantType   string
+	Cases      []testCase
+}
+
+func newTemplateData(p *catalog.Problem) (*templateData, error) {
+	d := &templateData{
+		Problem:   p,
+		Signature: p.Signature(),
+		WantType:  p.WantType(),
+		Zero:      zeroValue(p.Result),
+	}
+	r := []rune(p.Func)
+	r[0] = unicode.ToUpper(r[0])
+	d.Exported = string(r)
+
+	switch p.Difficulty {
+	case "Easy", "Medium", "Hard":
+		d.Difficulty = p.Difficulty
+	default:
+		return nil, fmt.Errorf("scaffold: unknown difficulty %q", p.Difficulty)
+	}
+	if d.WantType == "" {
+		return nil, fmt.Errorf("scaffold: %d has no result and no in-place parameter", p.ID)
+	}
+
+	for i, ex := range p.Examples {
+		if len(ex.Args) != len(p.Params) {
+			return nil, fmt.Errorf("scaffold: example %d of %d has %d args, want %d", i+1, p.ID, len(ex.Args), len(p.Params))
+		}
+		c := testCase{}
+		for j, arg := range ex.Args {
+			lit, err := catalog.GoLiteral(p.Params[j].Type, arg)
+			if err != nil {
+				return nil, err
+			}
+			c.Args = append(c.Args, lit)
+		}
+		want, err := catalog.GoLiteral(d.WantType, ex.Want)
+		if err != nil {
+			return nil, err
+		}
+		c.Want = want
+		d.Cases = append(d.Cases, c)
+	}
+	return d, nil
+}
+
+func zeroValue(typ string) string {
+	switch {
+	case typ == "":
+		return ""
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["), strings.HasPrefix(typ, "*"):
+		return "nil"
+	}
+	return "0"
+}
+
+var funcs = template.FuncMap{
+	"join": strings.Join,
+	"quote": func(s string) string {
+		return fmt.Sprintf("%q", s)
+	},
+}
+
+var solutionTmpl = template.Must(template.New("solution").Funcs(funcs).Parse(`package problems
+
+import "leetcode-go/registry"
+
+/**
+ * {{.ID}}. {{.Title}}
+ * {{.URL}}
+ */
+{{.Signature}} {
+{{- if .Zero}}
+	return {{.Zero}}
+{{- end}}
+}
+
+func init() {
+	registry.Register(registry.Problem{
+		ID: {{.ID}}, Slug: {{quote .Slug}}, Title: {{quote .Title}},
+		Difficulty: registry.{{.Difficulty}}, Topics: []string{ {{- range $i, $t := .Topics}}{{if $i}}, {{end}}{{quote $t}}{{end -}} },
+		Solution: {{.Func}},
+	})
+}
+`))
+
+var testTmpl = template.Must(template.New("test").Funcs(funcs).Parse(`package problems
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test{{.Exported}}(t *testing.T) {
+	tests := []struct {
+{{- range .Params}}
+		{{.Name}} {{.Type}}
+{{- end}}
+		want {{.WantType}}
+	}{
+{{- range .Cases}}
+		{ {{- join .Args ", "}}, {{.Want -}} },
+{{- end}}
+	}
+	for _, tt := range tests {
+{{- if .Result}}
+		got := {{.Func}}({{range $i, $p := .Params}}{{if $i}}, {{end}}tt.{{$p.Name}}{{end}})
+{{- else}}
+		{{.Func}}({{range $i, $p := .Params}}{{if $i}}, {{end}}tt.{{$p.Name}}{{end}})
+		got := tt.{{.InPlace}}
+{{- end}}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("{{.Func}}() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func Benchmark{{.Exported}}(b *testing.B) {
+{{- if .Cases}}
+	for i := 0; i < b.N; i++ {
+{{- with index .Cases 0}}
+		{{$.Func}}({{join .Args ", "}})
+{{- end}}
+	}
+{{- else}}
+	b.Skip("no examples")
+{{- end}}
+}
+`))
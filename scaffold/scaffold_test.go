@@ -0,0 +1,144 @@
+package scaffold
+
+import (
+	"errors"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"leetcode-go/catalog"
+)
+
+// 类型检查共用一个 importer，标准库和 registry 只从源码加载一次。
+var (
+	fset = token.NewFileSet()
+	imp  = importer.ForCompiler(fset, "source", nil)
+)
+
+// typeCheck 把生成的题解和测试文件作为一个包做类型检查。
+func typeCheck(t *testing.T, files []File) {
+	t.Helper()
+	var parsed []*ast.File
+	for _, f := range files {
+		af, err := parser.ParseFile(fset, f.Path, f.Content, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed = append(parsed, af)
+	}
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check("leetcode-go/problems", fset, parsed, nil); err != nil {
+		t.Fatalf("%s: %v\n%s", files[0].Path, err, files[1].Content)
+	}
+}
+
+func TestRenderCatalog(t *testing.T) {
+	all, err := catalog.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range all {
+		files, err := Render(p, "problems")
+		if err != nil {
+			t.Fatalf("Render(%d): %v", p.ID, err)
+		}
+		if files[0].Path != filepath.Join("problems", FileName(p)) || !strings.HasSuffix(files[1].Path, "_test.go") {
+			t.Fatalf("Render(%d) paths %s, %s", p.ID, files[0].Path, files[1].Path)
+		}
+		if !strings.Contains(string(files[0].Content), p.URL()) {
+			t.Errorf("%d: solution has no header URL", p.ID)
+		}
+		typeCheck(t, files)
+	}
+}
+
+func TestRenderNoExamples(t *testing.T) {
+	p, err := catalog.Lookup("two-sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bare := *p
+	bare.Examples = nil
+	files, err := Render(&bare, "problems")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(files[1].Content), `b.Skip("no examples")`) {
+		t.Errorf("benchmark without examples:\n%s", files[1].Content)
+	}
+	typeCheck(t, files)
+}
+
+func TestRenderErrors(t *testing.T) {
+	p, _ := catalog.Lookup("two-sum")
+	for name, edit := range map[string]func(p *catalog.Problem){
+		"difficulty": func(p *catalog.Problem) { p.Difficulty = "Trivial" },
+		"no result":  func(p *catalog.Problem) { p.Result = "" },
+		"arity":      func(p *catalog.Problem) { p.Params = p.Params[:1] },
+		"bad value":  func(p *catalog.Problem) { p.Result = "bool" },
+	} {
+		bad := *p
+		edit(&bad)
+		if _, err := Render(&bad, "problems"); err == nil {
+			t.Errorf("%s: Render succeeded", name)
+		}
+	}
+}
+
+func TestCheckDuplicate(t *testing.T) {
+	p := &catalog.Problem{ID: 99999, Slug: "made-up-problem", Title: "虚构的题目"}
+	root := t.TempDir()
+	if err := CheckDuplicate(p, root); err != nil {
+		t.Fatal(err)
+	}
+	// 链接出现在注释里
+	src := "/**\n * 99999. 虚构的题目\n * https://leetcode.cn/problems/made-up-problem/\n */\npackage main\n"
+	if err := os.WriteFile(filepath.Join(root, "other.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDuplicate(p, root); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("CheckDuplicate with a linking file = %v", err)
+	}
+	// 同名文件，以及前缀相同的其他题目不算
+	root = t.TempDir()
+	other := strings.ReplaceAll(src, "made-up-problem", "made-up-problem-ii")
+	if err := os.WriteFile(filepath.Join(root, "other.go"), []byte(other), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDuplicate(p, root); err != nil {
+		t.Errorf("CheckDuplicate matched another slug: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, FileName(p)), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckDuplicate(p, root); !errors.Is(err, ErrDuplicate) {
+		t.Errorf("CheckDuplicate with a file of the same name = %v", err)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	files := []File{{filepath.Join(dir, "a.go"), []byte("a")}, {filepath.Join(dir, "b.go"), []byte("b")}}
+	if err := os.WriteFile(files[1].Path, []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(files); !errors.Is(err, ErrDuplicate) {
+		t.Fatalf("Write over an existing file = %v", err)
+	}
+	if _, err := os.Stat(files[0].Path); err == nil {
+		t.Fatal("Write wrote a.go although b.go exists")
+	}
+	os.Remove(files[1].Path)
+	if err := Write(files); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := os.ReadFile(files[1].Path); string(b) != "b" {
+		t.Fatalf("b.go = %q", b)
+	}
+}
@@ -0,0 +1,125 @@
+package search
+
+import "cmp"
+
+/**
+ * 35. 搜索插入位置
+ * https://leetcode.cn/problems/search-insert-position/
+ */
+func SearchInsert(nums []int, target int) int {
+	return LowerBound(nums, target, cmp.Compare[int])
+}
+
+/**
+ * 34. 在排序数组中查找元素的第一个和最后一个位置
+ * https://leetcode.cn/problems/find-first-and-last-position-of-element-in-sorted-array/
+ */
+func SearchRange(nums []int, target int) []int {
+	first, last := EqualRange(nums, target, cmp.Compare[int])
+	if first == last {
+		return []int{-1, -1}
+	}
+	return []int{first, last - 1}
+}
+
+/**
+ * 33. 搜索旋转排序数组
+ * https://leetcode.cn/problems/search-in-rotated-sorted-array/
+ */
+func SearchRotated(nums []int, target int) int {
+	return FindRotated(nums, target, cmp.Compare[int])
+}
+
+/**
+ * 153. 寻找旋转排序数组中的最小值
+ * https://leetcode.cn/problems/find-minimum-in-rotated-sorted-array/
+ */
+func FindMin(nums []int) int {
+	return nums[RotatedMin(nums, cmp.Compare[int])]
+}
+
+/**
+ * 74. 搜索二维矩阵
+ * https://leetcode.cn/problems/search-a-2d-matrix/
+ * 把矩阵看成按行拼接的一维有序数组。
+ */
+func SearchMatrix(matrix [][]int, target int) bool {
+	m, n := len(matrix), len(matrix[0])
+	i := FirstTrue(0, m*n, func(k int) bool { return matrix[k/n][k%n] >= target })
+	return i < m*n && matrix[i/n][i%n] == target
+}
+
+/**
+ * 162. 寻找峰值
+ * https://leetcode.cn/problems/find-peak-element/
+ * 第一个满足 nums[i] > nums[i+1] 的位置一定是峰值，且谓词对这个「第一个」单调。
+ */
+func FindPeakElement(nums []int) int {
+	n := len(nums)
+	return FirstTrue(0, n-1, func(i int) bool { return nums[i] > nums[i+1] })
+}
+
+/**
+ * 167. 两数之和 II - 输入有序数组
+ * https://leetcode.cn/problems/two-sum-ii-input-array-is-sorted/
+ * 枚举第一个数，在它后面二分查找第二个数，O(n log n)。
+ */
+func TwoSumSorted(numbers []int, target int) []int {
+	for i, x := range numbers {
+		if j := Find(numbers[i+1:], target-x, cmp.Compare[int]); j >= 0 {
+			return []int{i + 1, i + j + 2}
+		}
+	}
+	return nil
+}
+
+/**
+ * 875. 爱吃香蕉的珂珂
+ * https://leetcode.cn/problems/koko-eating-bananas/
+ * 在答案空间上二分：速度越快越能按时吃完。
+ */
+func MinEatingSpeed(piles []int, h int) int {
+	mx := 0
+	for _, p := range piles {
+		mx = max(mx, p)
+	}
+	return FirstTrue(1, mx+1, func(k int) bool {
+		hours := 0
+		for _, p := range piles {
+			hours += (p + k - 1) / k
+		}
+		return hours <= h
+	})
+}
+
+/**
+ * 1011. 在 D 天内送达包裹的能力
+ * https://leetcode.cn/problems/capacity-to-ship-packages-within-d-days/
+ * 运载能力的下界是最重的包裹，上界是总重量。
+ */
+func ShipWithinDays(weights []int, days int) int {
+	lo, sum := 0, 0
+	for _, w := range weights {
+		lo = max(lo, w)
+		sum += w
+	}
+	return FirstTrue(lo, sum+1, func(capacity int) bool {
+		need, load := 1, 0
+		for _, w := range weights {
+			if load+w > capacity {
+				need++
+				load = 0
+			}
+			load += w
+		}
+		return need <= days
+	})
+}
+
+/**
+ * 69. x 的平方根
+ * https://leetcode.cn/problems/sqrtx/
+ */
+func MySqrt(x int) int {
+	return LastFalse(0, x+1, func(k int) bool { return k*k > x })
+}
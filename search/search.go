@@ -0,0 +1,84 @@
+/**
+ * 二分查找工具，所有区间都是左闭右开的 [lo, hi)，避免边界上的差一错误。
+ * 有序切片上的查找接收比较函数，答案空间上的查找接收单调谓词：
+ * 谓词在某个位置之前全为 false，之后全为 true，查找的就是第一个 true。
+ */
+package search
+
+// FirstTrue 返回 [lo, hi) 中第一个使 pred 为 true 的整数，全为 false 时返回 hi。
+func FirstTrue(lo, hi int, pred func(int) bool) int {
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// LastFalse 返回 [lo, hi) 中最后一个使 pred 为 false 的整数，全为 true 时返回 lo-1。
+func LastFalse(lo, hi int, pred func(int) bool) int {
+	return FirstTrue(lo, hi, pred) - 1
+}
+
+// FirstTrueFloat 在实数区间 [lo, hi] 上二分 iterations 次，返回使 pred 为 true 的最小值的近似。
+// 固定迭代次数而不是比较精度，避免浮点误差导致死循环；100 次对 float64 已经足够。
+func FirstTrueFloat(lo, hi float64, iterations int, pred func(float64) bool) float64 {
+	for i := 0; i < iterations; i++ {
+		mid := lo + (hi-lo)/2
+		if pred(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+// LowerBound 返回有序切片中第一个不小于 target 的下标。
+func LowerBound[T any](s []T, target T, cmp func(a, b T) int) int {
+	return FirstTrue(0, len(s), func(i int) bool { return cmp(s[i], target) >= 0 })
+}
+
+// UpperBound 返回有序切片中第一个大于 target 的下标。
+func UpperBound[T any](s []T, target T, cmp func(a, b T) int) int {
+	return FirstTrue(0, len(s), func(i int) bool { return cmp(s[i], target) > 0 })
+}
+
+// EqualRange 返回等于 target 的元素所在的区间 [first, last)。
+func EqualRange[T any](s []T, target T, cmp func(a, b T) int) (int, int) {
+	return LowerBound(s, target, cmp), UpperBound(s, target, cmp)
+}
+
+// Find 返回 target 的下标，不存在时返回 -1；有重复时返回第一个。
+func Find[T any](s []T, target T, cmp func(a, b T) int) int {
+	i := LowerBound(s, target, cmp)
+	if i < len(s) && cmp(s[i], target) == 0 {
+		return i
+	}
+	return -1
+}
+
+// RotatedMin 返回升序数组轮转后最小元素的下标，元素必须互不相同。
+// 最小值之后的元素都不大于最后一个元素，之前的都大于它。
+func RotatedMin[T any](s []T, cmp func(a, b T) int) int {
+	n := len(s)
+	if n == 0 {
+		return -1
+	}
+	return FirstTrue(0, n, func(i int) bool { return cmp(s[i], s[n-1]) <= 0 })
+}
+
+// FindRotated 在轮转过的升序数组中查找 target，元素必须互不相同，不存在时返回 -1。
+func FindRotated[T any](s []T, target T, cmp func(a, b T) int) int {
+	k := RotatedMin(s, cmp)
+	if k < 0 {
+		return -1
+	}
+	if i := Find(s[k:], target, cmp); i >= 0 {
+		return k + i
+	}
+	return Find(s[:k], target, cmp)
+}
@@ -0,0 +1,266 @@
+package search
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// sortedArrays 枚举长度不超过 maxLen、元素取自 [0, alphabet) 的所有非降序数组。
+func sortedArrays(maxLen, alphabet int) [][]int {
+	res := [][]int{{}}
+	var gen func(cur []int, lo int)
+	gen = func(cur []int, lo int) {
+		if len(cur) == maxLen {
+			return
+		}
+		for x := lo; x < alphabet; x++ {
+			next := append(slices.Clone(cur), x)
+			res = append(res, next)
+			gen(next, x)
+		}
+	}
+	gen(nil, 0)
+	return res
+}
+
+// allArrays 枚举长度不超过 maxLen、元素取自 [0, alphabet) 的所有数组。
+func allArrays(maxLen, alphabet int) [][]int {
+	res := [][]int{{}}
+	for start := 0; start < len(res); start++ {
+		if len(res[start]) < maxLen {
+			for x := range alphabet {
+				res = append(res, append(slices.Clone(res[start]), x))
+			}
+		}
+	}
+	return res
+}
+
+func TestFirstTrue(t *testing.T) {
+	for lo := -3; lo <= 3; lo++ {
+		for hi := lo; hi <= lo+6; hi++ {
+			// 谓词在 split 及之后为 true
+			for split := lo; split <= hi; split++ {
+				calls := 0
+				got := FirstTrue(lo, hi, func(i int) bool {
+					calls++
+					if i < lo || i >= hi {
+						t.Fatalf("FirstTrue(%d, %d) called pred(%d)", lo, hi, i)
+					}
+					return i >= split
+				})
+				if got != split {
+					t.Fatalf("FirstTrue(%d, %d) with split %d = %d", lo, hi, split, got)
+				}
+				if got := LastFalse(lo, hi, func(i int) bool { return i >= split }); got != split-1 {
+					t.Fatalf("LastFalse(%d, %d) with split %d = %d", lo, hi, split, got)
+				}
+			}
+		}
+	}
+}
+
+func TestSorted(t *testing.T) {
+	for _, s := range sortedArrays(6, 4) {
+		for target := -1; target <= 4; target++ {
+			lower, upper, find := len(s), len(s), -1
+			for i := len(s) - 1; i >= 0; i-- {
+				if s[i] >= target {
+					lower = i
+				}
+				if s[i] > target {
+					upper = i
+				}
+				if s[i] == target {
+					find = i
+				}
+			}
+			if got := LowerBound(s, target, cmp.Compare[int]); got != lower {
+				t.Fatalf("LowerBound(%v, %d) = %d, want %d", s, target, got, lower)
+			}
+			if got := UpperBound(s, target, cmp.Compare[int]); got != upper {
+				t.Fatalf("UpperBound(%v, %d) = %d, want %d", s, target, got, upper)
+			}
+			if first, last := EqualRange(s, target, cmp.Compare[int]); first != lower || last != upper {
+				t.Fatalf("EqualRange(%v, %d) = %d, %d, want %d, %d", s, target, first, last, lower, upper)
+			}
+			if got := Find(s, target, cmp.Compare[int]); got != find {
+				t.Fatalf("Find(%v, %d) = %d, want %d", s, target, got, find)
+			}
+			if got := SearchInsert(s, target); got != lower {
+				t.Fatalf("SearchInsert(%v, %d) = %d, want %d", s, target, got, lower)
+			}
+			want := []int{-1, -1}
+			if lower < upper {
+				want = []int{lower, upper - 1}
+			}
+			if got := SearchRange(s, target); !slices.Equal(got, want) {
+				t.Fatalf("SearchRange(%v, %d) = %v, want %v", s, target, got, want)
+			}
+		}
+	}
+}
+
+func TestRotated(t *testing.T) {
+	// 互不相同的升序数组：[0, 6) 的所有子集
+	for mask := 0; mask < 1<<6; mask++ {
+		var sorted []int
+		for x := range 6 {
+			if mask>>x&1 == 1 {
+				sorted = append(sorted, x)
+			}
+		}
+		for k := range max(len(sorted), 1) {
+			s := append(slices.Clone(sorted[k:]), sorted[:k]...)
+			wantMin := -1
+			if len(s) > 0 {
+				wantMin = (len(s) - k) % len(s)
+			}
+			if got := RotatedMin(s, cmp.Compare[int]); got != wantMin {
+				t.Fatalf("RotatedMin(%v) = %d, want %d", s, got, wantMin)
+			}
+			if len(s) > 0 {
+				if got := FindMin(s); got != sorted[0] {
+					t.Fatalf("FindMin(%v) = %d, want %d", s, got, sorted[0])
+				}
+			}
+			for target := -1; target <= 6; target++ {
+				want := slices.Index(s, target)
+				if got := FindRotated(s, target, cmp.Compare[int]); got != want {
+					t.Fatalf("FindRotated(%v, %d) = %d, want %d", s, target, got, want)
+				}
+				if got := SearchRotated(s, target); got != want {
+					t.Fatalf("SearchRotated(%v, %d) = %d, want %d", s, target, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestSearchMatrix(t *testing.T) {
+	for _, s := range sortedArrays(6, 4) {
+		for n := 1; n <= len(s); n++ {
+			if len(s)%n != 0 {
+				continue
+			}
+			var matrix [][]int
+			for i := 0; i < len(s); i += n {
+				matrix = append(matrix, s[i:i+n])
+			}
+			for target := -1; target <= 4; target++ {
+				if got, want := SearchMatrix(matrix, target), slices.Contains(s, target); got != want {
+					t.Fatalf("SearchMatrix(%v, %d) = %v, want %v", matrix, target, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestFindPeakElement(t *testing.T) {
+	for _, s := range allArrays(6, 4) {
+		if len(s) == 0 {
+			continue
+		}
+		distinct := true
+		for i := 1; i < len(s); i++ {
+			distinct = distinct && s[i] != s[i-1]
+		}
+		if !distinct {
+			continue
+		}
+		i := FindPeakElement(s)
+		if i < 0 || i >= len(s) || i > 0 && s[i] < s[i-1] || i+1 < len(s) && s[i] < s[i+1] {
+			t.Fatalf("FindPeakElement(%v) = %d, not a peak", s, i)
+		}
+	}
+}
+
+func TestTwoSumSorted(t *testing.T) {
+	for _, s := range sortedArrays(6, 4) {
+		for target := 0; target <= 7; target++ {
+			possible := false
+			for i := range s {
+				for j := i + 1; j < len(s); j++ {
+					possible = possible || s[i]+s[j] == target
+				}
+			}
+			got := TwoSumSorted(s, target)
+			if got == nil {
+				if possible {
+					t.Fatalf("TwoSumSorted(%v, %d) = nil", s, target)
+				}
+				continue
+			}
+			if i, j := got[0]-1, got[1]-1; i < 0 || i >= j || j >= len(s) || s[i]+s[j] != target {
+				t.Fatalf("TwoSumSorted(%v, %d) = %v", s, target, got)
+			}
+		}
+	}
+}
+
+func TestEatingAndShipping(t *testing.T) {
+	for _, s := range allArrays(5, 4) {
+		// 题目保证至少一堆、每堆至少一根、包裹重量为正
+		piles := slices.Clone(s)
+		for i := range piles {
+			piles[i]++
+		}
+		if len(piles) == 0 {
+			continue
+		}
+		for h := len(piles); h <= len(piles)+4; h++ {
+			want := 1
+			for !eatsWithin(piles, want, h) {
+				want++
+			}
+			if got := MinEatingSpeed(piles, h); got != want {
+				t.Fatalf("MinEatingSpeed(%v, %d) = %d, want %d", piles, h, got, want)
+			}
+		}
+		for days := 1; days <= len(piles); days++ {
+			want := 1
+			for shipDays(piles, want) > days {
+				want++
+			}
+			if got := ShipWithinDays(piles, days); got != want {
+				t.Fatalf("ShipWithinDays(%v, %d) = %d, want %d", piles, days, got, want)
+			}
+		}
+	}
+}
+
+func eatsWithin(piles []int, k, h int) bool {
+	for _, p := range piles {
+		for ; p > 0; p -= k {
+			h--
+		}
+	}
+	return h >= 0
+}
+
+// shipDays 按顺序装船需要的天数，有包裹超过 capacity 时返回一个很大的数。
+func shipDays(weights []int, capacity int) int {
+	days, load := 1, 0
+	for _, w := range weights {
+		if w > capacity {
+			return 1 << 30
+		}
+		if load+w > capacity {
+			days, load = days+1, 0
+		}
+		load += w
+	}
+	return days
+}
+
+func TestMySqrt(t *testing.T) {
+	for x, r := 0, 0; x <= 10000; x++ {
+		if (r+1)*(r+1) <= x {
+			r++
+		}
+		if got := MySqrt(x); got != r {
+			t.Fatalf("MySqrt(%d) = %d, want %d", x, got, r)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package slicesx
+
+import "iter"
+
+// Ring 环形缓冲区，逻辑轮转只移动起点，时间 O(1)。
+type Ring[T any] struct {
+	buf  []T
+	head int // 逻辑下标 0 对应的物理下标
+}
+
+// NewRing 复制 items 创建一个环形缓冲区。
+func NewRing[T any](items ...T) *Ring[T] {
+	buf := make([]T, len(items))
+	copy(buf, items)
+	return &Ring[T]{buf: buf}
+}
+
+func (r *Ring[T]) Len() int {
+	return len(r.buf)
+}
+
+func (r *Ring[T]) index(i int) int {
+	n := len(r.buf)
+	if i < 0 || i >= n {
+		panic("slicesx: ring index out of range")
+	}
+	return (r.head + i) % n
+}
+
+// At 返回逻辑下标 i 的元素。
+func (r *Ring[T]) At(i int) T {
+	return r.buf[r.index(i)]
+}
+
+// Set 设置逻辑下标 i 的元素。
+func (r *Ring[T]) Set(i int, v T) {
+	r.buf[r.index(i)] = v
+}
+
+// Rotate 向右轮转 k 个位置，与 slicesx.Rotate 语义一致。
+func (r *Ring[T]) Rotate(k int) {
+	n := len(r.buf)
+	if n == 0 {
+		return
+	}
+	r.head = ((r.head-k%n)%n + n) % n
+}
+
+// Slice 按逻辑顺序返回元素的副本。
+func (r *Ring[T]) Slice() []T {
+	res := make([]T, 0, len(r.buf))
+	res = append(res, r.buf[r.head:]...)
+	return append(res, r.buf[:r.head]...)
+}
+
+// All 按逻辑顺序遍历下标和元素。
+func (r *Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		n := len(r.buf)
+		for i := 0; i < n; i++ {
+			if !yield(i, r.buf[(r.head+i)%n]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package slicesx
+
+// RotateAlgo 选择 Rotate 使用的算法，便于做基准对比。
+type RotateAlgo int
+
+const (
+	Reversal  RotateAlgo = iota // 三次翻转
+	Juggling                    // 按 gcd(n, k) 个环依次搬动
+	BlockSwap                   // 块交换，递归地把较短的块换到最终位置
+)
+
+func (a RotateAlgo) String() string {
+	switch a {
+	case Reversal:
+		return "reversal"
+	case Juggling:
+		return "juggling"
+	case BlockSwap:
+		return "blockswap"
+	}
+	return "unknown"
+}
+
+// Rotate 将切片原地向右轮转 k 个位置，k 可以为负数（向左）或超过长度。
+func Rotate[S ~[]E, E any](s S, k int) {
+	RotateWith(s, k, Reversal)
+}
+
+// RotateWith 用指定算法将切片原地向右轮转 k 个位置。
+func RotateWith[S ~[]E, E any](s S, k int, algo RotateAlgo) {
+	n := len(s)
+	if n == 0 {
+		return
+	}
+	// 向右轮转 k 等价于向左轮转 n-k；先对 n 取余，k 为 math.MinInt 时 n-k 会溢出
+	left := (n - k%n) % n
+	if left == 0 {
+		return
+	}
+
+	switch algo {
+	case Juggling:
+		rotateJuggling(s, left)
+	case BlockSwap:
+		rotateBlockSwap(s, left)
+	default:
+		rotateReversal(s, left)
+	}
+}
+
+func reverse[E any](s []E) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func rotateReversal[E any](s []E, left int) {
+	reverse(s[:left])
+	reverse(s[left:])
+	reverse(s)
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func rotateJuggling[E any](s []E, left int) {
+	n := len(s)
+	for start := 0; start < gcd(n, left); start++ {
+		tmp := s[start]
+		i := start
+		for {
+			j := i + left
+			if j >= n {
+				j -= n
+			}
+			if j == start {
+				break
+			}
+			s[i] = s[j]
+			i = j
+		}
+		s[i] = tmp
+	}
+}
+
+// swapBlocks 交换 s[a:a+m] 和 s[b:b+m] 两个不重叠的块。
+func swapBlocks[E any](s []E, a, b, m int) {
+	for i := 0; i < m; i++ {
+		s[a+i], s[b+i] = s[b+i], s[a+i]
+	}
+}
+
+func rotateBlockSwap[E any](s []E, left int) {
+	// 当前待处理区间为 s[lo:hi]，把 A = s[lo:lo+i] 与 B = s[lo+i:hi] 互换位置
+	lo, hi := 0, len(s)
+	i := left
+	for i > 0 && i < hi-lo {
+		a, b := i, hi-lo-i
+		if a <= b {
+			// A B_l B_r，|A| == |B_r|：交换 A 和 B_r 后 A 已就位
+			swapBlocks(s, lo, hi-a, a)
+			hi -= a
+		} else {
+			// A_l A_r B，|A_l| == |B|：交换 A_l 和 B 后 B 已就位
+			swapBlocks(s, lo, lo+a, b)
+			lo += b
+			i -= b
+		}
+	}
+}
@@ -0,0 +1,49 @@
+/**
+ * 原地切片算法的泛型版本，来自 removeElement、removeDuplicates、removeDuplicatesII 和 rotate。
+ * https://leetcode.cn/problems/remove-element/
+ * https://leetcode.cn/problems/remove-duplicates-from-sorted-array-ii/
+ * https://leetcode.cn/problems/rotate-array/
+ */
+package slicesx
+
+// RemoveIf 原地删除满足 pred 的元素，保持其余元素的相对顺序，返回缩短后的切片。
+// 与 slices.DeleteFunc 一样，尾部空出的位置会被清零。
+func RemoveIf[S ~[]E, E any](s S, pred func(E) bool) S {
+	left := 0
+	for _, v := range s {
+		if !pred(v) {
+			s[left] = v
+			left++
+		}
+	}
+	clear(s[left:])
+	return s[:left]
+}
+
+// Remove 原地删除所有等于 val 的元素。
+func Remove[S ~[]E, E comparable](s S, val E) S {
+	return RemoveIf(s, func(v E) bool { return v == val })
+}
+
+// DedupSortedAtMostK 有序切片中每个元素最多保留 k 个，返回缩短后的切片。
+// k == 1 即 removeDuplicates，k == 2 即 removeDuplicatesII；k <= 0 时返回空切片。
+func DedupSortedAtMostK[S ~[]E, E comparable](s S, k int) S {
+	if k <= 0 {
+		clear(s)
+		return s[:0]
+	}
+	n := len(s)
+	if n <= k {
+		return s
+	}
+
+	slow := k
+	for fast := k; fast < n; fast++ {
+		if s[slow-k] != s[fast] {
+			s[slow] = s[fast]
+			slow++
+		}
+	}
+	clear(s[slow:])
+	return s[:slow]
+}
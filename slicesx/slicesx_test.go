@@ -0,0 +1,85 @@
+package slicesx
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func FuzzRemoveIf(f *testing.F) {
+	f.Add([]byte{}, byte(1))
+	f.Add([]byte{3, 2, 2, 3}, byte(2))
+	f.Add([]byte{0, 1, 2, 2, 3, 0, 4, 2}, byte(1))
+	f.Fuzz(func(t *testing.T, data []byte, mod byte) {
+		m := mod%7 + 1
+		pred := func(v byte) bool { return v%m == 0 }
+		want := slices.DeleteFunc(slices.Clone(data), pred)
+
+		s := slices.Clone(data)
+		got := RemoveIf(s, pred)
+		if !slices.Equal(got, want) {
+			t.Fatalf("RemoveIf(%v, %%%d) = %v, want %v", data, m, got, want)
+		}
+		if tail := s[len(got):]; slices.ContainsFunc(tail, func(v byte) bool { return v != 0 }) {
+			t.Fatalf("RemoveIf(%v, %%%d) left %v after the result, want zeros", data, m, tail)
+		}
+	})
+}
+
+func FuzzRotateWith(f *testing.F) {
+	f.Add([]byte{}, 3)
+	f.Add([]byte{1, 2, 3, 4, 5, 6, 7}, 3)
+	f.Add([]byte{1, 2, 3, 4, 5, 6}, -4)
+	f.Add([]byte{1, 2, 3, 4}, 1<<62)
+	f.Add([]byte{1, 2, 3}, math.MinInt)
+	f.Fuzz(func(t *testing.T, data []byte, k int) {
+		n := len(data)
+		want := slices.Clone(data)
+		if n > 0 {
+			r := (k%n + n) % n
+			want = slices.Concat(data[n-r:], data[:n-r])
+		}
+		for _, algo := range []RotateAlgo{Reversal, Juggling, BlockSwap} {
+			got := slices.Clone(data)
+			RotateWith(got, k, algo)
+			if !slices.Equal(got, want) {
+				t.Fatalf("RotateWith(%v, %d, %v) = %v, want %v", data, k, algo, got, want)
+			}
+		}
+		ring := NewRing(data...)
+		ring.Rotate(k)
+		if got := ring.Slice(); !slices.Equal(got, want) {
+			t.Fatalf("Ring(%v).Rotate(%d) = %v, want %v", data, k, got, want)
+		}
+	})
+}
+
+func FuzzDedupSortedAtMostK(f *testing.F) {
+	f.Add([]byte{}, 2)
+	f.Add([]byte{1, 1, 1, 2, 2, 3}, 2)
+	f.Add([]byte{0, 0, 1, 1, 1, 1, 2, 3, 3}, 1)
+	f.Add([]byte{5, 5, 5}, 0)
+	f.Fuzz(func(t *testing.T, data []byte, k int) {
+		k %= 5
+		s := slices.Sorted(slices.Values(data))
+
+		// 参照实现：每一段相同元素保留前 k 个
+		want := []byte{}
+		for i := 0; i < len(s); {
+			j := i + 1
+			for j < len(s) && s[j] == s[i] {
+				j++
+			}
+			want = append(want, s[i:i+max(min(k, j-i), 0)]...)
+			i = j
+		}
+		if k == 1 && !slices.Equal(want, slices.Compact(slices.Clone(s))) {
+			t.Fatalf("reference disagrees with slices.Compact on %v", s)
+		}
+
+		got := DedupSortedAtMostK(slices.Clone(s), k)
+		if !slices.Equal(got, want) && !(len(got) == 0 && len(want) == 0) {
+			t.Fatalf("DedupSortedAtMostK(%v, %d) = %v, want %v", s, k, got, want)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package sorting
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"slices"
+	"time"
+)
+
+// Input 基准测试使用的一种输入分布。
+type Input struct {
+	Name string
+	Gen  func(n int, r *rand.Rand) []int
+}
+
+// Inputs 随机、有序、逆序、大量重复四种输入。
+var Inputs = []Input{
+	{"random", func(n int, r *rand.Rand) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.Int()
+		}
+		return s
+	}},
+	{"sorted", func(n int, r *rand.Rand) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		return s
+	}},
+	{"reversed", func(n int, r *rand.Rand) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = n - i
+		}
+		return s
+	}},
+	{"duplicates", func(n int, r *rand.Rand) []int {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.Intn(8)
+		}
+		return s
+	}},
+}
+
+// Row 基准矩阵中的一行结果。
+type Row struct {
+	Input       string
+	Algorithm   string
+	N           int
+	NsPerOp     int64
+	Comparisons int
+	Swaps       int
+}
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+// Matrix 对每种输入、每个算法以及作为基准的 slices.Sort 计时，
+// 比较和交换次数取自一次带 Counter 的排序。slices.Sort 无法统计次数，记为 -1。
+func Matrix(n int, seed int64) []Row {
+	rows := []Row{}
+	for _, in := range Inputs {
+		data := in.Gen(n, rand.New(rand.NewSource(seed)))
+		for _, algo := range Algorithms[int]() {
+			// O(n^2) 的算法在大输入上太慢，跳过
+			if n > 5000 && (algo.Name == "bubble" || algo.Name == "selection" || algo.Name == "insertion") {
+				continue
+			}
+			cnt := &Counter{}
+			algo.Sort(slices.Clone(data), cmpInt, cnt)
+			ns := benchmark(data, func(s []int) { algo.Sort(s, cmpInt, nil) })
+			rows = append(rows, Row{in.Name, algo.Name, n, ns, cnt.Comparisons, cnt.Swaps})
+		}
+		ns := benchmark(data, slices.Sort[[]int])
+		rows = append(rows, Row{in.Name, "slices.Sort", n, ns, -1, -1})
+	}
+	return rows
+}
+
+// benchTime 每个算法在每种输入上的计时总时长，与 go test -bench 的默认值相同，测试中会调小。
+var benchTime = time.Second
+
+// benchmark 返回 sort 排序一份 data 拷贝的平均耗时（纳秒）。每轮先拷贝出一批输入再统一计时，
+// 拷贝不计入耗时；一批的大小让小输入也能摊薄 time.Now 的开销。
+func benchmark(data []int, sort func([]int)) int64 {
+	bufs := make([][]int, min(max(1<<16/max(len(data), 1), 1), 1024))
+	for i := range bufs {
+		bufs[i] = make([]int, len(data))
+	}
+	var elapsed time.Duration
+	ops := 0
+	for elapsed < benchTime {
+		for _, buf := range bufs {
+			copy(buf, data)
+		}
+		start := time.Now()
+		for _, buf := range bufs {
+			sort(buf)
+		}
+		elapsed += time.Since(start)
+		ops += len(bufs)
+	}
+	return elapsed.Nanoseconds() / int64(ops)
+}
+
+// WriteTable 以 Markdown 表格输出基准结果，ratio 列是相对同一输入下 slices.Sort 的耗时倍数。
+func WriteTable(w io.Writer, rows []Row) error {
+	base := map[string]int64{}
+	for _, r := range rows {
+		if r.Algorithm == "slices.Sort" {
+			base[r.Input] = r.NsPerOp
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, "| input | algorithm | n | ns/op | ratio | comparisons | swaps |"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "|---|---|---|---|---|---|---|"); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		ratio := "-"
+		if b := base[r.Input]; b > 0 {
+			ratio = fmt.Sprintf("%.2fx", float64(r.NsPerOp)/float64(b))
+		}
+		cmps, swaps := "-", "-"
+		if r.Comparisons >= 0 {
+			cmps, swaps = fmt.Sprint(r.Comparisons), fmt.Sprint(r.Swaps)
+		}
+		if _, err := fmt.Fprintf(w, "| %s | %s | %d | %d | %s | %s | %s |\n",
+			r.Input, r.Algorithm, r.N, r.NsPerOp, ratio, cmps, swaps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
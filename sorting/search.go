@@ -0,0 +1,101 @@
+package sorting
+
+/**
+ * 704. 二分查找
+ * https://leetcode.cn/problems/binary-search/
+ * 在按 cmp 升序排列的 s 中查找 target，返回下标和是否找到；
+ * 找不到时下标为 target 应插入的位置。
+ */
+func BinarySearch[T any](s []T, target T, cmp func(a, b T) int, hook Hook) (int, bool) {
+	st := &sorter[T]{s, cmp, hook}
+	lo, hi := 0, len(s)
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if st.compare(s[mid], target) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(s) && st.compare(s[lo], target) == 0
+}
+
+// BST 不做平衡的二叉搜索树，用于演示按比较查找。
+type BST[T any] struct {
+	root *bstNode[T]
+	cmp  func(a, b T) int
+	size int
+}
+
+type bstNode[T any] struct {
+	val         T
+	left, right *bstNode[T]
+}
+
+func NewBST[T any](cmp func(a, b T) int, items ...T) *BST[T] {
+	t := &BST[T]{cmp: cmp}
+	for _, v := range items {
+		t.Insert(v)
+	}
+	return t
+}
+
+// Insert 插入 v，已存在相等元素时返回 false。
+func (t *BST[T]) Insert(v T) bool {
+	p := &t.root
+	for *p != nil {
+		c := t.cmp(v, (*p).val)
+		switch {
+		case c < 0:
+			p = &(*p).left
+		case c > 0:
+			p = &(*p).right
+		default:
+			return false
+		}
+	}
+	*p = &bstNode[T]{val: v}
+	t.size++
+	return true
+}
+
+// Search 查找与 v 相等的元素。
+func (t *BST[T]) Search(v T, hook Hook) (T, bool) {
+	for n := t.root; n != nil; {
+		if hook != nil {
+			hook.Compare()
+		}
+		c := t.cmp(v, n.val)
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.val, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+func (t *BST[T]) Len() int {
+	return t.size
+}
+
+// InOrder 中序遍历，结果按 cmp 升序。
+func (t *BST[T]) InOrder() []T {
+	res := make([]T, 0, t.size)
+	stack := []*bstNode[T]{}
+	for n := t.root; n != nil || len(stack) > 0; {
+		for n != nil {
+			stack = append(stack, n)
+			n = n.left
+		}
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		res = append(res, n.val)
+		n = n.right
+	}
+	return res
+}
@@ -0,0 +1,255 @@
+/**
+ * 排序算法，对应 面试准备/算法.md 中的快速排序、归并排序等。
+ * 所有算法都接收一个比较函数（与 slices.SortFunc 的约定相同），
+ * 并通过 Hook 统计比较和交换次数，hook 为 nil 时不做统计。
+ */
+package sorting
+
+// Hook 接收排序过程中的比较和交换事件。
+type Hook interface {
+	Compare()
+	Swap()
+}
+
+// Counter 统计比较和交换次数的 Hook。
+type Counter struct {
+	Comparisons int
+	Swaps       int
+}
+
+func (c *Counter) Compare() {
+	c.Comparisons++
+}
+
+func (c *Counter) Swap() {
+	c.Swaps++
+}
+
+func (c *Counter) Reset() {
+	*c = Counter{}
+}
+
+// Func 排序函数的统一签名。
+type Func[T any] func(s []T, cmp func(a, b T) int, hook Hook)
+
+// Algorithm 一个具名的排序算法。
+type Algorithm[T any] struct {
+	Name   string
+	Stable bool
+	Sort   Func[T]
+}
+
+// Algorithms 返回本包实现的全部排序算法。
+func Algorithms[T any]() []Algorithm[T] {
+	return []Algorithm[T]{
+		{"quick", false, Quick[T]},
+		{"merge", true, Merge[T]},
+		{"heap", false, Heap[T]},
+		{"insertion", true, Insertion[T]},
+		{"selection", false, Selection[T]},
+		{"bubble", true, Bubble[T]},
+	}
+}
+
+// sorter 把比较和交换集中起来，方便统一上报给 hook。
+type sorter[T any] struct {
+	s    []T
+	cmp  func(a, b T) int
+	hook Hook
+}
+
+func (st *sorter[T]) compare(a, b T) int {
+	if st.hook != nil {
+		st.hook.Compare()
+	}
+	return st.cmp(a, b)
+}
+
+func (st *sorter[T]) less(i, j int) bool {
+	return st.compare(st.s[i], st.s[j]) < 0
+}
+
+func (st *sorter[T]) swap(i, j int) {
+	if st.hook != nil {
+		st.hook.Swap()
+	}
+	st.s[i], st.s[j] = st.s[j], st.s[i]
+}
+
+// moved 记录一次非交换的元素写入（归并排序），按一次交换计数。
+func (st *sorter[T]) moved() {
+	if st.hook != nil {
+		st.hook.Swap()
+	}
+}
+
+// Bubble 冒泡排序，一轮没有交换时提前结束。稳定，O(n^2)。
+func Bubble[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	for end := len(s) - 1; end > 0; end-- {
+		swapped := false
+		for i := 0; i < end; i++ {
+			if st.less(i+1, i) {
+				st.swap(i, i+1)
+				swapped = true
+			}
+		}
+		if !swapped {
+			return
+		}
+	}
+}
+
+// Selection 选择排序，每轮把最小值交换到前面。不稳定，O(n^2)。
+func Selection[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	n := len(s)
+	for i := 0; i < n-1; i++ {
+		minIdx := i
+		for j := i + 1; j < n; j++ {
+			if st.less(j, minIdx) {
+				minIdx = j
+			}
+		}
+		if minIdx != i {
+			st.swap(i, minIdx)
+		}
+	}
+}
+
+// Insertion 插入排序，把新元素逐个往前交换到位。稳定，近乎有序时接近 O(n)。
+func Insertion[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && st.less(j, j-1); j-- {
+			st.swap(j, j-1)
+		}
+	}
+}
+
+// Quick 快速排序，三数取中选基准、三路划分处理大量重复元素，
+// 先递归较短的一侧以保证栈深度为 O(log n)。不稳定。
+func Quick[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	st.quick(0, len(s)-1)
+}
+
+func (st *sorter[T]) quick(lo, hi int) {
+	for lo < hi {
+		if hi-lo < 12 {
+			for i := lo + 1; i <= hi; i++ {
+				for j := i; j > lo && st.less(j, j-1); j-- {
+					st.swap(j, j-1)
+				}
+			}
+			return
+		}
+
+		st.medianOfThree(lo, lo+(hi-lo)/2, hi)
+		pivot := st.s[lo]
+
+		// [lo, lt) < pivot, [lt, i) == pivot, (gt, hi] > pivot
+		lt, i, gt := lo, lo+1, hi
+		for i <= gt {
+			c := st.compare(st.s[i], pivot)
+			switch {
+			case c < 0:
+				st.swap(lt, i)
+				lt++
+				i++
+			case c > 0:
+				st.swap(i, gt)
+				gt--
+			default:
+				i++
+			}
+		}
+
+		if lt-lo < hi-gt {
+			st.quick(lo, lt-1)
+			lo = gt + 1
+		} else {
+			st.quick(gt+1, hi)
+			hi = lt - 1
+		}
+	}
+}
+
+// medianOfThree 把 a、b、c 三个位置的中位数放到 a。
+func (st *sorter[T]) medianOfThree(a, b, c int) {
+	if st.less(b, a) {
+		st.swap(a, b)
+	}
+	if st.less(c, b) {
+		st.swap(b, c)
+		if st.less(b, a) {
+			st.swap(a, b)
+		}
+	}
+	st.swap(a, b)
+}
+
+// Merge 自顶向下归并排序。稳定，O(n log n)，需要 O(n) 辅助空间。
+// 归并时写回原切片的每个元素按一次交换计数。
+func Merge[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	buf := make([]T, len(s))
+	st.mergeSort(buf, 0, len(s))
+}
+
+func (st *sorter[T]) mergeSort(buf []T, lo, hi int) {
+	if hi-lo < 2 {
+		return
+	}
+	mid := lo + (hi-lo)/2
+	st.mergeSort(buf, lo, mid)
+	st.mergeSort(buf, mid, hi)
+	// 两半已经有序，无需归并
+	if !st.less(mid, mid-1) {
+		return
+	}
+
+	copy(buf[lo:hi], st.s[lo:hi])
+	i, j := lo, mid
+	for k := lo; k < hi; k++ {
+		// 相等时取左半边，保证稳定
+		if j >= hi || (i < mid && st.compare(buf[j], buf[i]) >= 0) {
+			st.s[k] = buf[i]
+			i++
+		} else {
+			st.s[k] = buf[j]
+			j++
+		}
+		st.moved()
+	}
+}
+
+// Heap 堆排序，原地建大顶堆后依次把堆顶换到末尾。不稳定，O(n log n)。
+func Heap[T any](s []T, cmp func(a, b T) int, hook Hook) {
+	st := &sorter[T]{s, cmp, hook}
+	n := len(s)
+	for i := n/2 - 1; i >= 0; i-- {
+		st.siftDown(i, n)
+	}
+	for end := n - 1; end > 0; end-- {
+		st.swap(0, end)
+		st.siftDown(0, end)
+	}
+}
+
+func (st *sorter[T]) siftDown(i, n int) {
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if child+1 < n && st.less(child, child+1) {
+			child++
+		}
+		if !st.less(i, child) {
+			return
+		}
+		st.swap(i, child)
+		i = child
+	}
+}
@@ -0,0 +1,162 @@
+package sorting
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAlgorithms(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, algo := range Algorithms[int]() {
+		for n := range 40 {
+			for _, in := range Inputs {
+				data := in.Gen(n, r)
+				want := slices.Sorted(slices.Values(data))
+				got := slices.Clone(data)
+				cnt := &Counter{}
+				algo.Sort(got, cmp.Compare[int], cnt)
+				if !slices.Equal(got, want) {
+					t.Fatalf("%s(%v) = %v, want %v", algo.Name, data, got, want)
+				}
+				if n > 1 && cnt.Comparisons == 0 {
+					t.Fatalf("%s(%v) reported no comparisons", algo.Name, data)
+				}
+				// 不统计时结果相同
+				got = slices.Clone(data)
+				algo.Sort(got, cmp.Compare[int], nil)
+				if !slices.Equal(got, want) {
+					t.Fatalf("%s(%v) without a hook = %v", algo.Name, data, got)
+				}
+			}
+		}
+	}
+}
+
+// TestStable 标记为稳定的算法从不打乱相等元素的顺序，其余算法在大量重复的输入上确实会打乱。
+func TestStable(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for _, algo := range Algorithms[indexed[int]]() {
+		reordered := false
+		for range 200 {
+			data := make([]int, r.Intn(30))
+			for i := range data {
+				data[i] = r.Intn(4)
+			}
+			i := CheckStable(algo.Sort, data, cmp.Compare[int])
+			if algo.Stable && i >= 0 {
+				t.Fatalf("%s reordered equal elements at %d of %v", algo.Name, i, data)
+			}
+			reordered = reordered || i >= 0
+		}
+		if !algo.Stable && !reordered {
+			t.Errorf("%s is marked unstable but never reordered equal elements", algo.Name)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for range 500 {
+		data := Inputs[3].Gen(r.Intn(20), r)
+		slices.Sort(data)
+		target := r.Intn(10) - 1
+		i, found := BinarySearch(data, target, cmp.Compare[int], nil)
+		wi, wfound := slices.BinarySearch(data, target)
+		if i != wi || found != wfound {
+			t.Fatalf("BinarySearch(%v, %d) = %d, %v, want %d, %v", data, target, i, found, wi, wfound)
+		}
+
+		tree := NewBST(cmp.Compare[int], data...)
+		uniq := slices.Compact(slices.Clone(data))
+		if got := tree.InOrder(); !slices.Equal(got, uniq) || tree.Len() != len(uniq) {
+			t.Fatalf("BST(%v).InOrder() = %v, Len = %d", data, got, tree.Len())
+		}
+		if _, ok := tree.Search(target, nil); ok != wfound {
+			t.Fatalf("BST(%v).Search(%d) = %v", data, target, ok)
+		}
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	defer func(d time.Duration) { benchTime = d }(benchTime)
+	benchTime = time.Millisecond
+	rows := Matrix(64, 1)
+	if want := len(Inputs) * (len(Algorithms[int]()) + 1); len(rows) != want {
+		t.Fatalf("Matrix returned %d rows, want %d", len(rows), want)
+	}
+	for _, r := range rows {
+		if r.NsPerOp <= 0 || r.N != 64 {
+			t.Fatalf("row %+v", r)
+		}
+		if (r.Algorithm == "slices.Sort") != (r.Comparisons < 0) {
+			t.Fatalf("row %+v: only slices.Sort has no comparison count", r)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := WriteTable(&b, rows); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != len(rows)+2 {
+		t.Fatalf("WriteTable wrote %d lines, want %d", len(lines), len(rows)+2)
+	}
+	if !strings.HasPrefix(lines[len(lines)-1], "| duplicates | slices.Sort | 64 |") || !strings.Contains(lines[len(lines)-1], "| 1.00x | - | - |") {
+		t.Fatalf("last line %q", lines[len(lines)-1])
+	}
+}
+
+// failWriter 第 n 次写入开始返回错误。
+type failWriter struct {
+	n int
+}
+
+var errWrite = errors.New("write failed")
+
+func (w *failWriter) Write(p []byte) (int, error) {
+	if w.n--; w.n < 0 {
+		return 0, errWrite
+	}
+	return len(p), nil
+}
+
+func TestWriteTableError(t *testing.T) {
+	rows := []Row{{"random", "quick", 10, 100, 20, 5}}
+	for n := range 3 {
+		if err := WriteTable(&failWriter{n}, rows); err != errWrite {
+			t.Errorf("WriteTable failing at write %d = %v", n, err)
+		}
+	}
+}
+
+var matrixSizes = flag.String("matrix.n", "1000,100000", "BenchmarkMatrix 的输入规模，逗号分隔")
+
+// BenchmarkMatrix 输出完整的基准矩阵（Markdown 表格），每个单元格计时 1s，需要几分钟：
+//
+//	go test -run '^$' -bench Matrix ./sorting
+//	go test -run '^$' -bench Matrix ./sorting -matrix.n 100,1000
+//
+// Matrix 自己计时，b.N 只会是 1。
+func BenchmarkMatrix(b *testing.B) {
+	var rows []Row
+	for _, s := range strings.Split(*matrixSizes, ",") {
+		var n int
+		if _, err := fmt.Sscan(s, &n); err != nil || n <= 0 {
+			b.Fatalf("bad -matrix.n %q", s)
+		}
+		rows = append(rows, Matrix(n, 1)...)
+	}
+	b.StopTimer()
+	if err := WriteTable(os.Stdout, rows); err != nil {
+		b.Fatal(err)
+	}
+}
@@ -0,0 +1,33 @@
+package sorting
+
+// CheckStable 用带原始下标的副本对 s 排序，检查相等元素是否保持原有顺序。
+// 返回第一个相对顺序被打乱的位置，稳定时返回 -1。
+func CheckStable[T any](sort Func[indexed[T]], s []T, cmp func(a, b T) int) int {
+	items := make([]indexed[T], len(s))
+	for i, v := range s {
+		items[i] = indexed[T]{v, i}
+	}
+	sort(items, func(a, b indexed[T]) int { return cmp(a.val, b.val) }, nil)
+
+	for i := 1; i < len(items); i++ {
+		if cmp(items[i-1].val, items[i].val) == 0 && items[i-1].idx > items[i].idx {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsSorted 检查 s 是否按 cmp 升序。
+func IsSorted[T any](s []T, cmp func(a, b T) int) bool {
+	for i := 1; i < len(s); i++ {
+		if cmp(s[i-1], s[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type indexed[T any] struct {
+	val T
+	idx int
+}
@@ -0,0 +1,117 @@
+package strmatch
+
+import (
+	"bufio"
+	"io"
+)
+
+// Match Aho–Corasick 的一次匹配：第 Pattern 个模式串出现在 Offset 处。
+type Match struct {
+	Pattern int
+	Offset  int
+}
+
+// AhoCorasick 多模式串匹配自动机：在字典树上加失配指针，一次扫描找出所有模式串的所有出现。
+type AhoCorasick struct {
+	patterns []string
+	next     []map[byte]int
+	fail     []int
+	out      [][]int // 以该结点结尾的模式串，包含沿失配指针可达的结点
+}
+
+func NewAhoCorasick(patterns ...string) *AhoCorasick {
+	ac := &AhoCorasick{patterns: patterns}
+	ac.newNode()
+	for idx, p := range patterns {
+		cur := 0
+		for i := 0; i < len(p); i++ {
+			nxt, ok := ac.next[cur][p[i]]
+			if !ok {
+				nxt = ac.newNode()
+				ac.next[cur][p[i]] = nxt
+			}
+			cur = nxt
+		}
+		ac.out[cur] = append(ac.out[cur], idx)
+	}
+
+	// 按 BFS 顺序计算失配指针，父结点的失配指针总是先算好
+	queue := []int{}
+	for _, child := range ac.next[0] {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		ac.out[u] = append(ac.out[u], ac.out[ac.fail[u]]...)
+		for c, v := range ac.next[u] {
+			ac.fail[v] = ac.goTo(ac.fail[u], c)
+			queue = append(queue, v)
+		}
+	}
+	return ac
+}
+
+func (ac *AhoCorasick) newNode() int {
+	ac.next = append(ac.next, map[byte]int{})
+	ac.fail = append(ac.fail, 0)
+	ac.out = append(ac.out, nil)
+	return len(ac.next) - 1
+}
+
+// goTo 从状态 s 读入 c 后的状态。
+func (ac *AhoCorasick) goTo(s int, c byte) int {
+	for {
+		if nxt, ok := ac.next[s][c]; ok {
+			return nxt
+		}
+		if s == 0 {
+			return 0
+		}
+		s = ac.fail[s]
+	}
+}
+
+func (ac *AhoCorasick) Patterns() []string {
+	return ac.patterns
+}
+
+// emit 报告在 end（不含）处结束的所有匹配。
+func (ac *AhoCorasick) emit(res []Match, s, end int) []Match {
+	for _, idx := range ac.out[s] {
+		res = append(res, Match{Pattern: idx, Offset: end - len(ac.patterns[idx])})
+	}
+	return res
+}
+
+// FindAll 返回所有匹配，按结束位置升序排列。
+func (ac *AhoCorasick) FindAll(text string) []Match {
+	res := ac.emit(nil, 0, 0)
+	s := 0
+	for i := 0; i < len(text); i++ {
+		s = ac.goTo(s, text[i])
+		res = ac.emit(res, s, i+1)
+	}
+	return res
+}
+
+// FindAllReader 逐字节读取 r 并返回所有匹配。
+func (ac *AhoCorasick) FindAllReader(r io.Reader) ([]Match, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	res := ac.emit(nil, 0, 0)
+	s := 0
+	for i := 0; ; i++ {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		s = ac.goTo(s, c)
+		res = ac.emit(res, s, i+1)
+	}
+}
@@ -0,0 +1,309 @@
+/**
+ * 字符串匹配：KMP、Z 函数、Rabin–Karp、Boyer–Moore–Horspool 和多模式的 Aho–Corasick。
+ * https://oi-wiki.org/string/
+ * https://www.cnblogs.com/zzuuoo666/p/9028287.html
+ *
+ * 所有算法按字节匹配，返回全部（可重叠的）匹配起点，与 strings.Index 的语义一致；
+ * 空模式串在 0..len(text) 的每个位置都匹配。
+ */
+package strmatch
+
+import (
+	"bufio"
+	"io"
+)
+
+// Matcher 单模式串匹配器。
+type Matcher interface {
+	Pattern() string
+	// FindAll 返回 text 中所有匹配的起点，按升序排列。
+	FindAll(text string) []int
+}
+
+// Index 返回第一个匹配的起点，没有匹配时返回 -1。
+func Index(m Matcher, text string) int {
+	if res := m.FindAll(text); len(res) > 0 {
+		return res[0]
+	}
+	return -1
+}
+
+func emptyMatches(n int) []int {
+	res := make([]int, n+1)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
+
+// chunkSize FindAllReader 每次读取的字节数。
+const chunkSize = 4096
+
+// FindAllReader 从 r 中分块读取文本并返回所有匹配的起点。
+// 相邻两块之间保留 len(pattern)-1 字节的重叠，跨块的匹配不会丢失，也不会重复。
+func FindAllReader(m Matcher, r io.Reader) ([]int, error) {
+	keep := max(len(m.Pattern())-1, 0)
+	res := []int{}
+	buf := make([]byte, 0, keep+chunkSize)
+	base := 0 // buf[0] 在整个流中的偏移
+	first := true
+	for {
+		n, err := io.ReadFull(r, buf[len(buf):len(buf)+chunkSize])
+		buf = buf[:len(buf)+n]
+		if n > 0 || first {
+			for _, off := range m.FindAll(string(buf)) {
+				// 空模式串在块开头的匹配上一轮已经报告过
+				if !first && len(m.Pattern()) == 0 && off == 0 {
+					continue
+				}
+				res = append(res, base+off)
+			}
+			first = false
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+
+		drop := max(len(buf)-keep, 0)
+		base += drop
+		buf = buf[:copy(buf, buf[drop:])]
+	}
+}
+
+// KMP 基于前缀函数的 Knuth–Morris–Pratt 匹配。
+type KMP struct {
+	pattern string
+	pi      []int
+}
+
+func NewKMP(pattern string) *KMP {
+	return &KMP{pattern: pattern, pi: PrefixFunction(pattern)}
+}
+
+// PrefixFunction 返回前缀函数：pi[i] 为 s[:i+1] 最长的相等真前缀与真后缀的长度。
+func PrefixFunction(s string) []int {
+	pi := make([]int, len(s))
+	for i := 1; i < len(s); i++ {
+		j := pi[i-1]
+		for j > 0 && s[i] != s[j] {
+			j = pi[j-1]
+		}
+		if s[i] == s[j] {
+			j++
+		}
+		pi[i] = j
+	}
+	return pi
+}
+
+func (k *KMP) Pattern() string {
+	return k.pattern
+}
+
+// PrefixFunction 返回模式串的前缀函数，调用方不应修改返回值。
+func (k *KMP) PrefixFunction() []int {
+	return k.pi
+}
+
+// step 已匹配 j 个字符时读入 c，返回新的已匹配长度。
+func (k *KMP) step(j int, c byte) int {
+	p := k.pattern
+	if j == len(p) {
+		j = k.pi[j-1]
+	}
+	for j > 0 && c != p[j] {
+		j = k.pi[j-1]
+	}
+	if c == p[j] {
+		j++
+	}
+	return j
+}
+
+func (k *KMP) FindAll(text string) []int {
+	m := len(k.pattern)
+	if m == 0 {
+		return emptyMatches(len(text))
+	}
+	res := []int{}
+	j := 0
+	for i := 0; i < len(text); i++ {
+		if j = k.step(j, text[i]); j == m {
+			res = append(res, i-m+1)
+		}
+	}
+	return res
+}
+
+// FindAllReader 逐字节读取 r，只需 O(len(pattern)) 的额外空间。
+func (k *KMP) FindAllReader(r io.Reader) ([]int, error) {
+	m := len(k.pattern)
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	res := []int{}
+	if m == 0 {
+		res = append(res, 0)
+	}
+	j := 0
+	for i := 0; ; i++ {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return res, err
+		}
+		if m == 0 {
+			res = append(res, i+1)
+			continue
+		}
+		if j = k.step(j, c); j == m {
+			res = append(res, i-m+1)
+		}
+	}
+}
+
+// ZFunction 返回 Z 函数：z[i] 为 s 与 s[i:] 的最长公共前缀长度，约定 z[0] = len(s)。
+func ZFunction(s string) []int {
+	n := len(s)
+	z := make([]int, n)
+	if n == 0 {
+		return z
+	}
+	z[0] = n
+	for i, l, r := 1, 0, 0; i < n; i++ {
+		if i < r {
+			z[i] = min(z[i-l], r-i)
+		}
+		for i+z[i] < n && s[z[i]] == s[i+z[i]] {
+			z[i]++
+		}
+		if i+z[i] > r {
+			l, r = i, i+z[i]
+		}
+	}
+	return z
+}
+
+// Z 在 pattern+text 上计算 Z 函数，z[m+i] >= m 即 text[i:] 以 pattern 开头。
+type Z struct {
+	pattern string
+}
+
+func NewZ(pattern string) *Z {
+	return &Z{pattern: pattern}
+}
+
+func (z *Z) Pattern() string {
+	return z.pattern
+}
+
+func (z *Z) FindAll(text string) []int {
+	m := len(z.pattern)
+	if m == 0 {
+		return emptyMatches(len(text))
+	}
+	zs := ZFunction(z.pattern + text)
+	res := []int{}
+	for i := 0; i+m <= len(text); i++ {
+		if zs[m+i] >= m {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// RabinKarp 滚动哈希匹配，哈希值对 2^64 取模，哈希相等时再逐字节确认。
+type RabinKarp struct {
+	pattern string
+	hash    uint64
+	pow     uint64 // base^(m-1)
+}
+
+const rkBase = 131
+
+func NewRabinKarp(pattern string) *RabinKarp {
+	rk := &RabinKarp{pattern: pattern, pow: 1}
+	for i := 0; i < len(pattern); i++ {
+		rk.hash = rk.hash*rkBase + uint64(pattern[i])
+		if i > 0 {
+			rk.pow *= rkBase
+		}
+	}
+	return rk
+}
+
+func (rk *RabinKarp) Pattern() string {
+	return rk.pattern
+}
+
+func (rk *RabinKarp) FindAll(text string) []int {
+	m := len(rk.pattern)
+	if m == 0 {
+		return emptyMatches(len(text))
+	}
+	res := []int{}
+	if len(text) < m {
+		return res
+	}
+
+	var h uint64
+	for i := 0; i < m; i++ {
+		h = h*rkBase + uint64(text[i])
+	}
+	for i := 0; ; i++ {
+		if h == rk.hash && text[i:i+m] == rk.pattern {
+			res = append(res, i)
+		}
+		if i+m >= len(text) {
+			return res
+		}
+		h = (h-uint64(text[i])*rk.pow)*rkBase + uint64(text[i+m])
+	}
+}
+
+// Horspool Boyer–Moore–Horspool 匹配：从窗口末尾往前比较，失配时按窗口最后一个字节跳转。
+type Horspool struct {
+	pattern string
+	shift   [256]int
+}
+
+func NewHorspool(pattern string) *Horspool {
+	h := &Horspool{pattern: pattern}
+	m := len(pattern)
+	for i := range h.shift {
+		h.shift[i] = m
+	}
+	for i := 0; i < m-1; i++ {
+		h.shift[pattern[i]] = m - 1 - i
+	}
+	return h
+}
+
+func (h *Horspool) Pattern() string {
+	return h.pattern
+}
+
+func (h *Horspool) FindAll(text string) []int {
+	p := h.pattern
+	m := len(p)
+	if m == 0 {
+		return emptyMatches(len(text))
+	}
+	res := []int{}
+	for pos := 0; pos+m <= len(text); pos += h.shift[text[pos+m-1]] {
+		j := m - 1
+		for j >= 0 && text[pos+j] == p[j] {
+			j--
+		}
+		if j < 0 {
+			res = append(res, pos)
+		}
+	}
+	return res
+}
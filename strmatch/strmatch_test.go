@@ -0,0 +1,70 @@
+package strmatch
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// naive 暴力找出所有（可重叠的）匹配起点。
+func naive(text, pattern string) []int {
+	res := []int{}
+	for i := 0; i+len(pattern) <= len(text); i++ {
+		if text[i:i+len(pattern)] == pattern {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func FuzzIndex(f *testing.F) {
+	f.Add("", "")
+	f.Add("abc", "")
+	f.Add("aaaaa", "aa")
+	f.Add("abababcab", "ababc")
+	f.Add("hello world", "world")
+	f.Add("mississippi", "issi")
+	f.Add(strings.Repeat("ab", 3000)+"c", "abc") // 跨过 FindAllReader 的分块边界
+	f.Fuzz(func(t *testing.T, text, pattern string) {
+		want := naive(text, pattern)
+		matchers := []Matcher{NewKMP(pattern), NewZ(pattern), NewRabinKarp(pattern), NewHorspool(pattern)}
+		for _, m := range matchers {
+			if got := Index(m, text); got != strings.Index(text, pattern) {
+				t.Fatalf("%T: Index(%q, %q) = %d, want %d", m, text, pattern, got, strings.Index(text, pattern))
+			}
+			if got := m.FindAll(text); !slices.Equal(got, want) {
+				t.Fatalf("%T: FindAll(%q, %q) = %v, want %v", m, text, pattern, got, want)
+			}
+			got, err := FindAllReader(m, iotest.HalfReader(strings.NewReader(text)))
+			if err != nil || !slices.Equal(got, want) {
+				t.Fatalf("%T: FindAllReader(%q, %q) = %v, %v, want %v", m, text, pattern, got, err, want)
+			}
+		}
+		got, err := NewKMP(pattern).FindAllReader(iotest.OneByteReader(strings.NewReader(text)))
+		if err != nil || !slices.Equal(got, want) {
+			t.Fatalf("KMP.FindAllReader(%q, %q) = %v, %v, want %v", text, pattern, got, err, want)
+		}
+
+		// 多加一个模式串，第 0 个模式串的匹配不受影响
+		ac := NewAhoCorasick(pattern, "a")
+		for _, matches := range [][]Match{ac.FindAll(text), must(ac.FindAllReader(iotest.OneByteReader(strings.NewReader(text))))} {
+			var offsets []int
+			for _, m := range matches {
+				if m.Pattern == 0 {
+					offsets = append(offsets, m.Offset)
+				}
+			}
+			if !slices.Equal(offsets, want) && len(offsets)+len(want) > 0 {
+				t.Fatalf("AhoCorasick(%q, %q) = %v, want %v", text, pattern, offsets, want)
+			}
+		}
+	})
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+
+	"leetcode-go/search"
+)
 
 /**
  * 167. 两数之和 II - 输入有序数组
@@ -12,6 +17,7 @@ func main() {
 	target := 9
 
 	fmt.Println(twoSum(numbers, target))
+	fmt.Println(twoSum2(numbers, target))
 }
 
 func twoSum(numbers []int, target int) []int {
@@ -28,3 +34,13 @@ func twoSum(numbers []int, target int) []int {
 	}
 	return []int{-1, -1}
 }
+
+// twoSum2 枚举第一个数，在它右边二分查找另一个数
+func twoSum2(numbers []int, target int) []int {
+	for i, num := range numbers {
+		if j := search.Find(numbers[i+1:], target-num, cmp.Compare[int]); j >= 0 {
+			return []int{i + 1, i + j + 2}
+		}
+	}
+	return []int{-1, -1}
+}
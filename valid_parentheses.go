@@ -14,7 +14,7 @@ func main() {
 
 func isValid(s string) bool {
 	n := len(s)
-	if n/2 == 1 {
+	if n%2 == 1 {
 		return false
 	}
 
@@ -1,80 +1,855 @@
 /**
  * 代理模式是一种结构型设计模式， 让你能够提供对象的替代品或其占位符。 代理控制着对于原对象的访问， 并允许在将请求提交给对象前后进行一些处理。
  * https://refactoringguru.cn/design-patterns/proxy
+ * notes: 面试准备/网络协议.md#网络架构
  */
 package main
 
-import "fmt"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
-type server interface {
-	handleRequest(string, string) (int, string)
+	"leetcode-go/balancer"
+	"leetcode-go/cache"
+	"leetcode-go/ratelimit"
+)
+
+// Middleware 包装一个 handler，在请求转发前后做处理，例如限流、日志。
+type Middleware func(http.Handler) http.Handler
+
+// chain 按顺序套上中间件，第一个中间件最先处理请求。
+func chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// HeaderRewrite 对请求头或响应头依次执行删除、设置、追加。
+type HeaderRewrite struct {
+	Remove []string
+	Set    map[string]string
+	Add    map[string]string
+}
+
+func (hr HeaderRewrite) apply(h http.Header) {
+	for _, k := range hr.Remove {
+		h.Del(k)
+	}
+	for k, v := range hr.Set {
+		h.Set(k, v)
+	}
+	for k, v := range hr.Add {
+		h.Add(k, v)
+	}
+}
+
+// Route 路由规则：路径以 Prefix 开头的请求转发给 Upstream，多条规则匹配时取最长的前缀。
+type Route struct {
+	Prefix          string
+	Upstream        http.Handler
+	StripPrefix     bool // 转发前去掉路径中的 Prefix
+	RequestHeaders  HeaderRewrite
+	ResponseHeaders HeaderRewrite
+	Middlewares     []Middleware // 只作用于这条路由，在全局中间件之后执行
+
+	handler http.Handler
 }
 
+// Nginx 反向代理，本身是一个 http.Handler。路由和中间件应在开始处理请求之前配置好。
 type Nginx struct {
-	application       *Application
-	maxAllowedRequest int
-	rateLimiter       map[string]int
+	routes      []*Route
+	middlewares []Middleware
+	handler     http.Handler
+	once        sync.Once
 }
 
 func newNginxServer() *Nginx {
-	return &Nginx{
-		application:       &Application{},
-		maxAllowedRequest: 2,
-		rateLimiter:       make(map[string]int),
+	return &Nginx{}
+}
+
+func (n *Nginx) addRoute(r Route) {
+	r.handler = chain(r.Upstream, r.Middlewares...)
+	n.routes = append(n.routes, &r)
+}
+
+// use 追加中间件，例如 logging 和 ratelimit.Middleware。
+func (n *Nginx) use(mws ...Middleware) {
+	n.middlewares = append(n.middlewares, mws...)
+}
+
+func (n *Nginx) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.once.Do(func() {
+		n.handler = chain(http.HandlerFunc(n.dispatch), n.middlewares...)
+	})
+	n.handler.ServeHTTP(w, r)
+}
+
+func (n *Nginx) match(path string) *Route {
+	var best *Route
+	for _, r := range n.routes {
+		if strings.HasPrefix(path, r.Prefix) && (best == nil || len(r.Prefix) > len(best.Prefix)) {
+			best = r
+		}
+	}
+	return best
+}
+
+func (n *Nginx) dispatch(w http.ResponseWriter, r *http.Request) {
+	route := n.match(r.URL.Path)
+	if route == nil {
+		http.Error(w, "no route", http.StatusNotFound)
+		return
+	}
+
+	r = r.Clone(r.Context())
+	route.RequestHeaders.apply(r.Header)
+	if route.StripPrefix {
+		r.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, route.Prefix), "/")
+		r.URL.RawPath = ""
+	}
+	rw := &rewriteWriter{ResponseWriter: w, rewrite: route.ResponseHeaders}
+	route.handler.ServeHTTP(rw, r)
+	// 什么都没写的 handler 由 net/http 补一个 200，在那之前先改写响应头
+	if !rw.wrote {
+		rw.WriteHeader(http.StatusOK)
+	}
+}
+
+// rewriteWriter 在响应头写出之前改写响应头。
+type rewriteWriter struct {
+	http.ResponseWriter
+	rewrite HeaderRewrite
+	wrote   bool
+}
+
+func (w *rewriteWriter) WriteHeader(code int) {
+	if !w.wrote {
+		w.wrote = true
+		w.rewrite.apply(w.Header())
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *rewriteWriter) Write(b []byte) (int, error) {
+	if !w.wrote {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *rewriteWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// forwardTo 转发到远程地址（例如 httptest.NewServer 的 URL），带上 X-Forwarded-* 头，连接失败时返回 502。
+func forwardTo(rawURL string) (http.Handler, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(target)
+			r.SetXForwarded()
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, "upstream unavailable: "+err.Error(), http.StatusBadGateway)
+		},
+	}, nil
+}
+
+// statusWriter 记录状态码和响应体字节数。
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// logging 记录每个请求的方法、路径、状态码、响应大小和耗时。
+func logging(logger *log.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			sw := &statusWriter{ResponseWriter: w}
+			next.ServeHTTP(sw, r)
+			logger.Printf("%s %s -> %d %dB %s", r.Method, r.URL.RequestURI(), sw.status, sw.bytes, time.Since(start).Round(time.Microsecond))
+		})
+	}
+}
+
+// responseBuffer 把上游的响应缓冲下来，决定是否缓存之后再写给客户端。
+// 响应体超过 limit（或者 Content-Length 已经超过）时不再缓冲：把响应头和已缓冲的部分写给 client，
+// 之后的数据直接转发，这样的响应不会被缓存。
+type responseBuffer struct {
+	status    int
+	header    http.Header
+	body      bytes.Buffer
+	limit     int
+	client    http.ResponseWriter // 为 nil 时没有客户端在等（后台重新验证），超限的响应直接丢弃
+	streaming bool
+}
+
+func newResponseBuffer(limit int, client http.ResponseWriter) *responseBuffer {
+	return &responseBuffer{header: http.Header{}, limit: limit, client: client}
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(code int) {
+	if b.status != 0 {
+		return
+	}
+	b.status = code
+	if n, err := strconv.ParseInt(b.header.Get("Content-Length"), 10, 64); err == nil && n > int64(b.limit) {
+		b.stream()
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	b.WriteHeader(http.StatusOK)
+	if !b.streaming && b.body.Len()+len(p) > b.limit {
+		b.stream()
+	}
+	if !b.streaming {
+		return b.body.Write(p)
+	}
+	if b.client == nil {
+		return len(p), nil
+	}
+	return b.client.Write(p)
+}
+
+// stream 放弃缓存，开始把响应直接转发给客户端。
+func (b *responseBuffer) stream() {
+	b.streaming = true
+	if b.client != nil {
+		b.header.Set("X-Cache", "MISS")
+		b.writeTo(b.client)
+	}
+	b.body.Reset()
+}
+
+func (b *responseBuffer) writeTo(w http.ResponseWriter) {
+	maps.Copy(w.Header(), b.header)
+	w.WriteHeader(b.status)
+	w.Write(b.body.Bytes())
+}
+
+// cacheControl 解析后的 Cache-Control 指令，值为空表示指令不带参数。
+type cacheControl map[string]string
+
+func parseCacheControl(h http.Header) cacheControl {
+	cc := cacheControl{}
+	for _, line := range h.Values("Cache-Control") {
+		for _, part := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			if name != "" {
+				cc[strings.ToLower(name)] = strings.Trim(value, `"`)
+			}
+		}
+	}
+	return cc
+}
+
+func (cc cacheControl) has(name string) bool {
+	_, ok := cc[name]
+	return ok
+}
+
+func (cc cacheControl) seconds(name string) (time.Duration, bool) {
+	n, err := strconv.Atoi(cc[name])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+// cachedResponse 缓存的响应。带 Vary 的响应在 URL 对应的键下只存一个 varyMarker，
+// 真正的响应按 Vary 列出的请求头的值分别存放。每个 varyMarker 有一个新的 generation，
+// 它也是各个变体的键的一部分：删掉 varyMarker 之后，旧的变体再也查不到，等着被淘汰或过期。
+type cachedResponse struct {
+	status               int
+	header               http.Header
+	body                 []byte
+	date                 time.Time     // 响应在源站生成的时间，即收到时间减去上游给出的 Age
+	fresh                time.Duration // 新鲜期
+	staleWhileRevalidate time.Duration // 过期后还能先返回旧响应、同时在后台重新验证的时间
+	mustRevalidate       bool
+	vary                 []string
+	varyMarker           bool
+	generation           uint64
+}
+
+func (e *cachedResponse) age(now time.Time) time.Duration {
+	return max(now.Sub(e.date), 0)
+}
+
+// cacheableStatus 默认可以缓存的状态码。
+var cacheableStatus = map[int]bool{200: true, 203: true, 301: true, 404: true, 410: true}
+
+// keepForRevalidation 有 ETag 或 Last-Modified 的响应过期后继续保留多久，以便用条件请求重新验证。
+const keepForRevalidation = time.Hour
+
+// cachingProxy 缓存代理：缓存 GET 响应，遵循 Cache-Control、Vary，过期后用 ETag/Last-Modified 向上游做条件请求，
+// 支持 stale-while-revalidate。响应体放在带淘汰策略的 cache.Cache 中，响应头 X-Cache 给出
+// HIT、MISS、STALE、REVALIDATED 或 BYPASS。作为共享缓存，不缓存 private、带 Set-Cookie 或 Authorization 的响应。
+type cachingProxy struct {
+	next    http.Handler
+	store   *cache.Cache[string, *cachedResponse]
+	clock   cache.Clock
+	maxBody int // 超过这个大小的响应不缓存，直接转发给客户端
+
+	generations  atomic.Uint64 // 分配给 varyMarker 的代数
+	mu           sync.Mutex
+	revalidating map[string]bool // 正在后台重新验证的键
+	background   sync.WaitGroup
+}
+
+func newCachingProxy(next http.Handler, store *cache.Cache[string, *cachedResponse], clock cache.Clock) *cachingProxy {
+	store.SetClock(clock)
+	return &cachingProxy{
+		next:         next,
+		store:        store,
+		clock:        clock,
+		maxBody:      1 << 20,
+		revalidating: map[string]bool{},
+	}
+}
+
+func (p *cachingProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	base := r.Host + r.URL.RequestURI()
+	if r.Method != http.MethodGet {
+		w.Header().Set("X-Cache", "BYPASS")
+		sw := &statusWriter{ResponseWriter: w}
+		p.next.ServeHTTP(sw, r)
+		// 修改成功后，这个 URL 的缓存就不能再用了；带 Vary 时删掉 varyMarker，所有变体一起失效
+		if r.Method != http.MethodHead && r.Method != http.MethodOptions && sw.status < 400 {
+			p.store.Remove(base)
+		}
+		return
+	}
+	reqCC := parseCacheControl(r.Header)
+	if reqCC.has("no-store") || r.Header.Get("Authorization") != "" {
+		w.Header().Set("X-Cache", "BYPASS")
+		p.next.ServeHTTP(w, r)
+		return
+	}
+
+	e := p.lookup(base, r)
+	now := p.clock.Now()
+	maxAge, limited := reqCC.seconds("max-age")
+	if e != nil && !reqCC.has("no-cache") {
+		age := e.age(now)
+		if age < e.fresh && (!limited || age <= maxAge) {
+			p.serve(w, r, e, "HIT")
+			return
+		}
+		if !e.mustRevalidate && !limited && age < e.fresh+e.staleWhileRevalidate {
+			p.revalidateInBackground(base, r, e)
+			p.serve(w, r, e, "STALE")
+			return
+		}
+	}
+
+	resp := p.fetch(r, e, w)
+	if resp.streaming {
+		return
+	}
+	if e != nil && resp.status == http.StatusNotModified {
+		p.serve(w, r, p.refresh(base, r, e, resp.header), "REVALIDATED")
+		return
+	}
+	if saved := p.save(base, r, resp); saved != nil {
+		p.serve(w, r, saved, "MISS")
+		return
+	}
+	resp.header.Set("X-Cache", "MISS")
+	resp.writeTo(w)
+}
+
+// variantKey 带 Vary 的响应的键：URL、varyMarker 的代数，加上 Vary 列出的请求头的值。
+// e 是 varyMarker 或者变体本身，不带 Vary 时就是 URL。
+func variantKey(base string, e *cachedResponse, h http.Header) string {
+	if len(e.vary) == 0 {
+		return base
+	}
+	var sb strings.Builder
+	sb.WriteString(base)
+	sb.WriteString("\x00#" + strconv.FormatUint(e.generation, 10))
+	for _, name := range e.vary {
+		sb.WriteString("\x00" + name + "=" + strings.Join(h.Values(name), ","))
+	}
+	return sb.String()
+}
+
+func (p *cachingProxy) lookup(base string, r *http.Request) *cachedResponse {
+	e, ok := p.store.Get(base)
+	if ok && e.varyMarker {
+		e, ok = p.store.Get(variantKey(base, e, r.Header))
+	}
+	if !ok {
+		return nil
+	}
+	return e
+}
+
+// fetch 向上游转发。有缓存的响应时带上它的 ETag 和 Last-Modified 做条件请求；
+// 客户端自己的条件请求头不转发，由缓存来回答。太大的响应直接转发给 w，返回时 streaming 为 true。
+func (p *cachingProxy) fetch(r *http.Request, e *cachedResponse, w http.ResponseWriter) *responseBuffer {
+	req := r.Clone(r.Context())
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+	if e != nil {
+		if etag := e.header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lm := e.header.Get("Last-Modified"); lm != "" {
+			req.Header.Set("If-Modified-Since", lm)
+		}
+	}
+	resp := newResponseBuffer(p.maxBody, w)
+	p.next.ServeHTTP(resp, req)
+	if resp.status == 0 {
+		resp.status = http.StatusOK
+	}
+	return resp
+}
+
+// save 按响应头判断能否缓存，能则写入缓存并返回缓存的条目。
+func (p *cachingProxy) save(base string, r *http.Request, resp *responseBuffer) *cachedResponse {
+	h := resp.header
+	cc := parseCacheControl(h)
+	if resp.streaming || !cacheableStatus[resp.status] || cc.has("no-store") || cc.has("private") ||
+		h.Get("Set-Cookie") != "" {
+		return nil
+	}
+	var vary []string
+	for _, line := range h.Values("Vary") {
+		for _, name := range strings.Split(line, ",") {
+			if name = strings.TrimSpace(name); name == "*" {
+				return nil
+			} else if name != "" {
+				vary = append(vary, http.CanonicalHeaderKey(name))
+			}
+		}
 	}
+	slices.Sort(vary)
+
+	now := p.clock.Now()
+	e := &cachedResponse{
+		status:         resp.status,
+		header:         h.Clone(),
+		body:           bytes.Clone(resp.body.Bytes()),
+		date:           now,
+		fresh:          freshness(h, cc, now),
+		mustRevalidate: cc.has("must-revalidate") || cc.has("proxy-revalidate"),
+		vary:           vary,
+	}
+	if age, err := strconv.Atoi(h.Get("Age")); err == nil && age > 0 {
+		e.date = now.Add(-time.Duration(age) * time.Second)
+	}
+	e.header.Del("Age")
+	e.header.Del("X-Cache")
+	if cc.has("no-cache") {
+		e.fresh = 0
+	}
+	e.staleWhileRevalidate, _ = cc.seconds("stale-while-revalidate")
+
+	ttl := e.fresh + e.staleWhileRevalidate - e.age(now)
+	if h.Get("ETag") != "" || h.Get("Last-Modified") != "" {
+		ttl = max(ttl, 0) + keepForRevalidation
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	if len(vary) > 0 {
+		// Vary 不变时沿用原来的代数，其他变体继续有效
+		marker, ok := p.store.Get(base)
+		if !ok || !marker.varyMarker || !slices.Equal(marker.vary, vary) {
+			marker = &cachedResponse{vary: vary, varyMarker: true, generation: p.generations.Add(1)}
+		}
+		p.store.Add(base, marker)
+		e.generation = marker.generation
+	}
+	p.store.AddWithTTL(variantKey(base, e, r.Header), e, ttl)
+	return e
 }
 
-func (n *Nginx) handleRequest(url, method string) (int, string) {
-	allowed := n.checkRateLimiting(url)
-	if !allowed {
-		return 403, "Not Allowed"
+// freshness 新鲜期：s-maxage 优先于 max-age，都没有时用 Expires 减去 Date。
+func freshness(h http.Header, cc cacheControl, now time.Time) time.Duration {
+	if d, ok := cc.seconds("s-maxage"); ok {
+		return d
+	}
+	if d, ok := cc.seconds("max-age"); ok {
+		return d
+	}
+	expires, err := http.ParseTime(h.Get("Expires"))
+	if err != nil {
+		return 0
+	}
+	date, err := http.ParseTime(h.Get("Date"))
+	if err != nil {
+		date = now
 	}
-	return n.application.handleRequest(url, method)
+	return max(expires.Sub(date), 0)
 }
 
-func (n *Nginx) checkRateLimiting(url string) bool {
-	if n.rateLimiter[url] == 0 {
-		n.rateLimiter[url] = 1
+// refresh 上游返回 304 时，用新的响应头更新缓存的条目。
+func (p *cachingProxy) refresh(base string, r *http.Request, e *cachedResponse, header http.Header) *cachedResponse {
+	resp := newResponseBuffer(p.maxBody, nil)
+	resp.status = e.status
+	resp.header = e.header.Clone()
+	for _, k := range []string{"Cache-Control", "Expires", "Date", "ETag", "Last-Modified", "Vary", "Age"} {
+		if v := header.Values(k); len(v) > 0 {
+			resp.header[k] = v
+		}
 	}
-	if n.rateLimiter[url] > n.maxAllowedRequest {
+	resp.body.Write(e.body)
+	if saved := p.save(base, r, resp); saved != nil {
+		return saved
+	}
+	// 新的响应头不允许再缓存
+	p.store.Remove(variantKey(base, e, r.Header))
+	return e
+}
+
+// revalidateInBackground 同一个键同时只有一个后台重新验证。
+func (p *cachingProxy) revalidateInBackground(base string, r *http.Request, e *cachedResponse) {
+	key := variantKey(base, e, r.Header)
+	p.mu.Lock()
+	if p.revalidating[key] {
+		p.mu.Unlock()
+		return
+	}
+	p.revalidating[key] = true
+	p.mu.Unlock()
+
+	// 客户端的请求结束后后台请求还要继续
+	req := r.Clone(context.WithoutCancel(r.Context()))
+	p.background.Add(1)
+	go func() {
+		defer p.background.Done()
+		defer func() {
+			p.mu.Lock()
+			delete(p.revalidating, key)
+			p.mu.Unlock()
+		}()
+		resp := p.fetch(req, e, nil)
+		if resp.status == http.StatusNotModified {
+			p.refresh(base, req, e, resp.header)
+		} else {
+			p.save(base, req, resp)
+		}
+	}()
+}
+
+// etagMatch 按 If-None-Match 的弱比较规则判断 etag 是否在列表中。
+func etagMatch(ifNoneMatch, etag string) bool {
+	if etag == "" {
 		return false
 	}
-	n.rateLimiter[url] = n.rateLimiter[url] + 1
-	return true
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// serve 用缓存的条目回答客户端，客户端的 If-None-Match 命中时返回 304。
+func (p *cachingProxy) serve(w http.ResponseWriter, r *http.Request, e *cachedResponse, result string) {
+	h := w.Header()
+	for k, v := range e.header {
+		h[k] = slices.Clone(v)
+	}
+	h.Set("Age", strconv.Itoa(int(e.age(p.clock.Now())/time.Second)))
+	h.Set("X-Cache", result)
+	if e.status == http.StatusOK && etagMatch(r.Header.Get("If-None-Match"), e.header.Get("ETag")) {
+		h.Del("Content-Length")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(e.status)
+	w.Write(e.body)
 }
 
 type Application struct{}
 
-func (a *Application) handleRequest(url, method string) (int, string) {
-	if url == "/app/status" && method == "GET" {
-		return 200, "Ok"
+func (a *Application) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/app/status" && r.Method == "GET" {
+		fmt.Fprint(w, "Ok")
+		return
 	}
 
-	if url == "/create/user" && method == "POST" {
-		return 201, "User Created"
+	if r.URL.Path == "/create/user" && r.Method == "POST" {
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, "User Created")
+		return
 	}
 
-	return 404, "Not Ok"
+	http.Error(w, "Not Ok", http.StatusNotFound)
+}
+
+// backend 可以被设置成变慢或失败的上游，用来演示负载均衡、健康检查和异常摘除。
+type backend struct {
+	*httptest.Server
+	name    string
+	delay   atomic.Int64 // time.Duration
+	failing atomic.Bool  // 请求和健康检查都返回 500
+}
+
+func newBackend(name string) *backend {
+	b := &backend{name: name}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if b.failing.Load() {
+			http.Error(w, name+" is failing", http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path == "/healthz" {
+			return
+		}
+		time.Sleep(time.Duration(b.delay.Load()))
+		fmt.Fprint(w, name)
+	}))
+	return b
+}
+
+// send 通过 h 发一个 GET 请求，返回状态码和响应体。
+func send(h http.Handler, target string) (int, string) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func printStatus(pool *balancer.Pool) {
+	for _, s := range pool.Status() {
+		fmt.Println("  " + s.String())
+	}
+}
+
+func request(h http.Handler, method, target string) {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", target, rec.Code, strings.TrimSpace(rec.Body.String()))
+	if retry := rec.Header().Get("Retry-After"); retry != "" {
+		fmt.Printf("Retry-After: %s\n", retry)
+	}
 }
 
 func main() {
+	// 远程上游：回显收到的路径和请求头
+	users := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "users/1.0")
+		fmt.Fprintf(w, "users got %s, X-Request-From=%s, X-Forwarded-Host=%s",
+			r.URL.Path, r.Header.Get("X-Request-From"), r.Header.Get("X-Forwarded-Host"))
+	}))
+	defer users.Close()
+	usersProxy, err := forwardTo(users.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	// 每个 URL 每分钟最多 2 个请求，用假时钟演示窗口重置；缓存代理也用同一个时钟
+	clock := cache.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	limiter := ratelimit.New(ratelimit.FixedWindow(2, time.Minute), ratelimit.Options{Clock: clock})
 
 	nginxServer := newNginxServer()
+	nginxServer.use(logging(log.New(os.Stdout, "nginx: ", 0)))
+	nginxServer.addRoute(Route{
+		Prefix:      "/",
+		Upstream:    &Application{},
+		Middlewares: []Middleware{ratelimit.Middleware(limiter, ratelimit.ByURL)},
+	})
+
+	// 一组订单服务，平滑加权轮询，单次转发 200ms 超时，连接失败或超时换一台重试一次
+	var backends []*backend
+	var targets []balancer.Target
+	for i, weight := range []int{3, 1, 1} {
+		b := newBackend(fmt.Sprintf("orders-%d", i+1))
+		defer b.Close()
+		backends = append(backends, b)
+		targets = append(targets, balancer.Target{Name: b.name, URL: b.URL, Weight: weight})
+	}
+	pool, err := balancer.New(targets, balancer.WeightedRoundRobin(), balancer.Options{
+		Timeout: 200 * time.Millisecond,
+		Retries: 1,
+		Outlier: balancer.OutlierOptions{ConsecutiveFailures: 2},
+		Health:  balancer.HealthOptions{Unhealthy: 2, Healthy: 1},
+	})
+	if err != nil {
+		panic(err)
+	}
+	nginxServer.addRoute(Route{Prefix: "/api/orders/", Upstream: pool})
+
+	// 商品目录服务前面加一层缓存代理，缓存用 LRU 淘汰，时间由假时钟控制
+	var catalogHits, newsVersion atomic.Int64
+	catalog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		catalogHits.Add(1)
+		switch r.URL.Path {
+		case "/logo":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("ETag", `"logo-v1"`)
+			if r.Header.Get("If-None-Match") == `"logo-v1"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			fmt.Fprint(w, "<svg>logo</svg>")
+		case "/news":
+			w.Header().Set("Cache-Control", "max-age=10, stale-while-revalidate=30")
+			fmt.Fprintf(w, "news v%d", newsVersion.Add(1))
+		case "/greeting":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Vary", "Accept-Language")
+			if strings.HasPrefix(r.Header.Get("Accept-Language"), "zh") {
+				fmt.Fprint(w, "你好")
+			} else {
+				fmt.Fprint(w, "hello")
+			}
+		default:
+			w.Header().Set("Cache-Control", "private, no-store")
+			fmt.Fprint(w, "account data")
+		}
+	}))
+	defer catalog.Close()
+	catalogProxy, err := forwardTo(catalog.URL)
+	if err != nil {
+		panic(err)
+	}
+	catalogStore := cache.New[string, *cachedResponse](cache.NewLru[string](), 100)
+	catalogCache := newCachingProxy(catalogProxy, catalogStore, clock)
+	nginxServer.addRoute(Route{Prefix: "/api/catalog/", Upstream: catalogCache, StripPrefix: true})
+	nginxServer.addRoute(Route{
+		Prefix:          "/api/users/",
+		Upstream:        usersProxy,
+		StripPrefix:     true,
+		RequestHeaders:  HeaderRewrite{Set: map[string]string{"X-Request-From": "nginx"}},
+		ResponseHeaders: HeaderRewrite{Remove: []string{"Server"}, Set: map[string]string{"X-Proxy": "nginx"}},
+	})
+
 	appStatusURL := "/app/status"
 	createUserURL := "/create/user"
 
-	httpCode, body := nginxServer.handleRequest(appStatusURL, "GET")
-	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", appStatusURL, httpCode, body)
+	request(nginxServer, "GET", appStatusURL)
+	request(nginxServer, "GET", appStatusURL)
+	request(nginxServer, "GET", appStatusURL)
+	clock.Advance(time.Minute)
+	request(nginxServer, "GET", appStatusURL)
+	request(nginxServer, "POST", createUserURL)
+	request(nginxServer, "GET", createUserURL)
 
-	httpCode, body = nginxServer.handleRequest(appStatusURL, "GET")
-	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", appStatusURL, httpCode, body)
+	fmt.Println("\n权重 3:1:1 的加权轮询:")
+	for i := range 5 {
+		_, body := send(nginxServer, fmt.Sprintf("/api/orders/%d", i))
+		fmt.Println(body)
+	}
+
+	fmt.Println("\norders-2 开始返回 500，连续失败 2 次后被摘除:")
+	backends[1].failing.Store(true)
+	for i := range 10 {
+		code, body := send(nginxServer, fmt.Sprintf("/api/orders/%d", 100+i))
+		fmt.Println(code, body)
+	}
+	printStatus(pool)
+
+	fmt.Println("\n健康检查连续失败 2 次，orders-2 被标记为不健康:")
+	pool.CheckHealth(context.Background())
+	pool.CheckHealth(context.Background())
+	printStatus(pool)
+
+	fmt.Println("\norders-1 变慢，超时后换到其他上游重试:")
+	backends[0].delay.Store(int64(time.Second))
+	for i := range 3 {
+		code, body := send(nginxServer, fmt.Sprintf("/api/orders/%d", 200+i))
+		fmt.Println(code, body)
+	}
+	backends[0].delay.Store(0)
+
+	fmt.Println("\n缓存代理:")
+	cached := func(method, target string, header ...string) {
+		req := httptest.NewRequest(method, target, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		nginxServer.ServeHTTP(rec, req)
+		fmt.Printf("  %s %s %v -> %d %s Age=%s %q, upstream hits: %d\n", method, target, header,
+			rec.Code, rec.Header().Get("X-Cache"), rec.Header().Get("Age"), rec.Body.String(), catalogHits.Load())
+	}
+	cached("GET", "/api/catalog/logo")
+	cached("GET", "/api/catalog/logo")
+	clock.Advance(30 * time.Second)
+	cached("GET", "/api/catalog/logo")
+	clock.Advance(31 * time.Second) // 过期，用 ETag 重新验证
+	cached("GET", "/api/catalog/logo")
+	cached("GET", "/api/catalog/logo", "If-None-Match", `"logo-v1"`)
 
-	httpCode, body = nginxServer.handleRequest(appStatusURL, "GET")
-	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", appStatusURL, httpCode, body)
+	cached("GET", "/api/catalog/news")
+	clock.Advance(15 * time.Second) // 过期但在 stale-while-revalidate 内，先返回旧的
+	cached("GET", "/api/catalog/news")
+	catalogCache.background.Wait()
+	cached("GET", "/api/catalog/news")
 
-	httpCode, body = nginxServer.handleRequest(createUserURL, "POST")
-	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", appStatusURL, httpCode, body)
+	cached("GET", "/api/catalog/greeting", "Accept-Language", "en")
+	cached("GET", "/api/catalog/greeting", "Accept-Language", "zh-CN")
+	cached("GET", "/api/catalog/greeting", "Accept-Language", "en")
+	cached("GET", "/api/catalog/greeting", "Accept-Language", "zh-CN")
 
-	httpCode, body = nginxServer.handleRequest(createUserURL, "GET")
-	fmt.Printf("\nUrl: %s\nHttpCode: %d\nBody: %s\n", appStatusURL, httpCode, body)
+	cached("GET", "/api/catalog/account")
+	cached("GET", "/api/catalog/account")
+	cached("POST", "/api/catalog/logo") // 修改后缓存失效
+	cached("GET", "/api/catalog/logo")
+	fmt.Println(" ", catalogStore.Stats())
+
+	// 代理本身也可以作为真正的 HTTP 服务
+	front := httptest.NewServer(nginxServer)
+	defer front.Close()
+	resp, err := http.Get(front.URL + "/api/users/42")
+	if err != nil {
+		panic(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	fmt.Printf("\nUrl: %s\nHttpCode: %d\nServer: %q X-Proxy: %q\nBody: %s\n",
+		"/api/users/42", resp.StatusCode, resp.Header.Get("Server"), resp.Header.Get("X-Proxy"), body)
 }
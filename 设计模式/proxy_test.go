@@ -0,0 +1,383 @@
+// 设计模式 下每个文件都是独立的 main 包，需要连同 proxy.go 一起指定：
+//
+//	cd 设计模式 && go test proxy.go proxy_test.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"leetcode-go/cache"
+)
+
+// echo 回显收到的路径和 X-Test 请求头。
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Server", "echo")
+	fmt.Fprintf(w, "%s %s", r.URL.Path, r.Header.Get("X-Test"))
+})
+
+func serve(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	return rec
+}
+
+func TestRouting(t *testing.T) {
+	n := newNginxServer()
+	n.addRoute(Route{Prefix: "/api/", Upstream: echo})
+	n.addRoute(Route{Prefix: "/api/users/", Upstream: echo, StripPrefix: true})
+	for _, tc := range []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{"/api/orders", 200, "/api/orders "},
+		{"/api/users/42", 200, "/42 "},
+		{"/api/users/", 200, "/ "},
+		{"/static/logo", 404, "no route\n"},
+	} {
+		rec := serve(n, "GET", tc.target)
+		if rec.Code != tc.code || rec.Body.String() != tc.body {
+			t.Errorf("GET %s = %d %q, want %d %q", tc.target, rec.Code, rec.Body.String(), tc.code, tc.body)
+		}
+	}
+}
+
+func TestHeaderRewrite(t *testing.T) {
+	n := newNginxServer()
+	rewrite := func(upstream http.Handler, prefix string) {
+		n.addRoute(Route{
+			Prefix:          prefix,
+			Upstream:        upstream,
+			RequestHeaders:  HeaderRewrite{Set: map[string]string{"X-Test": "rewritten"}},
+			ResponseHeaders: HeaderRewrite{Remove: []string{"Server"}, Set: map[string]string{"X-Proxy": "nginx"}},
+		})
+	}
+	rewrite(echo, "/echo")
+	rewrite(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "empty")
+	}), "/empty")
+	rewrite(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Server", "created")
+		w.WriteHeader(http.StatusCreated)
+	}), "/created")
+
+	for _, tc := range []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{"/echo", 200, "/echo rewritten"},
+		{"/empty", 200, ""}, // 没有调用 Write 和 WriteHeader 也要改写
+		{"/created", 201, ""},
+	} {
+		rec := serve(n, "GET", tc.target)
+		if rec.Code != tc.code || rec.Body.String() != tc.body {
+			t.Errorf("GET %s = %d %q, want %d %q", tc.target, rec.Code, rec.Body.String(), tc.code, tc.body)
+		}
+		if s, p := rec.Header().Get("Server"), rec.Header().Get("X-Proxy"); s != "" || p != "nginx" {
+			t.Errorf("GET %s: Server=%q X-Proxy=%q, want the response headers rewritten", tc.target, s, p)
+		}
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	n := newNginxServer()
+	n.use(mw("global-1"), mw("global-2"))
+	n.addRoute(Route{Prefix: "/", Upstream: echo, Middlewares: []Middleware{mw("route-1"), mw("route-2")}})
+	serve(n, "GET", "/")
+	if got, want := strings.Join(order, ","), "global-1,global-2,route-1,route-2"; got != want {
+		t.Fatalf("middlewares ran in order %s, want %s", got, want)
+	}
+}
+
+func TestForwardTo(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s host=%s", r.URL.Path, r.Header.Get("X-Forwarded-Host"))
+	}))
+	defer upstream.Close()
+	h, err := forwardTo(upstream.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := newNginxServer()
+	n.addRoute(Route{Prefix: "/users/", Upstream: h, StripPrefix: true})
+	front := httptest.NewServer(n)
+	defer front.Close()
+
+	resp, err := http.Get(front.URL + "/users/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := readAll(t, resp)
+	if want := "/42 host=" + strings.TrimPrefix(front.URL, "http://"); resp.StatusCode != 200 || body != want {
+		t.Fatalf("GET /users/42 = %d %q, want 200 %q", resp.StatusCode, body, want)
+	}
+
+	upstream.Close()
+	if rec := serve(n, "GET", "/users/42"); rec.Code != http.StatusBadGateway {
+		t.Fatalf("GET with the upstream down = %d, want 502", rec.Code)
+	}
+}
+
+func readAll(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	var sb strings.Builder
+	if _, err := io.Copy(&sb, resp.Body); err != nil {
+		t.Fatal(err)
+	}
+	return sb.String()
+}
+
+func newTestCachingProxy(upstream http.Handler) (*cachingProxy, *cache.FakeClock) {
+	clock := cache.NewFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	return newCachingProxy(upstream, cache.New[string, *cachedResponse](cache.NewLru[string](), 100), clock), clock
+}
+
+func cachedGet(h http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCachingProxy(t *testing.T) {
+	var hits atomic.Int64
+	p, clock := newTestCachingProxy(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "body")
+	}))
+	for _, want := range []struct {
+		advance time.Duration
+		result  string
+		hits    int64
+	}{
+		{0, "MISS", 1},
+		{30 * time.Second, "HIT", 1},
+		{31 * time.Second, "REVALIDATED", 2},
+		{0, "HIT", 2},
+	} {
+		clock.Advance(want.advance)
+		rec := cachedGet(p, "/logo")
+		if got := rec.Header().Get("X-Cache"); got != want.result || rec.Body.String() != "body" || hits.Load() != want.hits {
+			t.Fatalf("X-Cache %s %q after %d upstream hits, want %s after %d", got, rec.Body.String(), hits.Load(), want.result, want.hits)
+		}
+	}
+	if rec := cachedGet(p, "/logo", "If-None-Match", `"v1"`); rec.Code != http.StatusNotModified {
+		t.Fatalf("conditional request = %d, want 304", rec.Code)
+	}
+}
+
+// TestStaleWhileRevalidate 过期但在 stale-while-revalidate 内时先返回旧的响应，
+// 同一个键同时只有一个后台请求，后台请求完成后缓存换成新的响应。
+func TestStaleWhileRevalidate(t *testing.T) {
+	var hits, version atomic.Int64
+	version.Store(1)
+	started, release := make(chan struct{}, 10), make(chan struct{})
+	var blocking atomic.Bool
+	p, clock := newTestCachingProxy(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if blocking.Load() {
+			started <- struct{}{}
+			<-release
+		}
+		w.Header().Set("Cache-Control", "max-age=10, stale-while-revalidate=30")
+		fmt.Fprintf(w, "v%d", version.Load())
+	}))
+	check := func(want, body string, wantHits int64) {
+		t.Helper()
+		rec := cachedGet(p, "/news")
+		if got := rec.Header().Get("X-Cache"); got != want || rec.Body.String() != body || hits.Load() != wantHits {
+			t.Fatalf("X-Cache %s %q after %d upstream hits, want %s %q after %d", got, rec.Body.String(), hits.Load(), want, body, wantHits)
+		}
+	}
+	check("MISS", "v1", 1)
+
+	clock.Advance(15 * time.Second)
+	version.Store(2)
+	blocking.Store(true)
+	check("STALE", "v1", 1)
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no background revalidation started")
+	}
+	// 后台请求还没完成，再次请求仍返回旧的响应，也不会再发起一个后台请求
+	check("STALE", "v1", 2)
+	check("STALE", "v1", 2)
+	close(release)
+	p.background.Wait()
+	if hits.Load() != 2 {
+		t.Fatalf("upstream hits = %d, want a single background revalidation", hits.Load())
+	}
+	blocking.Store(false)
+	check("HIT", "v2", 2)
+
+	// 超过 stale-while-revalidate 后必须同步向上游请求
+	clock.Advance(41 * time.Second)
+	version.Store(3)
+	check("MISS", "v3", 3)
+}
+
+// TestCachingProxyBypass 作为共享缓存，带 Authorization 或 no-store 的请求直接转发，
+// private、no-store、带 Set-Cookie 的响应不缓存。
+func TestCachingProxyBypass(t *testing.T) {
+	var hits atomic.Int64
+	p, _ := newTestCachingProxy(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		switch r.URL.Path {
+		case "/private":
+			w.Header().Set("Cache-Control", "private, max-age=60")
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/cookie":
+			w.Header().Set("Cache-Control", "max-age=60")
+			w.Header().Set("Set-Cookie", "session=1")
+		default:
+			w.Header().Set("Cache-Control", "max-age=60")
+		}
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	for _, tc := range []struct {
+		path   string
+		header []string
+		want   string
+	}{
+		{"/public", []string{"Authorization", "Bearer t"}, "BYPASS"},
+		{"/public", []string{"Cache-Control", "no-store"}, "BYPASS"},
+		{"/private", nil, "MISS"},
+		{"/no-store", nil, "MISS"},
+		{"/cookie", nil, "MISS"},
+	} {
+		for i := range 2 {
+			before := hits.Load()
+			rec := cachedGet(p, tc.path, tc.header...)
+			if got := rec.Header().Get("X-Cache"); got != tc.want || rec.Body.String() != tc.path || hits.Load() != before+1 {
+				t.Fatalf("%s %v request %d: X-Cache %s %q, %d upstream hits, want %s from the upstream",
+					tc.path, tc.header, i, got, rec.Body.String(), hits.Load()-before, tc.want)
+			}
+		}
+	}
+	// 绕过缓存的请求也没有把响应存下来
+	for _, want := range []string{"MISS", "HIT"} {
+		if got := cachedGet(p, "/public").Header().Get("X-Cache"); got != want {
+			t.Fatalf("/public X-Cache %s, want %s", got, want)
+		}
+	}
+}
+
+// TestVaryInvalidation 修改成功后，同一个 URL 的所有 Vary 变体都失效。
+func TestVaryInvalidation(t *testing.T) {
+	var version atomic.Int64
+	p, _ := newTestCachingProxy(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			version.Add(1)
+			return
+		}
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		fmt.Fprintf(w, "%s v%d", r.Header.Get("Accept-Language"), version.Load())
+	}))
+	for _, lang := range []string{"en", "zh", "en", "zh"} {
+		if rec := cachedGet(p, "/greeting", "Accept-Language", lang); rec.Body.String() != lang+" v0" {
+			t.Fatalf("%s = %q", lang, rec.Body.String())
+		}
+	}
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("POST", "/greeting", nil))
+
+	// en 先重新缓存，zh 的旧变体不能因为 varyMarker 重新出现而被返回
+	for _, lang := range []string{"en", "zh", "zh"} {
+		if rec := cachedGet(p, "/greeting", "Accept-Language", lang); rec.Body.String() != lang+" v1" {
+			t.Fatalf("%s after POST = %q %s, want v1", lang, rec.Body.String(), rec.Header().Get("X-Cache"))
+		}
+	}
+}
+
+// TestLargeResponseStreams 超过 maxBody 的响应不等上游写完就开始转发，也不缓存。
+func TestLargeResponseStreams(t *testing.T) {
+	release := make(chan struct{})
+	var hits atomic.Int64
+	p, _ := newTestCachingProxy(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.URL.Path == "/sized" {
+			w.Header().Set("Content-Length", "100")
+		}
+		// /large 先写超过 maxBody 的部分再等待，/sized 在 Content-Length 中声明了总大小
+		w.Write(bytes.Repeat([]byte("a"), 90))
+		if r.URL.Path == "/large" {
+			<-release
+		}
+		w.Write(bytes.Repeat([]byte("b"), 10))
+	}))
+	p.maxBody = 80
+
+	// 上游还没写完时客户端已经收到了响应头和前面的数据
+	w := &notifyWriter{ResponseRecorder: httptest.NewRecorder(), wrote: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.ServeHTTP(w, httptest.NewRequest("GET", "/large", nil))
+	}()
+	select {
+	case <-w.wrote:
+	case <-time.After(5 * time.Second):
+		t.Fatal("large response was buffered until the upstream finished")
+	}
+	close(release)
+	<-done
+	if w.Header().Get("X-Cache") != "MISS" || w.Body.Len() != 100 {
+		t.Fatalf("X-Cache %s, %d bytes, want MISS and 100 bytes", w.Header().Get("X-Cache"), w.Body.Len())
+	}
+
+	// Content-Length 已经超过 maxBody 时从第一个字节起就直接转发
+	for i := range 2 {
+		rec := cachedGet(p, "/sized")
+		if rec.Header().Get("X-Cache") != "MISS" || rec.Body.Len() != 100 {
+			t.Fatalf("request %d: X-Cache %s, %d bytes", i, rec.Header().Get("X-Cache"), rec.Body.Len())
+		}
+	}
+	if hits.Load() != 3 {
+		t.Fatalf("upstream hits = %d, want 3: large responses must not be cached", hits.Load())
+	}
+}
+
+// notifyWriter 第一次写入响应体时关闭 wrote。
+type notifyWriter struct {
+	*httptest.ResponseRecorder
+	wrote chan struct{}
+	once  sync.Once
+}
+
+func (w *notifyWriter) Write(b []byte) (int, error) {
+	w.once.Do(func() { close(w.wrote) })
+	return w.ResponseRecorder.Write(b)
+}
@@ -1,29 +1,499 @@
 /**
  * 单例模式是一种创建型设计模式， 让你能够保证一个类只有一个实例， 并提供一个访问该实例的全局节点。
  * https://refactoringguru.cn/design-patterns/singleton
+ * notes: 笔记/go.md#如何保证多个 goroutine 对共享变量的并发安全？
  */
 package main
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
-var once sync.Once
+// Lazy 延迟初始化的单例。与 sync.Once 不同，初始化失败时不会记住失败，下次 Get 会重试；
+// Reset 之后重新初始化，方便测试。初始化完成后 Get 只有一次原子读。
+type Lazy[T any] struct {
+	mu    sync.Mutex
+	value atomic.Pointer[T]
+	init  func() (T, error)
+}
+
+func NewLazy[T any](init func() (T, error)) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+func (l *Lazy[T]) Get() (T, error) {
+	if p := l.value.Load(); p != nil {
+		return *p, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if p := l.value.Load(); p != nil {
+		return *p, nil
+	}
+	v, err := l.init()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	l.value.Store(&v)
+	return v, nil
+}
+
+func (l *Lazy[T]) MustGet() T {
+	v, err := l.Get()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Set 直接指定实例，用于测试中替换成假的实现。
+func (l *Lazy[T]) Set(v T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.value.Store(&v)
+}
+
+// Reset 丢弃已创建的实例，下次 Get 重新初始化。
+func (l *Lazy[T]) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.value.Store(nil)
+}
 
 type single struct{}
 
-var singleInstance *single
+var singleInstance = NewLazy(func() (*single, error) {
+	return &single{}, nil
+})
 
 func getInstance() *single {
-	if singleInstance == nil {
-		once.Do(func() {
-			fmt.Println("Creating single instance now.")
-			singleInstance = &single{}
-		})
+	return singleInstance.MustGet()
+}
+
+// Lifetime 容器中服务的生命周期。
+type Lifetime int
+
+const (
+	Singleton Lifetime = iota // 整个容器只创建一次
+	Scoped                    // 每个作用域（例如一次请求）创建一次
+	Transient                 // 每次解析都创建新的
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Scoped:
+		return "scoped"
+	case Transient:
+		return "transient"
+	}
+	return fmt.Sprintf("Lifetime(%d)", int(l))
+}
+
+var (
+	ErrNotRegistered = errors.New("di: service not registered")
+	ErrNoScope       = errors.New("di: scoped service resolved outside a scope")
+	ErrClosed        = errors.New("di: container closed")
+)
+
+// CycleError 依赖成环，Path 的首尾是同一个类型。
+type CycleError struct {
+	Path []reflect.Type
+}
+
+func (e *CycleError) Error() string {
+	names := make([]string, len(e.Path))
+	for i, t := range e.Path {
+		names[i] = t.String()
+	}
+	return "di: dependency cycle: " + strings.Join(names, " -> ")
+}
+
+type provider struct {
+	lifetime Lifetime
+	build    func(r *Resolver) (any, error)
+}
+
+// Container 按类型注册服务的依赖注入容器。Scope 创建的子容器共享注册信息和单例，
+// 作用域服务各自一份。容器和作用域各自记录自己创建的实例，Close 时按创建的逆序关闭。
+type Container struct {
+	root   *Container
+	mu     sync.Mutex
+	closed bool
+	// 以下三个字段只在根容器上使用
+	providers map[reflect.Type]*provider
+	overrides map[reflect.Type]any
+	waitMu    sync.Mutex // 保护所有 building.waitingOn
+
+	instances map[reflect.Type]any
+	building  map[reflect.Type]*building   // 正在创建的实例，同一个类型同时只创建一次
+	created   []interface{ Close() error } // 需要关闭的实例，按创建顺序
+}
+
+// building 一次正在进行的创建，其他解析同一个类型的调用等待 done 后共享结果。
+// 不同类型的创建互不阻塞，构造函数里也可以再解析别的服务。
+type building struct {
+	t    reflect.Type
+	done chan struct{}
+	v    any
+	err  error
+	// waitingOn 这次创建的构造函数正在等待的另一次创建，用来发现跨 goroutine 的依赖环
+	waitingOn *building
+}
+
+func NewContainer() *Container {
+	c := &Container{
+		providers: map[reflect.Type]*provider{},
+		overrides: map[reflect.Type]any{},
+		instances: map[reflect.Type]any{},
+		building:  map[reflect.Type]*building{},
+	}
+	c.root = c
+	return c
+}
+
+// Scope 创建一个作用域，用完后调用 Close 关闭其中创建的实例。
+func (c *Container) Scope() *Container {
+	return &Container{root: c.root, instances: map[reflect.Type]any{}, building: map[reflect.Type]*building{}}
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// Register 注册类型 T 的构造函数，重复注册时后者覆盖前者。构造函数通过 r 解析自己的依赖。
+func Register[T any](c *Container, lifetime Lifetime, build func(r *Resolver) (T, error)) {
+	root := c.root
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	root.providers[typeOf[T]()] = &provider{lifetime, func(r *Resolver) (any, error) {
+		return build(r)
+	}}
+}
+
+// Override 在测试中用 v 代替 T 的注册，对根容器和所有作用域生效，返回的函数用于恢复。
+// 替身不会被 Close。
+func Override[T any](c *Container, v T) (restore func()) {
+	root, t := c.root, typeOf[T]()
+	root.mu.Lock()
+	defer root.mu.Unlock()
+	old, had := root.overrides[t]
+	root.overrides[t] = v
+	return func() {
+		root.mu.Lock()
+		defer root.mu.Unlock()
+		if had {
+			root.overrides[t] = old
+		} else {
+			delete(root.overrides, t)
+		}
+	}
+}
+
+// Resolver 一次解析过程，记录正在创建的类型以检测依赖环。
+// 构造函数应当通过传给它的 r 解析依赖：直接用容器解析时不知道调用方正在创建什么，
+// 依赖环（例如解析自己）会一直等待下去，而不是返回 CycleError。
+type Resolver struct {
+	scope   *Container
+	path    []reflect.Type
+	current *building // 正在为哪次创建解析依赖，最外层为 nil
+}
+
+// resolver 由 *Container 和 *Resolver 实现，Resolve 对两者都可用。
+type resolver interface {
+	resolve(t reflect.Type) (any, error)
+}
+
+func Resolve[T any](r resolver) (T, error) {
+	v, err := r.resolve(typeOf[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+func MustResolve[T any](r resolver) T {
+	v, err := Resolve[T](r)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (c *Container) resolve(t reflect.Type) (any, error) {
+	return (&Resolver{scope: c}).resolve(t)
+}
+
+func (r *Resolver) resolve(t reflect.Type) (any, error) {
+	root := r.scope.root
+	root.mu.Lock()
+	v, overridden := root.overrides[t]
+	p := root.providers[t]
+	root.mu.Unlock()
+	if overridden {
+		return v, nil
+	}
+	if p == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotRegistered, t)
+	}
+	for i, seen := range r.path {
+		if seen == t {
+			return nil, &CycleError{append(r.path[i:len(r.path):len(r.path)], t)}
+		}
+	}
+
+	// 单例属于根容器，它的依赖也只能从根容器解析，这样单例不会捕获某个作用域的实例。
+	owner := r.scope
+	switch p.lifetime {
+	case Singleton:
+		owner = root
+	case Scoped:
+		if r.scope == root {
+			return nil, fmt.Errorf("%w: %v", ErrNoScope, t)
+		}
+	}
+	path := append(r.path[:len(r.path):len(r.path)], t)
+	if p.lifetime == Transient {
+		if owner.isClosed() {
+			return nil, ErrClosed
+		}
+		// 瞬时服务不共享，它的构造函数仍是在为 r.current 解析依赖
+		v, err := p.build(&Resolver{scope: owner, path: path, current: r.current})
+		if err != nil {
+			return nil, fmt.Errorf("di: build %v: %w", t, err)
+		}
+		return v, owner.track(t, v, false)
+	}
+
+	owner.mu.Lock()
+	if v, ok := owner.instances[t]; ok {
+		owner.mu.Unlock()
+		return v, nil
+	}
+	if owner.closed {
+		owner.mu.Unlock()
+		return nil, ErrClosed
+	}
+	if b := owner.building[t]; b != nil {
+		owner.mu.Unlock()
+		return r.wait(b)
+	}
+	b := &building{t: t, done: make(chan struct{})}
+	owner.building[t] = b
+	owner.mu.Unlock()
+
+	v, err := p.build(&Resolver{scope: owner, path: path, current: b})
+	if err != nil {
+		err = fmt.Errorf("di: build %v: %w", t, err)
 	} else {
-		fmt.Println("Single instance already created.")
+		err = owner.track(t, v, true)
+	}
+	if err != nil {
+		v = nil
+	}
+	b.v, b.err = v, err
+	owner.mu.Lock()
+	delete(owner.building, t)
+	owner.mu.Unlock()
+	close(b.done)
+	return v, err
+}
+
+// wait 等待另一个 goroutine 中同一个类型的创建完成。如果那次创建直接或间接地在等 r.current，
+// 两边会永远互相等待，这时返回 CycleError。
+func (r *Resolver) wait(b *building) (any, error) {
+	if cur := r.current; cur != nil {
+		root := r.scope.root
+		root.waitMu.Lock()
+		path := []reflect.Type{cur.t}
+		for w := b; w != nil; w = w.waitingOn {
+			path = append(path, w.t)
+			if w == cur {
+				root.waitMu.Unlock()
+				return nil, &CycleError{path}
+			}
+		}
+		cur.waitingOn = b
+		root.waitMu.Unlock()
+		defer func() {
+			root.waitMu.Lock()
+			cur.waitingOn = nil
+			root.waitMu.Unlock()
+		}()
+	}
+	<-b.done
+	return b.v, b.err
+}
+
+func (c *Container) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// track 缓存非瞬时的实例，并记下需要关闭的实例。只记录实现了 Close() error 的实例，
+// 否则反复解析的瞬时服务会让 created 无限增长。
+func (c *Container) track(t reflect.Type, v any, cache bool) error {
+	closer, _ := v.(interface{ Close() error })
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		if closer != nil {
+			closer.Close()
+		}
+		return ErrClosed
+	}
+	if cache {
+		c.instances[t] = v
 	}
+	if closer != nil {
+		c.created = append(c.created, closer)
+	}
+	return nil
+}
+
+// Close 按创建的逆序关闭实现了 Close() error 的实例，返回所有错误。关闭后不能再解析新实例。
+func (c *Container) Close() error {
+	c.mu.Lock()
+	created := c.created
+	c.created, c.instances, c.closed = nil, map[reflect.Type]any{}, true
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(created) - 1; i >= 0; i-- {
+		errs = append(errs, created[i].Close())
+	}
+	return errors.Join(errs...)
+}
+
+type Config struct {
+	DSN string
+}
+
+type DB struct {
+	dsn string
+}
+
+func (db *DB) Close() error {
+	fmt.Println("  close DB", db.dsn)
+	return nil
+}
+
+type RequestLog struct {
+	id    int
+	lines []string
+}
+
+func (l *RequestLog) Close() error {
+	fmt.Printf("  flush request %d log: %v\n", l.id, l.lines)
+	return nil
+}
+
+type UserHandler struct {
+	db  *DB
+	log *RequestLog
+}
+
+type chicken struct{}
+type egg struct{}
+
+func main() {
+	fmt.Println(getInstance() == getInstance())
+
+	// 初始化失败时不缓存错误，下次 Get 重试
+	attempts := 0
+	conn := NewLazy(func() (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", fmt.Errorf("connect attempt %d failed", attempts)
+		}
+		return "conn#1", nil
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn.Get()
+		}()
+	}
+	wg.Wait()
+	v, err := conn.Get()
+	fmt.Println("lazy:", v, err, "attempts:", attempts)
+	conn.Reset()
+	conn.Set("fake-conn")
+	fmt.Println("after Reset and Set:", conn.MustGet())
+
+	c := NewContainer()
+	Register(c, Singleton, func(r *Resolver) (*Config, error) {
+		return &Config{DSN: "postgres://localhost/app"}, nil
+	})
+	Register(c, Singleton, func(r *Resolver) (*DB, error) {
+		cfg, err := Resolve[*Config](r)
+		if err != nil {
+			return nil, err
+		}
+		return &DB{dsn: cfg.DSN}, nil
+	})
+	requests := 0
+	Register(c, Scoped, func(r *Resolver) (*RequestLog, error) {
+		requests++
+		return &RequestLog{id: requests}, nil
+	})
+	Register(c, Transient, func(r *Resolver) (*UserHandler, error) {
+		db, err := Resolve[*DB](r)
+		if err != nil {
+			return nil, err
+		}
+		log, err := Resolve[*RequestLog](r)
+		if err != nil {
+			return nil, err
+		}
+		return &UserHandler{db, log}, nil
+	})
+	Register(c, Singleton, func(r *Resolver) (*chicken, error) {
+		_, err := Resolve[*egg](r)
+		return &chicken{}, err
+	})
+	Register(c, Singleton, func(r *Resolver) (*egg, error) {
+		_, err := Resolve[*chicken](r)
+		return &egg{}, err
+	})
+
+	for i := 0; i < 2; i++ {
+		scope := c.Scope()
+		h1 := MustResolve[*UserHandler](scope)
+		h2 := MustResolve[*UserHandler](scope)
+		h1.log.lines = append(h1.log.lines, fmt.Sprintf("GET /users/%d", i))
+		fmt.Printf("request %d: same handler %v, same log %v, db %s\n", h1.log.id, h1 == h2, h1.log == h2.log, h1.db.dsn)
+		scope.Close()
+	}
+
+	_, err = Resolve[*UserHandler](c)
+	fmt.Println(err)
+	_, err = Resolve[*chicken](c)
+	fmt.Println(err)
+	var cycle *CycleError
+	fmt.Println("is cycle:", errors.As(err, &cycle))
+	_, err = Resolve[*strings.Builder](c)
+	fmt.Println(err)
+
+	// 测试中替换依赖
+	restore := Override(c, &Config{DSN: "sqlite://:memory:"})
+	fmt.Println("override:", MustResolve[*Config](c).DSN)
+	restore()
+	fmt.Println("restored:", MustResolve[*Config](c).DSN)
 
-	return singleInstance
+	fmt.Println("shutdown:")
+	fmt.Println(c.Close())
+	_, err = Resolve[*DB](c)
+	fmt.Println(err)
 }
@@ -0,0 +1,340 @@
+// 设计模式 下每个文件都是独立的 main 包，需要连同 singleton.go 一起指定：
+//
+//	cd 设计模式 && go test -race singleton.go singleton_test.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazy(t *testing.T) {
+	attempts := 0
+	l := NewLazy(func() (int, error) {
+		attempts++
+		if attempts < 3 {
+			return 0, fmt.Errorf("attempt %d failed", attempts)
+		}
+		return attempts * 10, nil
+	})
+	for i := 1; i < 3; i++ {
+		if _, err := l.Get(); err == nil {
+			t.Fatalf("Get %d succeeded, want the init error", i)
+		}
+	}
+	// 失败不被记住，第三次初始化成功后不再调用 init
+	for range 2 {
+		if v, err := l.Get(); v != 30 || err != nil || attempts != 3 {
+			t.Fatalf("Get() = %d, %v after %d attempts, want 30 after 3", v, err, attempts)
+		}
+	}
+
+	l.Set(7)
+	if v := l.MustGet(); v != 7 {
+		t.Fatalf("MustGet after Set = %d, want 7", v)
+	}
+	l.Reset()
+	if v := l.MustGet(); v != 40 || attempts != 4 {
+		t.Fatalf("MustGet after Reset = %d after %d attempts, want 40 after 4", v, attempts)
+	}
+}
+
+// TestLazyConcurrent 多个 goroutine 同时 Get 时只初始化一次，用 -race 运行。
+func TestLazyConcurrent(t *testing.T) {
+	var calls atomic.Int64
+	l := NewLazy(func() (*single, error) {
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+		return &single{}, nil
+	})
+	got := make([]*single, 16)
+	var wg sync.WaitGroup
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = l.MustGet()
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Fatalf("init called %d times, want 1", calls.Load())
+	}
+	for _, v := range got {
+		if v != got[0] {
+			t.Fatal("goroutines got different instances")
+		}
+	}
+}
+
+// recorder 关闭时把名字记到 closed 中，用来检查关闭顺序。
+type recorder struct {
+	name   string
+	closed *[]string
+}
+
+func (r *recorder) Close() error {
+	*r.closed = append(*r.closed, r.name)
+	return nil
+}
+
+type (
+	settings struct{ name string }
+	conn     struct{ *recorder }
+	session  struct{ *recorder }
+	handler  struct {
+		conn    conn
+		session session
+	}
+)
+
+// newTestContainer 注册 settings（单例）、conn（单例，依赖 settings）、session（作用域）和 handler（瞬时）。
+func newTestContainer(closed *[]string) (*Container, *atomic.Int64) {
+	var builds atomic.Int64
+	c := NewContainer()
+	Register(c, Singleton, func(r *Resolver) (*settings, error) {
+		return &settings{"prod"}, nil
+	})
+	Register(c, Singleton, func(r *Resolver) (conn, error) {
+		s, err := Resolve[*settings](r)
+		if err != nil {
+			return conn{}, err
+		}
+		return conn{&recorder{"conn " + s.name, closed}}, nil
+	})
+	Register(c, Scoped, func(r *Resolver) (session, error) {
+		return session{&recorder{fmt.Sprintf("session %d", builds.Add(1)), closed}}, nil
+	})
+	Register(c, Transient, func(r *Resolver) (*handler, error) {
+		db, err := Resolve[conn](r)
+		if err != nil {
+			return nil, err
+		}
+		s, err := Resolve[session](r)
+		if err != nil {
+			return nil, err
+		}
+		return &handler{db, s}, nil
+	})
+	return c, &builds
+}
+
+func TestLifetimes(t *testing.T) {
+	var closed []string
+	c, builds := newTestContainer(&closed)
+	s1, s2 := c.Scope(), c.Scope()
+	h1, h2, h3 := MustResolve[*handler](s1), MustResolve[*handler](s1), MustResolve[*handler](s2)
+	if h1 == h2 {
+		t.Fatal("transient service resolved twice gave the same instance")
+	}
+	if h1.session != h2.session || h1.session == h3.session || builds.Load() != 2 {
+		t.Fatalf("scoped service: same within a scope %v, shared across scopes %v, %d builds, want 2",
+			h1.session == h2.session, h1.session == h3.session, builds.Load())
+	}
+	if h1.conn != h3.conn || h1.conn != MustResolve[conn](c) {
+		t.Fatal("singleton differs between scopes and the root")
+	}
+	if _, err := Resolve[session](c); !errors.Is(err, ErrNoScope) {
+		t.Fatalf("scoped service from the root: %v, want ErrNoScope", err)
+	}
+	if _, err := Resolve[*handler](c); !errors.Is(err, ErrNoScope) {
+		t.Fatalf("transient service depending on a scoped one from the root: %v, want ErrNoScope", err)
+	}
+	if _, err := Resolve[*recorder](c); !errors.Is(err, ErrNotRegistered) {
+		t.Fatalf("unregistered service: %v, want ErrNotRegistered", err)
+	}
+}
+
+// TestClose 作用域只关闭自己创建的实例，根容器按创建的逆序关闭，关闭后不能再解析。
+func TestClose(t *testing.T) {
+	var closed []string
+	c, _ := newTestContainer(&closed)
+	scope := c.Scope()
+	MustResolve[*handler](scope)
+	if err := scope.Close(); err != nil || !slices.Equal(closed, []string{"session 1"}) {
+		t.Fatalf("scope.Close() = %v, closed %v, want [session 1]", err, closed)
+	}
+	if _, err := Resolve[session](scope); !errors.Is(err, ErrClosed) {
+		t.Fatalf("resolving from a closed scope: %v, want ErrClosed", err)
+	}
+
+	// 把 session 改注册成依赖 conn 的单例：conn 先创建，所以关闭时 other 在 conn 之前
+	Register(c, Singleton, func(r *Resolver) (session, error) {
+		if _, err := Resolve[conn](r); err != nil {
+			return session{}, err
+		}
+		return session{&recorder{"other", &closed}}, nil
+	})
+	MustResolve[session](c)
+	closed = nil
+	if err := c.Close(); err != nil || !slices.Equal(closed, []string{"other", "conn prod"}) {
+		t.Fatalf("Close() = %v, closed %v, want [other conn prod]", err, closed)
+	}
+	if _, err := Resolve[conn](c); !errors.Is(err, ErrClosed) {
+		t.Fatalf("resolving from a closed container: %v, want ErrClosed", err)
+	}
+}
+
+func TestOverride(t *testing.T) {
+	var closed []string
+	c, _ := newTestContainer(&closed)
+	fake := conn{&recorder{"fake", &closed}}
+	restore := Override(c, fake)
+	if got := MustResolve[*handler](c.Scope()).conn; got != fake {
+		t.Fatalf("handler in a scope got %v, want the override", got.name)
+	}
+	restore()
+	if got := MustResolve[conn](c); got == fake || got.name != "conn prod" {
+		t.Fatalf("after restore got %s, want conn prod", got.name)
+	}
+	// 嵌套的替身恢复后回到外层的替身
+	restoreOuter := Override(c, &settings{"outer"})
+	restoreInner := Override(c, &settings{"inner"})
+	restoreInner()
+	if got := MustResolve[*settings](c).name; got != "outer" {
+		t.Fatalf("after restoring the inner override got %s, want outer", got)
+	}
+	restoreOuter()
+	if err := c.Close(); err != nil || slices.Contains(closed, "fake") {
+		t.Fatalf("Close() = %v, closed %v: overrides must not be closed", err, closed)
+	}
+}
+
+type (
+	hen  struct{}
+	seed struct{}
+)
+
+func TestCycle(t *testing.T) {
+	c := NewContainer()
+	Register(c, Singleton, func(r *Resolver) (*hen, error) {
+		_, err := Resolve[*seed](r)
+		return &hen{}, err
+	})
+	Register(c, Transient, func(r *Resolver) (*seed, error) {
+		_, err := Resolve[*hen](r)
+		return &seed{}, err
+	})
+	_, err := Resolve[*hen](c)
+	var cycle *CycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Resolve = %v, want a CycleError", err)
+	}
+	want := []reflect.Type{typeOf[*hen](), typeOf[*seed](), typeOf[*hen]()}
+	if !slices.Equal(cycle.Path, want) {
+		t.Fatalf("cycle path %v, want %v", cycle.Path, want)
+	}
+}
+
+// TestConcurrentCycle 两个 goroutine 各自开始创建环上的一个单例，互相等待时其中一方发现依赖环，两边都返回错误。
+func TestConcurrentCycle(t *testing.T) {
+	c := NewContainer()
+	var started sync.WaitGroup
+	started.Add(2)
+	Register(c, Singleton, func(r *Resolver) (*hen, error) {
+		started.Done()
+		started.Wait()
+		_, err := Resolve[*seed](r)
+		return &hen{}, err
+	})
+	Register(c, Singleton, func(r *Resolver) (*seed, error) {
+		started.Done()
+		started.Wait()
+		_, err := Resolve[*hen](r)
+		return &seed{}, err
+	})
+	errs := make(chan error, 2)
+	go func() {
+		_, err := Resolve[*hen](c)
+		errs <- err
+	}()
+	go func() {
+		_, err := Resolve[*seed](c)
+		errs <- err
+	}()
+	for range 2 {
+		select {
+		case err := <-errs:
+			var cycle *CycleError
+			if !errors.As(err, &cycle) {
+				t.Fatalf("Resolve = %v, want a CycleError", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("concurrent cycle deadlocked")
+		}
+	}
+}
+
+// TestConcurrentBuilds 同一个单例并发解析时只创建一次；不同类型的创建互不阻塞，
+// 构造函数里直接用容器解析别的服务也不会死锁。
+func TestConcurrentBuilds(t *testing.T) {
+	c := NewContainer()
+	var builds atomic.Int64
+	seedStarted := make(chan struct{})
+	Register(c, Singleton, func(r *Resolver) (*seed, error) {
+		builds.Add(1)
+		close(seedStarted)
+		time.Sleep(10 * time.Millisecond)
+		return &seed{}, nil
+	})
+	Register(c, Singleton, func(r *Resolver) (*hen, error) {
+		// seed 在另一个 goroutine 中创建，hen 的创建不能等它结束才开始
+		<-seedStarted
+		_, err := Resolve[*seed](c)
+		return &hen{}, err
+	})
+
+	seeds := make(chan *seed, 8)
+	for range cap(seeds) {
+		go func() {
+			seeds <- MustResolve[*seed](c)
+		}()
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := Resolve[*hen](c)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("resolving hen deadlocked")
+	}
+	first := <-seeds
+	for range cap(seeds) - 1 {
+		if <-seeds != first {
+			t.Fatal("concurrent resolves got different singletons")
+		}
+	}
+	if builds.Load() != 1 {
+		t.Fatalf("singleton built %d times, want 1", builds.Load())
+	}
+}
+
+// TestBuildError 创建失败不会被缓存，下次解析重新创建。
+func TestBuildError(t *testing.T) {
+	c := NewContainer()
+	fail := true
+	Register(c, Singleton, func(r *Resolver) (*seed, error) {
+		if fail {
+			return nil, errors.New("not yet")
+		}
+		return &seed{}, nil
+	})
+	if _, err := Resolve[*seed](c); err == nil {
+		t.Fatal("Resolve succeeded, want the build error")
+	}
+	fail = false
+	if _, err := Resolve[*seed](c); err != nil {
+		t.Fatalf("Resolve after the failure = %v", err)
+	}
+}
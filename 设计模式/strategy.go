@@ -1,84 +1,209 @@
 /**
  * 策略模式是一种行为设计模式， 它能让你定义一系列算法， 并将每种算法分别放入独立的类中， 以使算法的对象能够相互替换。
  * https://refactoringguru.cn/design-patterns/strategy
+ * 这里的策略是缓存的淘汰算法，Cache 和五种淘汰策略在 leetcode-go/cache 中，可以在运行时互相替换，
+ * 实现 cache.EvictionAlgo 接口就能加入新的策略，如下面的随机淘汰。
+ * notes: 笔记/redis.md#缓存淘汰策略
  */
 package main
 
-import "fmt"
-
-type EvictionAlgo interface {
-	evict(c *Cache)
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"leetcode-go/cache"
+)
+
+// workload 热点 key 服从 Zipf 分布，每隔一段插入一次只访问一次的顺序扫描。
+func workload(n int) []int {
+	r := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(r, 1.1, 1, 999)
+	keys := make([]int, 0, n)
+	for len(keys) < n {
+		if len(keys)%2000 == 1000 {
+			for i := 0; i < 200; i++ {
+				keys = append(keys, 10000+len(keys))
+			}
+			continue
+		}
+		keys = append(keys, int(zipf.Uint64()))
+	}
+	return keys[:n]
 }
 
-type Fifo struct{}
-
-func (l *Fifo) evict(c *Cache) {
-	fmt.Println("Evicting by fifo strategy")
+// randomEvict 随机淘汰，对应 Redis 的 allkeys-random。key 存在切片中，
+// 删除时用最后一个 key 填补空位，所有操作都是 O(1)。
+type randomEvict[K comparable] struct {
+	r     *rand.Rand
+	keys  []K
+	index map[K]int
 }
 
-type Lru struct{}
-
-func (l *Lru) evict(c *Cache) {
-	fmt.Println("Evicting by lru strategy")
+func newRandomEvict[K comparable](seed int64) *randomEvict[K] {
+	return &randomEvict[K]{r: rand.New(rand.NewSource(seed))}
 }
 
-type Lfu struct{}
-
-func (l *Lfu) evict(c *Cache) {
-	fmt.Println("Evicting by lfu strategy")
+func (e *randomEvict[K]) Reset(capacity int) {
+	e.keys = make([]K, 0, capacity)
+	e.index = make(map[K]int, capacity)
 }
 
-type Cache struct {
-	storage      map[string]string
-	evictionAlgo EvictionAlgo
-	capacity     int
-	maxCapacity  int
+func (e *randomEvict[K]) Add(key K) {
+	e.index[key] = len(e.keys)
+	e.keys = append(e.keys, key)
 }
 
-func initCache(e EvictionAlgo) *Cache {
-	storage := make(map[string]string)
-	return &Cache{
-		storage:      storage,
-		evictionAlgo: e,
-		capacity:     0,
-		maxCapacity:  2,
+func (e *randomEvict[K]) Touch(K) {}
+
+func (e *randomEvict[K]) Remove(key K) {
+	i, ok := e.index[key]
+	if !ok {
+		return
 	}
+	last := e.keys[len(e.keys)-1]
+	e.keys[i], e.index[last] = last, i
+	e.keys = e.keys[:len(e.keys)-1]
+	delete(e.index, key)
 }
 
-func (c *Cache) setEvictionAlgo(e EvictionAlgo) {
-	c.evictionAlgo = e
+func (e *randomEvict[K]) Evict(K) K {
+	key := e.keys[e.r.Intn(len(e.keys))]
+	e.Remove(key)
+	return key
 }
 
-func (c *Cache) add(key, value string) {
-	if c.capacity == c.maxCapacity {
-		c.evict()
-	}
-	c.capacity++
-	c.storage[key] = value
+// Keys 随机淘汰没有顺序，按当前存放的顺序返回。
+func (e *randomEvict[K]) Keys() []K {
+	return slices.Clone(e.keys)
 }
 
-func (c *Cache) get(key string) {
-	delete(c.storage, key)
-}
-
-func (c *Cache) evict() {
-	c.evictionAlgo.evict(c)
-	c.capacity--
+// contents 按字典序列出缓存中的 key，不影响统计和淘汰顺序。
+func contents[V any](c *cache.Cache[string, V]) []string {
+	keys := c.Keys()
+	slices.Sort(keys)
+	return keys
 }
 
 func main() {
-	lfu := &Lfu{}
-	cache := initCache(lfu)
-
-	cache.add("a", "1")
-	cache.add("b", "2")
-	cache.add("c", "3")
+	lfu := cache.NewLfu[string]()
+	c := cache.New[string, string](lfu, 2)
+
+	c.Add("a", "1")
+	c.Add("b", "2")
+	c.Get("a")
+	c.Add("c", "3")
+	fmt.Println("lfu :", contents(c))
+
+	lru := cache.NewLru[string]()
+	c.SetEvictionAlgo(lru)
+	c.Get("a")
+	c.Add("d", "4")
+	fmt.Println("lru :", contents(c))
+
+	fifo := cache.NewFifo[string]()
+	c.SetEvictionAlgo(fifo)
+	c.Get("d")
+	c.Add("e", "5")
+	fmt.Println("fifo:", contents(c))
+
+	v, ok := c.Get("a")
+	fmt.Println("get a:", v, ok)
+	v, ok = c.Get("e")
+	fmt.Println("get e:", v, ok)
+	fmt.Println(c.Stats())
+
+	// 同一负载下各策略的命中率
+	keys := workload(100000)
+	algos := []struct {
+		name string
+		algo cache.EvictionAlgo[int]
+	}{
+		{"fifo", cache.NewFifo[int]()},
+		{"lru", cache.NewLru[int]()},
+		{"lfu", cache.NewLfu[int]()},
+		{"2q", cache.NewTwoQ[int]()},
+		{"arc", cache.NewArc[int]()},
+		{"rand", newRandomEvict[int](1)},
+	}
+	for _, a := range algos {
+		c := cache.New[int, int](a.algo, 100)
+		for _, k := range keys {
+			if _, ok := c.Get(k); !ok {
+				c.Add(k, k)
+			}
+		}
+		fmt.Printf("%-4s %s\n", a.name, c.Stats())
+	}
+	readThrough()
 
-	lru := &Lru{}
-	cache.setEvictionAlgo(lru)
-	cache.add("d", "4")
+	sc := cache.NewSharded[string, int](4, 8, func() cache.EvictionAlgo[string] { return cache.NewLru[string]() }, true)
+	for i, k := range []string{"a", "b", "c", "d", "e", "f"} {
+		sc.Add(k, i)
+	}
+	v2, ok := sc.Get("c")
+	fmt.Println("sharded get c:", v2, ok, "len:", sc.Len(), sc.Stats())
+}
 
-	fifo := &Fifo{}
-	cache.setEvictionAlgo(fifo)
-	cache.add("e", "5")
+var errUserNotFound = errors.New("user not found")
+
+// readThrough 演示 TTL、加载、负缓存和回调，时间由 FakeClock 控制。
+func readThrough() {
+	clock := cache.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := cache.New[int, string](cache.NewLru[int](), 3)
+	c.SetClock(clock)
+	c.SetDefaultTTL(time.Minute)
+	c.SetOnEvict(func(key int, value string, reason cache.EvictReason) {
+		fmt.Printf("  %s %d=%s\n", reason, key, value)
+	})
+
+	var loads sync.Map
+	c.SetLoader(func(id int) (string, error) {
+		n, _ := loads.LoadOrStore(id, new(int))
+		*n.(*int)++
+		time.Sleep(10 * time.Millisecond) // 模拟查库
+		if id < 0 {
+			return "", errUserNotFound
+		}
+		return fmt.Sprintf("user%d", id), nil
+	}, 30*time.Second)
+
+	// 100 个 goroutine 同时加载同一个 key，Loader 只调用一次
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.GetOrLoad(1)
+		}()
+	}
+	wg.Wait()
+	n, _ := loads.Load(1)
+	fmt.Println("loads of key 1:", *n.(*int))
+
+	_, err := c.GetOrLoad(-1)
+	_, err2 := c.GetOrLoad(-1)
+	n, _ = loads.Load(-1)
+	fmt.Println("key -1:", err, err2, "loads:", *n.(*int))
+
+	c.AddWithTTL(2, "session", 10*time.Second)
+	c.AddWithTTL(3, "config", 0)
+	stop := c.StartJanitor(5 * time.Second)
+	defer stop()
+
+	clock.Advance(15 * time.Second)
+	_, ok := c.Get(2)
+	fmt.Println("after 15s, key 2 cached:", ok)
+	clock.Advance(time.Minute)
+	_, ok = c.Get(1)
+	fmt.Println("after 75s, key 1 cached:", ok)
+	c.Add(4, "a")
+	c.Add(5, "b")
+	c.Add(6, "c")
+	st := c.Stats()
+	fmt.Printf("%s expirations=%d loads=%d load errors=%d negative hits=%d\n",
+		st, st.Expirations, st.Loads, st.LoadErrors, st.NegativeHits)
 }
@@ -4,88 +4,366 @@
  */
 package main
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"leetcode-go/notify"
+	"leetcode-go/otp"
+)
 
 type IOtp interface {
-	genRandomOTP(int) string
-	saveOTPCache(string)
-	getMessage(string) string
-	sendNotification(string) error
+	genRandomOTP(digits int) (string, error)
+	saveOTPCache(to, code string) error
+	discardOTPCache(to, code string)
+	getMessage(code string) (notify.Message, error)
+	sendNotification(to string, message notify.Message) error
+	verifyOTP(to, code string) error
 }
 
 type Otp struct {
 	iOtp IOtp
 }
 
-func (o *Otp) genAndSendOTP(otpLength int) error {
-	otp := o.iOtp.genRandomOTP(otpLength)
-	o.iOtp.saveOTPCache(otp)
-	message := o.iOtp.getMessage(otp)
-	err := o.iOtp.sendNotification(message)
+// genAndSendOTP 固定的流程：生成、保存、组装消息、发送，每一步由具体的渠道实现。
+// 保存时可能因为重发过于频繁而失败，这时不会发送。保存之后的步骤失败时撤销保存，
+// 用户没收到验证码，可以立即重发。
+func (o *Otp) genAndSendOTP(to string, otpLength int) error {
+	code, err := o.iOtp.genRandomOTP(otpLength)
 	if err != nil {
 		return err
 	}
-	return nil
+	if err := o.iOtp.saveOTPCache(to, code); err != nil {
+		return err
+	}
+	message, err := o.iOtp.getMessage(code)
+	if err == nil {
+		err = o.iOtp.sendNotification(to, message)
+	}
+	if err != nil {
+		o.iOtp.discardOTPCache(to, code)
+	}
+	return err
+}
+
+// verifyOTP 去掉用户输入中的空白后交给具体的渠道校验。
+func (o *Otp) verifyOTP(to, input string) error {
+	return o.iOtp.verifyOTP(to, strings.Join(strings.Fields(input), ""))
 }
 
+const (
+	codeTTL     = 5 * time.Minute
+	sendTimeout = 10 * time.Second
+)
+
+// otpData 消息模版的数据。
+type otpData struct {
+	Code string
+	TTL  time.Duration
+}
+
+func (d otpData) Minutes() int {
+	return int(d.TTL / time.Minute)
+}
+
+// 每个语言区域一组模版，zh-CN 等找不到时退回 zh，其他语言退回 en
+const (
+	templatesEn = `
+{{define "sms"}}[Acme] Your login code is {{.Code}}. It expires in {{.Minutes}} minutes.{{end}}
+{{define "email.subject"}}Your Acme login code{{end}}
+{{define "email.body"}}Hi,
+
+Your login code is {{.Code}}. It expires in {{.Minutes}} minutes.
+If you did not try to sign in, you can ignore this email.
+{{end}}`
+	templatesZh = `
+{{define "sms"}}【Acme】您的登录验证码为 {{.Code}}，{{.Minutes}} 分钟内有效，请勿泄露。{{end}}
+{{define "email.subject"}}Acme 登录验证码{{end}}
+{{define "email.body"}}您好，
+
+您的登录验证码为 {{.Code}}，{{.Minutes}} 分钟内有效。
+如果不是您本人操作，请忽略这封邮件。
+{{end}}`
+)
+
+// Sms 短信验证码：crypto/rand 生成的随机数字，经短信网关的 Webhook 发出。
 type Sms struct {
 	Otp
+	store     *otp.Store
+	templates *notify.Templates
+	locale    string
+	channel   notify.Channel
 }
 
-func (s *Sms) genRandomOTP(len int) string {
-	randomOTP := "1234"
-	fmt.Printf("SMS: generating random otp %s\n", randomOTP)
-	return randomOTP
+func (s *Sms) genRandomOTP(digits int) (string, error) {
+	return otp.Random(digits)
 }
 
-func (s *Sms) saveOTPCache(otp string) {
-	fmt.Printf("SMS: saving otp: %s to cache\n", otp)
+func (s *Sms) saveOTPCache(to, code string) error {
+	return s.store.Save(to, code)
 }
 
-func (s *Sms) getMessage(otp string) string {
-	return "SMS OTP for login is " + otp
+func (s *Sms) discardOTPCache(to, code string) {
+	s.store.Discard(to, code)
 }
 
-func (s *Sms) sendNotification(message string) error {
-	fmt.Printf("SMS: sending sms: %s\n", message)
-	return nil
+func (s *Sms) getMessage(code string) (notify.Message, error) {
+	body, err := s.templates.Render(s.locale, "sms", otpData{code, codeTTL})
+	return notify.Message{Body: body}, err
 }
 
+func (s *Sms) sendNotification(to string, message notify.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	message.To = to
+	return s.channel.Send(ctx, message)
+}
+
+func (s *Sms) verifyOTP(to, code string) error {
+	return s.store.Verify(to, code)
+}
+
+// Email 邮件验证码：HOTP，每发一次计数器加一，不知道密钥就无法预测下一个验证码。
 type Email struct {
 	Otp
+	secret    []byte
+	counter   atomic.Uint64
+	store     *otp.Store
+	templates *notify.Templates
+	locale    string
+	channel   notify.Channel
+}
+
+func (s *Email) genRandomOTP(digits int) (string, error) {
+	return otp.HOTP(s.secret, s.counter.Add(1), digits)
+}
+
+func (s *Email) saveOTPCache(to, code string) error {
+	return s.store.Save(to, code)
+}
+
+func (s *Email) discardOTPCache(to, code string) {
+	s.store.Discard(to, code)
 }
 
-func (s *Email) genRandomOTP(len int) string {
-	randomOTP := "1234"
-	fmt.Printf("EMAIL: generating random otp %s\n", randomOTP)
-	return randomOTP
+func (s *Email) getMessage(code string) (notify.Message, error) {
+	data := otpData{code, codeTTL}
+	subject, err := s.templates.Render(s.locale, "email.subject", data)
+	if err != nil {
+		return notify.Message{}, err
+	}
+	body, err := s.templates.Render(s.locale, "email.body", data)
+	return notify.Message{Subject: subject, Body: body}, err
 }
 
-func (s *Email) saveOTPCache(otp string) {
-	fmt.Printf("EMAIL: saving otp: %s to cache\n", otp)
+func (s *Email) sendNotification(to string, message notify.Message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	message.To = to
+	return s.channel.Send(ctx, message)
 }
 
-func (s *Email) getMessage(otp string) string {
-	return "EMAIL OTP for login is " + otp
+func (s *Email) verifyOTP(to, code string) error {
+	return s.store.Verify(to, code)
 }
 
-func (s *Email) sendNotification(message string) error {
-	fmt.Printf("EMAIL: send email: %s\n", message)
-	return nil
+// webhookReceiver 代替短信网关和审计服务：校验签名，前 failFirst 次请求返回错误以演示重试。
+type webhookReceiver struct {
+	name      string
+	secret    []byte
+	failFirst int
+
+	mu       sync.Mutex
+	requests int
+	received []notify.WebhookPayload
+}
+
+func (h *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.requests++
+	body, err := notify.VerifyWebhook(r, h.secret, 5*time.Minute, time.Now())
+	if err != nil {
+		fmt.Printf("  %s: request %d rejected: %v\n", h.name, h.requests, err)
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case h.requests == 1 && h.failFirst >= 1:
+		fmt.Printf("  %s: request %d -> 503\n", h.name, h.requests)
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+		return
+	case h.requests <= h.failFirst:
+		fmt.Printf("  %s: request %d -> 429 Retry-After: 1\n", h.name, h.requests)
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "slow down", http.StatusTooManyRequests)
+		return
+	}
+	var p notify.WebhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// 重试时 ID 不变，按 ID 去重
+	for _, seen := range h.received {
+		if seen.ID == p.ID {
+			return
+		}
+	}
+	h.received = append(h.received, p)
+	summary := p.Subject
+	if summary == "" {
+		summary = p.Body
+	}
+	fmt.Printf("  %s: request %d -> to %s: %s\n", h.name, h.requests, p.To, summary)
+}
+
+func (h *webhookReceiver) last() notify.WebhookPayload {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.received[len(h.received)-1]
+}
+
+var codePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// readMail 解码最后一封邮件的标题和正文。
+func readMail(server *notify.FakeSMTPServer) (to, subject, body string) {
+	mails := server.Mails()
+	m, err := mails[len(mails)-1].Parse()
+	if err != nil {
+		panic(err)
+	}
+	subject, _ = new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	b, _ := io.ReadAll(quotedprintable.NewReader(m.Body))
+	return m.Header.Get("To"), subject, string(b)
 }
 
 func main() {
-	smsOTP := &Sms{}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	opts := otp.StoreOptions{TTL: codeTTL, MaxAttempts: 3, ResendInterval: time.Minute, Now: clock}
+
+	templates := notify.NewTemplates("en").MustParse("en", templatesEn).MustParse("zh", templatesZh)
+
+	// 短信网关：前两次请求失败，Webhook 退避后重试
+	gatewaySecret := []byte("sms-gateway-secret")
+	gateway := &webhookReceiver{name: "sms gateway", secret: gatewaySecret, failFirst: 2}
+	gatewayServer := httptest.NewServer(gateway)
+	defer gatewayServer.Close()
+	smsChannel := notify.NewWebhook(notify.WebhookOptions{
+		URL:        gatewayServer.URL,
+		Secret:     gatewaySecret,
+		Backoff:    10 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+	})
+
+	smsOTP := &Sms{store: otp.NewStore(opts), templates: templates, locale: "zh-CN", channel: smsChannel}
 	o := Otp{
 		iOtp: smsOTP,
 	}
-	o.genAndSendOTP(4)
+	phone := "+86 138 0000 0000"
+	fmt.Println("send:", o.genAndSendOTP(phone, 6))
+	code := codePattern.FindString(gateway.last().Body)
+	fmt.Println("wrong code:", o.verifyOTP(phone, "000000"))
+	fmt.Println("right code:", o.verifyOTP(phone, code))
+	fmt.Println("reuse:", o.verifyOTP(phone, code))
+
+	fmt.Println("resend:", o.genAndSendOTP(phone, 6))
+	now = now.Add(30 * time.Second)
+	err := o.genAndSendOTP(phone, 6)
+	var throttle *otp.ThrottleError
+	fmt.Println("resend after 30s:", err, errors.As(err, &throttle) && throttle.RetryAfter == 30*time.Second)
+	now = now.Add(6 * time.Minute)
+	fmt.Println("after 6m30s:", o.verifyOTP(phone, codePattern.FindString(gateway.last().Body)))
+
+	o.genAndSendOTP(phone, 6)
+	for range 3 {
+		fmt.Println("guess:", o.verifyOTP(phone, "123456"))
+	}
 
 	fmt.Println()
 
-	emailOTP := &Email{}
+	// 邮件同时发给 SMTP 服务器和审计 Webhook
+	mailServer, err := notify.NewFakeSMTPServer("otp", "smtp-password")
+	if err != nil {
+		panic(err)
+	}
+	defer mailServer.Close()
+	smtpChannel, err := notify.NewSMTP(notify.SMTPOptions{
+		Addr: mailServer.Addr,
+		From: "Acme <no-reply@acme.test>",
+		Auth: smtp.PlainAuth("", "otp", "smtp-password", "127.0.0.1"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	auditSecret := []byte("audit-secret")
+	audit := &webhookReceiver{name: "audit", secret: auditSecret}
+	auditServer := httptest.NewServer(audit)
+	defer auditServer.Close()
+	auditChannel := notify.NewWebhook(notify.WebhookOptions{URL: auditServer.URL, Secret: auditSecret})
+
+	secret, err := otp.NewSecret(20)
+	if err != nil {
+		panic(err)
+	}
+	emailOTP := &Email{
+		secret:    secret,
+		store:     otp.NewStore(opts),
+		templates: templates,
+		locale:    "fr-FR",
+		channel:   notify.Fanout{smtpChannel, auditChannel},
+	}
 	o = Otp{
 		iOtp: emailOTP,
 	}
-	o.genAndSendOTP(4)
+	fmt.Println("send:", o.genAndSendOTP("Alice <alice@example.com>", 6))
+	to, subject, body := readMail(mailServer)
+	fmt.Printf("mail to %s, subject %q:\n%s", to, subject, body)
+	code = codePattern.FindString(body)
+	fmt.Println("verify with spaces:", o.verifyOTP("Alice <alice@example.com>", code[:3]+" "+code[3:]))
+
+	emailOTP.locale = "zh"
+	o.genAndSendOTP("bob@example.com", 6)
+	to, subject, body = readMail(mailServer)
+	fmt.Printf("mail to %s, subject %q:\n%s", to, subject, body)
+
+	// 签名密钥不对的 Webhook 收到 401，不重试；Fanout 中其他渠道照常发送，错误合并返回
+	forged := notify.NewWebhook(notify.WebhookOptions{URL: auditServer.URL, Secret: []byte("wrong")})
+	emailOTP.channel = notify.Fanout{smtpChannel, forged}
+	fmt.Println("fan-out with a bad webhook:", o.genAndSendOTP("carol@example.com", 6))
+	fmt.Println("mails delivered:", len(mailServer.Mails()))
+	// 发送失败时撤销了保存，不受重发间隔限制
+	emailOTP.channel = smtpChannel
+	fmt.Println("resend after the failure:", o.genAndSendOTP("carol@example.com", 6))
+
+	// 身份验证器应用用 TOTP，服务端不保存验证码，只按时间重新计算
+	fmt.Println()
+	app, err := otp.TOTP(secret, now, 30*time.Second, 6)
+	if err != nil {
+		panic(err)
+	}
+	validate := func(t time.Time) bool {
+		ok, err := otp.ValidateTOTP(secret, app, t, 30*time.Second, 6, 1)
+		if err != nil {
+			panic(err)
+		}
+		return ok
+	}
+	fmt.Println("TOTP now:", validate(now))
+	fmt.Println("TOTP 20s later:", validate(now.Add(20*time.Second)))
+	fmt.Println("TOTP 2m later:", validate(now.Add(2*time.Minute)))
 }